@@ -25,6 +25,21 @@ import (
 const (
 	ReasonSynced  = "Synced"
 	ReasonErrored = "Errored"
+	ReasonPending = "Pending"
+
+	ReasonDrifted  = "Drifted"
+	ReasonRepaired = "Repaired"
+	ReasonInSync   = "InSync"
+)
+
+const (
+	// AnnotationRegenerate triggers a new run of the generator referenced in .spec.selector.generatorRef
+	// whenever its value changes.
+	AnnotationRegenerate = "pushsecret.external-secrets.io/regenerate"
+	// AnnotationGeneratorRef records the generator which produced the data of a generator state Secret.
+	AnnotationGeneratorRef = "pushsecret.external-secrets.io/generator-ref"
+	// LabelGeneratorState marks Secrets which hold the generated data of a PushSecret.
+	LabelGeneratorState = "pushsecret.external-secrets.io/generator-state"
 )
 
 type PushSecretStoreRef struct {
@@ -41,12 +56,16 @@ type PushSecretStoreRef struct {
 	Kind string `json:"kind,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=Replace;IfNotExists
+// +kubebuilder:validation:Enum=Replace;IfNotExists;Adopt
 type PushSecretUpdatePolicy string
 
 const (
 	PushSecretUpdatePolicyReplace     PushSecretUpdatePolicy = "Replace"
 	PushSecretUpdatePolicyIfNotExists PushSecretUpdatePolicy = "IfNotExists"
+	// PushSecretUpdatePolicyAdopt replaces remote secrets like Replace, but also takes over remote secrets
+	// owned by another PushSecret. Remote secrets not managed by external-secrets are only taken over
+	// if the metadata of the data sets adoptUnmanaged.
+	PushSecretUpdatePolicyAdopt PushSecretUpdatePolicy = "Adopt"
 )
 
 // +kubebuilder:validation:Enum=Delete;None
@@ -65,12 +84,37 @@ const (
 	PushSecretConversionReverseUnicode PushSecretConversionStrategy = "ReverseUnicode"
 )
 
+// +kubebuilder:validation:Enum=Repush;Report
+type PushSecretDriftPolicy string
+
+const (
+	PushSecretDriftPolicyRepush PushSecretDriftPolicy = "Repush"
+	PushSecretDriftPolicyReport PushSecretDriftPolicy = "Report"
+)
+
+// PushSecretVerification configures the verification of pushed secrets.
+type PushSecretVerification struct {
+	// ReadAfterWrite reads every pushed value back from the provider
+	// and fails the push if it does not match the pushed value.
+	// +optional
+	ReadAfterWrite bool `json:"readAfterWrite,omitempty"`
+	// DriftCheckInterval is the interval at which the remote values are compared with the values last pushed.
+	// Values whose source did not change are only pushed again if they drifted.
+	// Drift checks are disabled if not set.
+	// +optional
+	DriftCheckInterval *metav1.Duration `json:"driftCheckInterval,omitempty"`
+	// DriftPolicy to handle remote values which drifted from the pushed values. Possible Values: "Repush/Report". Defaults to "Repush".
+	// +kubebuilder:default="Repush"
+	// +optional
+	DriftPolicy PushSecretDriftPolicy `json:"driftPolicy,omitempty"`
+}
+
 // PushSecretSpec configures the behavior of the PushSecret.
 type PushSecretSpec struct {
 	// The Interval to which External Secrets will try to push a secret definition
 	RefreshInterval *metav1.Duration     `json:"refreshInterval,omitempty"`
 	SecretStoreRefs []PushSecretStoreRef `json:"secretStoreRefs"`
-	// UpdatePolicy to handle Secrets in the provider. Possible Values: "Replace/IfNotExists". Defaults to "Replace".
+	// UpdatePolicy to handle Secrets in the provider. Possible Values: "Replace/IfNotExists/Adopt". Defaults to "Replace".
 	// +kubebuilder:default="Replace"
 	// +optional
 	UpdatePolicy PushSecretUpdatePolicy `json:"updatePolicy,omitempty"`
@@ -85,16 +129,41 @@ type PushSecretSpec struct {
 	// Template defines a blueprint for the created Secret resource.
 	// +optional
 	Template *esv1beta1.ExternalSecretTemplate `json:"template,omitempty"`
+	// Verification configures read-after-write verification and drift checks of the pushed secrets.
+	// +optional
+	Verification *PushSecretVerification `json:"verification,omitempty"`
 }
 
 type PushSecretSecret struct {
 	// Name of the Secret. The Secret must exist in the same namespace as the PushSecret manifest.
-	Name string `json:"name"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Selector selects all Secrets with matching labels in the namespace of the PushSecret.
+	// Every key of the selected Secrets is pushed to the remote key rendered from RemoteKeyTemplate.
+	// Cannot be used together with Name or .spec.data.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// RemoteKeyTemplate is a Go template which renders the remote key for every key of a selected Secret.
+	// The template receives the namespace, name and key of the Secret as .namespace, .name and .key.
+	// Defaults to "{{ .namespace }}/{{ .name }}/{{ .key }}".
+	// +optional
+	RemoteKeyTemplate string `json:"remoteKeyTemplate,omitempty"`
+	// Rewrite the keys of the selected Secrets before the remote key is rendered.
+	// +optional
+	Rewrite []esv1beta1.ExternalSecretRewrite `json:"rewrite,omitempty"`
 }
 
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
 type PushSecretSelector struct {
 	// Select a Secret to Push.
-	Secret PushSecretSecret `json:"secret"`
+	// +optional
+	Secret *PushSecretSecret `json:"secret,omitempty"`
+	// Point to a generator to create the data to push.
+	// The generated data is stored in a Secret owned by the PushSecret
+	// and is only regenerated when the regenerate annotation changes.
+	// +optional
+	GeneratorRef *esv1beta1.GeneratorRef `json:"generatorRef,omitempty"`
 }
 
 type PushSecretRemoteRef struct {
@@ -156,6 +225,9 @@ type PushSecretConditionType string
 
 const (
 	PushSecretReady PushSecretConditionType = "Ready"
+	// PushSecretDrifted reports whether remote values drifted from the pushed values.
+	// It is only set if drift checks are enabled.
+	PushSecretDrifted PushSecretConditionType = "Drifted"
 )
 
 // PushSecretStatusCondition indicates the status of the PushSecret.
@@ -175,6 +247,37 @@ type PushSecretStatusCondition struct {
 
 type SyncedPushSecretsMap map[string]map[string]PushSecretData
 
+// PushSecretStoreStatus reports the outcome of the last push to a store.
+type PushSecretStoreStatus struct {
+	// Store is the store formatted as <kind>/<name>, as in SyncedPushSecrets.
+	Store string `json:"store"`
+	// +optional
+	Conditions []PushSecretStatusCondition `json:"conditions,omitempty"`
+	// Refs reports the outcome of the last push of every remote ref of the store.
+	// +optional
+	Refs []PushSecretRemoteRefStatus `json:"refs,omitempty"`
+}
+
+// PushSecretRemoteRefStatus reports the outcome of the last push of a remote ref.
+type PushSecretRemoteRefStatus struct {
+	// RemoteRef is the remote key, followed by the property if set, as in SyncedPushSecrets.
+	RemoteRef string `json:"remoteRef"`
+	// LastPushTime is the time the remote ref was last pushed successfully
+	// with a value or version different from the previous push.
+	// +optional
+	LastPushTime *metav1.Time `json:"lastPushTime,omitempty"`
+	// Version of the remote secret reported by the provider after the last push.
+	// It is only set for providers which version their secrets.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Hash of the value pushed last.
+	// +optional
+	Hash string `json:"hash,omitempty"`
+	// LastError is the error of the last push. It is cleared by a successful push.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
 // PushSecretStatus indicates the history of the status of PushSecret.
 type PushSecretStatus struct {
 	// +nullable
@@ -188,6 +291,21 @@ type PushSecretStatus struct {
 	// Matches secret stores to PushSecretData that was stored to that secret store.
 	// +optional
 	SyncedPushSecrets SyncedPushSecretsMap `json:"syncedPushSecrets,omitempty"`
+	// Stores reports the outcome of the last push to every store and of every remote ref in it.
+	// +optional
+	Stores []PushSecretStoreStatus `json:"stores,omitempty"`
+	// SyncedSourceSecrets maps the remote refs in SyncedPushSecrets to the name of the Secret they were pushed from.
+	// It is only populated if the source Secrets are selected by labels.
+	// +optional
+	SyncedSourceSecrets map[string]string `json:"syncedSourceSecrets,omitempty"`
+	// SyncedHashes maps the stores and remote refs in SyncedPushSecrets to the hash of the value last pushed.
+	// It is only populated if verification is configured.
+	// +optional
+	SyncedHashes map[string]map[string]string `json:"syncedHashes,omitempty"`
+	// GeneratorStateSecret is the name of the Secret which holds the data
+	// produced by the generator referenced in .spec.selector.generatorRef.
+	// +optional
+	GeneratorStateSecret string `json:"generatorStateSecret,omitempty"`
 	// +optional
 	Conditions []PushSecretStatusCondition `json:"conditions,omitempty"`
 }
@@ -0,0 +1,100 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// PushSecretValidator rejects PushSecrets which use features
+// the SecretStores they reference by name do not support.
+// Stores selected by labels are only known at reconcile time and are not checked.
+// +kubebuilder:object:generate=false
+type PushSecretValidator struct {
+	Reader client.Reader
+}
+
+func (psv *PushSecretValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return psv.validate(ctx, obj)
+}
+
+// ValidateUpdate skips the checks against the referenced stores if the PushSecret is being deleted
+// or its spec did not change, so that updates of the controller, like the removal of finalizers,
+// are not rejected because a store changed or is gone.
+func (psv *PushSecretValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldPS, okOld := oldObj.(*PushSecret)
+	newPS, okNew := newObj.(*PushSecret)
+	if okOld && okNew && (newPS.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldPS.Spec, newPS.Spec)) {
+		return nil, nil
+	}
+	return psv.validate(ctx, newObj)
+}
+
+func (psv *PushSecretValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (psv *PushSecretValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ps, ok := obj.(*PushSecret)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type")
+	}
+	if psv.Reader == nil {
+		return nil, nil
+	}
+
+	var errs []error
+	for _, ref := range ps.Spec.SecretStoreRefs {
+		if ref.Name == "" {
+			continue
+		}
+		store, err := esv1beta1.LookupStore(ctx, psv.Reader, esv1beta1.SecretStoreRef{Name: ref.Name, Kind: ref.Kind}, ps.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if store == nil {
+			continue
+		}
+		features, err := esv1beta1.GetStoreFeatures(store)
+		if err != nil || features == nil {
+			continue
+		}
+		errs = append(errs, validatePushFeatures(&ps.Spec, store, features)...)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func validatePushFeatures(spec *PushSecretSpec, store esv1beta1.GenericStore, features *esv1beta1.SecretStoreFeatures) []error {
+	var errs []error
+	if spec.UpdatePolicy == PushSecretUpdatePolicyIfNotExists && !features.SecretExists {
+		errs = append(errs, esv1beta1.FeatureErrors("spec.updatePolicy", store, []string{"updatePolicy=IfNotExists"})...)
+	}
+	if spec.DeletionPolicy == PushSecretDeletionPolicyDelete && !features.DeleteSecret {
+		errs = append(errs, esv1beta1.FeatureErrors("spec.deletionPolicy", store, []string{"deletionPolicy=Delete"})...)
+	}
+	for i, data := range spec.Data {
+		errs = append(errs, esv1beta1.FeatureErrors(fmt.Sprintf("spec.data[%d]", i), store, features.UnsupportedPushData(data))...)
+	}
+	return errs
+}
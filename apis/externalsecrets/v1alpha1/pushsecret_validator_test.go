@@ -0,0 +1,169 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// featureProvider only supports checking for existing secrets.
+type featureProvider struct{}
+
+func (p *featureProvider) NewClient(_ context.Context, _ esv1beta1.GenericStore, _ client.Client, _ string) (esv1beta1.SecretsClient, error) {
+	return nil, nil
+}
+
+func (p *featureProvider) ValidateStore(_ esv1beta1.GenericStore) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (p *featureProvider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadWrite
+}
+
+func (p *featureProvider) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{SecretExists: true}
+}
+
+func TestPushSecretValidator(t *testing.T) {
+	esv1beta1.ForceRegister(&featureProvider{}, &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}})
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+	store := &esv1beta1.ClusterSecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+		},
+	}
+	validator := &PushSecretValidator{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build(),
+	}
+	makePS := func(storeRef PushSecretStoreRef, deletionPolicy PushSecretDeletionPolicy, data ...PushSecretData) *PushSecret {
+		return &PushSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "foo"},
+			Spec: PushSecretSpec{
+				SecretStoreRefs: []PushSecretStoreRef{storeRef},
+				UpdatePolicy:    PushSecretUpdatePolicyIfNotExists,
+				DeletionPolicy:  deletionPolicy,
+				Selector:        PushSecretSelector{Secret: &PushSecretSecret{Name: "source"}},
+				Data:            data,
+			},
+		}
+	}
+	clusterStore := PushSecretStoreRef{Name: "fake", Kind: esv1beta1.ClusterSecretStoreKind}
+	singleKey := PushSecretData{Match: PushSecretMatch{SecretKey: "a", RemoteRef: PushSecretRemoteRef{RemoteKey: "a"}}}
+
+	tests := []struct {
+		name        string
+		obj         *PushSecret
+		expectedErr string
+	}{
+		{
+			name: "supported push",
+			obj:  makePS(clusterStore, PushSecretDeletionPolicyNone, singleKey),
+		},
+		{
+			name:        "unsupported deletion policy",
+			obj:         makePS(clusterStore, PushSecretDeletionPolicyDelete, singleKey),
+			expectedErr: `spec.deletionPolicy: deletionPolicy=Delete is not supported by ClusterSecretStore "fake"`,
+		},
+		{
+			name: "unsupported metadata and whole secret",
+			obj: makePS(clusterStore, PushSecretDeletionPolicyNone, singleKey, PushSecretData{
+				Match:    PushSecretMatch{RemoteRef: PushSecretRemoteRef{RemoteKey: "all"}},
+				Metadata: &apiextensionsv1.JSON{Raw: []byte(`{"labels":{"team":"a"}}`)},
+			}),
+			expectedErr: "spec.data[1]: metadata is not supported by ClusterSecretStore \"fake\"\n" +
+				"spec.data[1]: pushing the whole Secret is not supported by ClusterSecretStore \"fake\"",
+		},
+		{
+			name: "stores selected by labels are not checked",
+			obj: makePS(PushSecretStoreRef{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			}, PushSecretDeletionPolicyDelete, singleKey),
+		},
+		{
+			name: "namespaced store does not exist",
+			obj:  makePS(PushSecretStoreRef{Name: "fake", Kind: esv1beta1.SecretStoreKind}, PushSecretDeletionPolicyDelete, singleKey),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.obj)
+			if tt.expectedErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedErr != "" && (err == nil || err.Error() != tt.expectedErr) {
+				t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPushSecretValidatorUpdate(t *testing.T) {
+	esv1beta1.ForceRegister(&featureProvider{}, &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}})
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+	store := &esv1beta1.ClusterSecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+		},
+	}
+	validator := &PushSecretValidator{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build(),
+	}
+	// the store does not support deletionPolicy=Delete, e.g. because it was changed after the PushSecret was created
+	oldPS := &PushSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ps", Namespace: "foo", Finalizers: []string{"pushsecret.externalsecrets.io/finalizer"}},
+		Spec: PushSecretSpec{
+			SecretStoreRefs: []PushSecretStoreRef{{Name: "fake", Kind: esv1beta1.ClusterSecretStoreKind}},
+			DeletionPolicy:  PushSecretDeletionPolicyDelete,
+			Selector:        PushSecretSelector{Secret: &PushSecretSecret{Name: "source"}},
+		},
+	}
+
+	unchanged := oldPS.DeepCopy()
+	unchanged.Finalizers = nil
+	if _, err := validator.ValidateUpdate(context.Background(), oldPS, unchanged); err != nil {
+		t.Errorf("update without spec change must be allowed: %v", err)
+	}
+
+	deleted := oldPS.DeepCopy()
+	deleted.DeletionTimestamp = &metav1.Time{}
+	deleted.Spec.Data = []PushSecretData{{Match: PushSecretMatch{RemoteRef: PushSecretRemoteRef{RemoteKey: "all"}}}}
+	if _, err := validator.ValidateUpdate(context.Background(), oldPS, deleted); err != nil {
+		t.Errorf("update of a deleted PushSecret must be allowed: %v", err)
+	}
+
+	changed := oldPS.DeepCopy()
+	changed.Spec.UpdatePolicy = PushSecretUpdatePolicyReplace
+	if _, err := validator.ValidateUpdate(context.Background(), oldPS, changed); err == nil {
+		t.Errorf("update with spec change must be validated against the store")
+	}
+}
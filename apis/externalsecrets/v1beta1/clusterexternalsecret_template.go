@@ -0,0 +1,158 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"text/template"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/external-secrets/external-secrets/pkg/template/sprig"
+)
+
+// namespaceTemplateSkipPaths are fields of the ExternalSecretSpec which
+// carry templates of their own and must not be evaluated per namespace.
+var namespaceTemplateSkipPaths = map[string]struct{}{
+	"target.template":                {},
+	"additionalTargets[].template":   {},
+	"dataFrom[].rewrite[].transform": {},
+}
+
+var indexRegexp = regexp.MustCompile(`\[\d+\]`)
+
+var namespaceTemplateFuncs = sprig.FuncMap()
+
+// RenderNamespaceTemplates evaluates the Go-template expressions of a ClusterExternalSecret's
+// externalSecretName and externalSecretSpec against the given Namespace.
+func (c *ClusterExternalSecret) RenderNamespaceTemplates(name string, namespace metav1.ObjectMeta) (string, ExternalSecretSpec, error) {
+	data := namespaceTemplateData(namespace)
+	render := func(path, in string) (string, error) {
+		return executeNamespaceTemplate(path, in, data)
+	}
+
+	var spec ExternalSecretSpec
+	renderedName, err := render("externalSecretName", name)
+	if err != nil {
+		return "", spec, err
+	}
+	err = walkExternalSecretSpec(&c.Spec.ExternalSecretSpec, &spec, render)
+	return renderedName, spec, err
+}
+
+// validateNamespaceTemplates parses every template expression of the ClusterExternalSecret.
+func (c *ClusterExternalSecret) validateNamespaceTemplates() error {
+	parse := func(path, in string) (string, error) {
+		_, err := parseNamespaceTemplate(path, in)
+		return in, err
+	}
+	if _, err := parse("externalSecretName", c.Spec.ExternalSecretName); err != nil {
+		return err
+	}
+	var spec ExternalSecretSpec
+	return walkExternalSecretSpec(&c.Spec.ExternalSecretSpec, &spec, parse)
+}
+
+// namespaceTemplateData returns the data namespace templates are evaluated against.
+func namespaceTemplateData(namespace metav1.ObjectMeta) map[string]any {
+	return map[string]any{
+		"namespace": map[string]any{
+			"name":        namespace.Name,
+			"labels":      namespace.Labels,
+			"annotations": namespace.Annotations,
+		},
+	}
+}
+
+func executeNamespaceTemplate(path, in string, data map[string]any) (string, error) {
+	tpl, err := parseNamespaceTemplate(path, in)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("unable to execute template at %s: %w", path, err)
+	}
+	return buf.String(), nil
+}
+
+func parseNamespaceTemplate(path, in string) (*template.Template, error) {
+	tpl, err := template.New(path).
+		Funcs(namespaceTemplateFuncs).
+		Option("missingkey=error").
+		Parse(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template at %s: %w", path, err)
+	}
+	return tpl, nil
+}
+
+// walkExternalSecretSpec applies fn to every string value of in and stores the result in out.
+func walkExternalSecretSpec(in, out *ExternalSecretSpec, fn func(path, in string) (string, error)) error {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	var obj any
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return err
+	}
+	obj, err = walkValue("", obj, fn)
+	if err != nil {
+		return err
+	}
+	raw, err = json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+func walkValue(path string, v any, fn func(path, in string) (string, error)) (any, error) {
+	if _, skip := namespaceTemplateSkipPaths[indexRegexp.ReplaceAllString(path, "[]")]; skip {
+		return v, nil
+	}
+	switch val := v.(type) {
+	case string:
+		return fn(path, val)
+	case map[string]any:
+		for k, elem := range val {
+			elemPath := k
+			if path != "" {
+				elemPath = path + "." + k
+			}
+			res, err := walkValue(elemPath, elem, fn)
+			if err != nil {
+				return nil, err
+			}
+			val[k] = res
+		}
+		return val, nil
+	case []any:
+		for i, elem := range val {
+			res, err := walkValue(fmt.Sprintf("%s[%d]", path, i), elem, fn)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = res
+		}
+		return val, nil
+	default:
+		return v, nil
+	}
+}
@@ -28,6 +28,13 @@ type ClusterExternalSecretSpec struct {
 	// +optional
 	ExternalSecretName string `json:"externalSecretName,omitempty"`
 
+	// NamespaceTemplating enables Go-template expressions in externalSecretName and externalSecretSpec.
+	// They are evaluated against the target Namespace before the ExternalSecret is created,
+	// e.g. `{{ .namespace.name }}`, `{{ .namespace.labels.team }}` or `{{ index .namespace.annotations "key" }}`.
+	// Secret templates (target.template, additionalTargets[].template) and rewrite transforms are not evaluated.
+	// +optional
+	NamespaceTemplating bool `json:"namespaceTemplating,omitempty"`
+
 	// The metadata of the external secrets to be created
 	// +optional
 	ExternalSecretMetadata ExternalSecretMetadata `json:"externalSecretMetadata,omitempty"`
@@ -81,6 +88,39 @@ type ClusterExternalSecretNamespaceFailure struct {
 	Reason string `json:"reason,omitempty"`
 }
 
+// ExternalSecretHealth is the aggregated sync state of a provisioned ExternalSecret.
+// +kubebuilder:validation:Enum=Ready;Failed;Pending
+type ExternalSecretHealth string
+
+const (
+	// ExternalSecretHealthReady indicates that the ExternalSecret is Ready.
+	ExternalSecretHealthReady ExternalSecretHealth = "Ready"
+	// ExternalSecretHealthFailed indicates that the ExternalSecret failed to sync.
+	ExternalSecretHealthFailed ExternalSecretHealth = "Failed"
+	// ExternalSecretHealthPending indicates that the ExternalSecret has not reported a Ready condition yet.
+	ExternalSecretHealthPending ExternalSecretHealth = "Pending"
+)
+
+// ClusterExternalSecretNamespaceStatus reports the health of the ExternalSecret provisioned in a namespace.
+type ClusterExternalSecretNamespaceStatus struct {
+	// Namespace is the namespace of the ExternalSecret
+	Namespace string `json:"namespace"`
+
+	// ExternalSecretName is the name of the ExternalSecret in the namespace
+	ExternalSecretName string `json:"externalSecretName"`
+
+	// Health is derived from the Ready condition of the ExternalSecret
+	Health ExternalSecretHealth `json:"health"`
+
+	// Reason is the reason of the ExternalSecret Ready condition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the message of the ExternalSecret Ready condition
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // ClusterExternalSecretStatus defines the observed state of ClusterExternalSecret.
 type ClusterExternalSecretStatus struct {
 	// ExternalSecretName is the name of the ExternalSecrets created by the ClusterExternalSecret
@@ -94,6 +134,22 @@ type ClusterExternalSecretStatus struct {
 	// +optional
 	ProvisionedNamespaces []string `json:"provisionedNamespaces,omitempty"`
 
+	// NamespaceStatuses reports the health of the ExternalSecret in every provisioned namespace
+	// +optional
+	NamespaceStatuses []ClusterExternalSecretNamespaceStatus `json:"namespaceStatuses,omitempty"`
+
+	// ReadyExternalSecrets is the number of provisioned ExternalSecrets which are Ready
+	// +optional
+	ReadyExternalSecrets int `json:"readyExternalSecrets,omitempty"`
+
+	// FailedExternalSecrets is the number of provisioned ExternalSecrets which failed to sync
+	// +optional
+	FailedExternalSecrets int `json:"failedExternalSecrets,omitempty"`
+
+	// PendingExternalSecrets is the number of provisioned ExternalSecrets which did not report their state yet
+	// +optional
+	PendingExternalSecrets int `json:"pendingExternalSecrets,omitempty"`
+
 	// +optional
 	Conditions []ClusterExternalSecretStatusCondition `json:"conditions,omitempty"`
 }
@@ -105,6 +161,9 @@ type ClusterExternalSecretStatus struct {
 // +kubebuilder:printcolumn:name="Store",type=string,JSONPath=`.spec.externalSecretSpec.secretStoreRef.name`
 // +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=`.spec.refreshTime`
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Ready ES",type=integer,JSONPath=`.status.readyExternalSecrets`,priority=1
+// +kubebuilder:printcolumn:name="Failed ES",type=integer,JSONPath=`.status.failedExternalSecrets`,priority=1
+// +kubebuilder:printcolumn:name="Pending ES",type=integer,JSONPath=`.status.pendingExternalSecrets`,priority=1
 // ClusterExternalSecret is the Schema for the clusterexternalsecrets API.
 type ClusterExternalSecret struct {
 	metav1.TypeMeta   `json:",inline"`
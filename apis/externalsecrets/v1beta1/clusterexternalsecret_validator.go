@@ -0,0 +1,49 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type ClusterExternalSecretValidator struct{}
+
+func (cesv *ClusterExternalSecretValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return validateClusterExternalSecret(obj)
+}
+
+func (cesv *ClusterExternalSecretValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return validateClusterExternalSecret(newObj)
+}
+
+func (cesv *ClusterExternalSecretValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateClusterExternalSecret(obj runtime.Object) (admission.Warnings, error) {
+	ces, ok := obj.(*ClusterExternalSecret)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type")
+	}
+
+	if !ces.Spec.NamespaceTemplating {
+		return nil, nil
+	}
+	return nil, ces.validateNamespaceTemplates()
+}
@@ -0,0 +1,158 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestValidateClusterExternalSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		obj         runtime.Object
+		expectedErr string
+	}{
+		{
+			name:        "nil",
+			obj:         nil,
+			expectedErr: "unexpected type",
+		},
+		{
+			name: "templates are not parsed without namespaceTemplating",
+			obj: &ClusterExternalSecret{
+				Spec: ClusterExternalSecretSpec{
+					ExternalSecretName: "{{ .namespace.name",
+				},
+			},
+		},
+		{
+			name: "invalid name template",
+			obj: &ClusterExternalSecret{
+				Spec: ClusterExternalSecretSpec{
+					NamespaceTemplating: true,
+					ExternalSecretName:  "{{ .namespace.name",
+				},
+			},
+			expectedErr: "unable to parse template at externalSecretName: template: externalSecretName:1: unclosed action",
+		},
+		{
+			name: "invalid spec template",
+			obj: &ClusterExternalSecret{
+				Spec: ClusterExternalSecretSpec{
+					NamespaceTemplating: true,
+					ExternalSecretSpec: ExternalSecretSpec{
+						Data: []ExternalSecretData{
+							{
+								RemoteRef: ExternalSecretDataRemoteRef{
+									Key: "{{ unknownFunc .namespace.name }}",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: `unable to parse template at data[0].remoteRef.key: template: data[0].remoteRef.key:1: function "unknownFunc" not defined`,
+		},
+		{
+			name: "secret templates are not parsed",
+			obj: &ClusterExternalSecret{
+				Spec: ClusterExternalSecretSpec{
+					NamespaceTemplating: true,
+					ExternalSecretSpec: ExternalSecretSpec{
+						Target: ExternalSecretTarget{
+							Template: &ExternalSecretTemplate{
+								Data: map[string]string{"key": "{{ .value | filterPEM }}"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateClusterExternalSecret(tt.obj)
+			if err != nil {
+				if tt.expectedErr == "" {
+					t.Fatalf("validateClusterExternalSecret() returned an unexpected error: %v", err)
+				}
+
+				if err.Error() != tt.expectedErr {
+					t.Fatalf("validateClusterExternalSecret() returned an unexpected error: got: %v, expected: %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if tt.expectedErr != "" {
+				t.Errorf("validateClusterExternalSecret() should have returned an error but got nil")
+			}
+		})
+	}
+}
+
+func TestRenderNamespaceTemplates(t *testing.T) {
+	ces := &ClusterExternalSecret{
+		Spec: ClusterExternalSecretSpec{
+			NamespaceTemplating: true,
+			ExternalSecretSpec: ExternalSecretSpec{
+				Target: ExternalSecretTarget{
+					Name: "{{ .namespace.name }}-secret",
+					Template: &ExternalSecretTemplate{
+						Data: map[string]string{"password": "{{ .password }}"},
+					},
+				},
+				Data: []ExternalSecretData{
+					{
+						SecretKey: "password",
+						RemoteRef: ExternalSecretDataRemoteRef{
+							Key: "/tenants/{{ .namespace.name }}/{{ index .namespace.annotations \"example.com/db\" }}",
+						},
+					},
+				},
+			},
+		},
+	}
+	namespace := metav1.ObjectMeta{
+		Name:        "tenant-a",
+		Annotations: map[string]string{"example.com/db": "orders"},
+	}
+
+	name, spec, err := ces.RenderNamespaceTemplates("{{ .namespace.name | upper | lower }}-es", namespace)
+	if err != nil {
+		t.Fatalf("RenderNamespaceTemplates() returned an unexpected error: %v", err)
+	}
+	if name != "tenant-a-es" {
+		t.Errorf("unexpected name: %s", name)
+	}
+	if spec.Target.Name != "tenant-a-secret" {
+		t.Errorf("unexpected target name: %s", spec.Target.Name)
+	}
+	if got := spec.Data[0].RemoteRef.Key; got != "/tenants/tenant-a/orders" {
+		t.Errorf("unexpected remote key: %s", got)
+	}
+	if got := spec.Target.Template.Data["password"]; got != "{{ .password }}" {
+		t.Errorf("secret template must not be rendered: %s", got)
+	}
+	if got := ces.Spec.ExternalSecretSpec.Data[0].RemoteRef.Key; got != "/tenants/{{ .namespace.name }}/{{ index .namespace.annotations \"example.com/db\" }}" {
+		t.Errorf("ClusterExternalSecret spec must not be modified: %s", got)
+	}
+
+	_, _, err = ces.RenderNamespaceTemplates("{{ .namespace.labels.missing }}", namespace)
+	if err == nil {
+		t.Errorf("RenderNamespaceTemplates() should fail on missing keys")
+	}
+}
@@ -172,9 +172,8 @@ type ExternalSecretTarget struct {
 	// +kubebuilder:default="Owner"
 	CreationPolicy ExternalSecretCreationPolicy `json:"creationPolicy,omitempty"`
 	// DeletionPolicy defines rules on how to delete the resulting Secret
-	// Defaults to 'Retain'
+	// Defaults to the deletionPolicy of the store defaults or 'Retain'
 	// +optional
-	// +kubebuilder:default="Retain"
 	DeletionPolicy ExternalSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
 	// Template defines a blueprint for the created Secret resource.
 	// +optional
@@ -185,6 +184,19 @@ type ExternalSecretTarget struct {
 	Immutable bool `json:"immutable,omitempty"`
 }
 
+// ExternalSecretAdditionalTarget defines an additional Kubernetes Secret to be created
+// from the same provider data as the primary target.
+type ExternalSecretAdditionalTarget struct {
+	ExternalSecretTarget `json:",inline"`
+
+	// Namespace defines the namespace of the Secret resource to be managed.
+	// Targets outside of the ExternalSecret namespace must be allowed by the target Namespace
+	// through the `externalsecrets.external-secrets.io/allowed-source-namespaces` annotation.
+	// Defaults to the .metadata.namespace of the ExternalSecret resource
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // ExternalSecretData defines the connection between the Kubernetes Secret key (spec.data.<key>) and the Provider data.
 type ExternalSecretData struct {
 	// SecretKey defines the key in which the controller stores
@@ -219,13 +231,13 @@ type ExternalSecretDataRemoteRef struct {
 	Version string `json:"version,omitempty"`
 
 	// +optional
-	// Used to define a conversion Strategy
-	// +kubebuilder:default="Default"
+	// Used to define a conversion Strategy.
+	// Defaults to the conversionStrategy of the store defaults or 'Default'
 	ConversionStrategy ExternalSecretConversionStrategy `json:"conversionStrategy,omitempty"`
 
 	// +optional
-	// Used to define a decoding Strategy
-	// +kubebuilder:default="None"
+	// Used to define a decoding Strategy.
+	// Defaults to the decodingStrategy of the store defaults or 'None'
 	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
 }
 
@@ -318,13 +330,13 @@ type ExternalSecretFind struct {
 	Tags map[string]string `json:"tags,omitempty"`
 
 	// +optional
-	// Used to define a conversion Strategy
-	// +kubebuilder:default="Default"
+	// Used to define a conversion Strategy.
+	// Defaults to the conversionStrategy of the store defaults or 'Default'
 	ConversionStrategy ExternalSecretConversionStrategy `json:"conversionStrategy,omitempty"`
 
 	// +optional
-	// Used to define a decoding Strategy
-	// +kubebuilder:default="None"
+	// Used to define a decoding Strategy.
+	// Defaults to the decodingStrategy of the store defaults or 'None'
 	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
 }
 
@@ -338,14 +350,15 @@ type FindName struct {
 type ExternalSecretSpec struct {
 	// +optional
 	SecretStoreRef SecretStoreRef `json:"secretStoreRef,omitempty"`
-	// +kubebuilder:default={creationPolicy:Owner,deletionPolicy:Retain}
+	// +kubebuilder:default={creationPolicy:Owner}
 	// +optional
 	Target ExternalSecretTarget `json:"target,omitempty"`
 
 	// RefreshInterval is the amount of time before the values are read again from the SecretStore provider
 	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h"
-	// May be set to zero to fetch and create it once. Defaults to 1h.
-	// +kubebuilder:default="1h"
+	// May be set to zero to fetch and create it once.
+	// Defaults to the refreshInterval of the store defaults or 1h.
+	// +optional
 	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 
 	// Data defines the connection between the Kubernetes Secret keys and the Provider data
@@ -356,6 +369,12 @@ type ExternalSecretSpec struct {
 	// If multiple entries are specified, the Secret keys are merged in the specified order
 	// +optional
 	DataFrom []ExternalSecretDataFromRemoteRef `json:"dataFrom,omitempty"`
+
+	// AdditionalTargets defines further Secrets to be rendered from the same provider data.
+	// Every target has its own name, template and creation/deletion policy.
+	// .name is required for every additional target.
+	// +optional
+	AdditionalTargets []ExternalSecretAdditionalTarget `json:"additionalTargets,omitempty"`
 }
 
 // StoreSourceRef allows you to override the SecretStore source
@@ -431,8 +450,31 @@ const (
 	ReasonCreated      = "Created"
 	ReasonUpdated      = "Updated"
 	ReasonDeleted      = "Deleted"
+
+	ReasonLeaseRevocationSkipped = "LeaseRevocationSkipped"
 )
 
+// ExternalSecretTargetStatus represents the sync state of an additional target.
+type ExternalSecretTargetStatus struct {
+	// Name of the target Secret
+	Name string `json:"name"`
+
+	// Namespace of the target Secret
+	Namespace string `json:"namespace"`
+
+	// Status is True when the target Secret was synced
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
 type ExternalSecretStatus struct {
 	// +nullable
 	// refreshTime is the time and date the external secret was fetched and
@@ -447,6 +489,68 @@ type ExternalSecretStatus struct {
 
 	// Binding represents a servicebinding.io Provisioned Service reference to the secret
 	Binding corev1.LocalObjectReference `json:"binding,omitempty"`
+
+	// AdditionalTargets reports the sync state of every entry in .spec.additionalTargets
+	// +optional
+	AdditionalTargets []ExternalSecretTargetStatus `json:"additionalTargets,omitempty"`
+
+	// Effective reports the values used for the fields the ExternalSecret leaves unset.
+	// +optional
+	Effective *ExternalSecretEffectiveSettings `json:"effective,omitempty"`
+
+	// Leases of the generated secrets, they are renewed until they expire
+	// and revoked once the secrets are superseded or the ExternalSecret is deleted.
+	// +optional
+	Leases []GeneratorLease `json:"leases,omitempty"`
+}
+
+// GeneratorLease is the lease of a secret generated for the ExternalSecret.
+type GeneratorLease struct {
+	// GeneratorRef of the generator which issued the lease.
+	GeneratorRef GeneratorRef `json:"generatorRef"`
+
+	// ID of the lease.
+	ID string `json:"id"`
+
+	// Renewable is true if the lease can be renewed.
+	// +optional
+	Renewable bool `json:"renewable,omitempty"`
+
+	// RenewTime is the time the lease was issued or last renewed.
+	RenewTime metav1.Time `json:"renewTime"`
+
+	// ExpirationTime is the time the lease expires, unset if it does not expire.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// Superseded is true if the generated secret is no longer used
+	// and the lease could not be revoked yet.
+	// +optional
+	Superseded bool `json:"superseded,omitempty"`
+}
+
+// ExternalSecretEffectiveSettings reports the values the controller uses for fields
+// the ExternalSecret leaves unset, taken from the defaults of the store or the built-in defaults.
+type ExternalSecretEffectiveSettings struct {
+	// DefaultsFrom is the store whose defaults were applied, formatted as <kind>/<name>.
+	// +optional
+	DefaultsFrom string `json:"defaultsFrom,omitempty"`
+	// RefreshInterval is the effective refresh interval.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// DeletionPolicy is the effective deletion policy of the target.
+	// +optional
+	DeletionPolicy ExternalSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// ConversionStrategy is used by data and dataFrom entries which do not set one.
+	// +optional
+	ConversionStrategy ExternalSecretConversionStrategy `json:"conversionStrategy,omitempty"`
+	// DecodingStrategy is used by data and dataFrom entries which do not set one.
+	// +optional
+	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+	// TemplateMetadata are the labels and annotations added to the target Secrets
+	// unless the ExternalSecret sets them itself.
+	// +optional
+	TemplateMetadata *ExternalSecretTemplateMetadata `json:"templateMetadata,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -455,7 +559,7 @@ type ExternalSecretStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,categories={externalsecrets},shortName=es
 // +kubebuilder:printcolumn:name="Store",type=string,JSONPath=`.spec.secretStoreRef.name`
-// +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=`.spec.refreshInterval`
+// +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=`.status.effective.refreshInterval`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 type ExternalSecret struct {
@@ -472,6 +576,22 @@ const (
 	// LabelOwner points to the owning ExternalSecret resource
 	//  and is used to manage the lifecycle of a Secret
 	LabelOwner = "reconcile.external-secrets.io/created-by"
+	// AnnotationAllowedSourceNamespaces is set on a Namespace to allow ExternalSecrets
+	// from the listed namespaces to manage additional targets in it.
+	// The value is a comma separated list of namespace names, "*" allows all namespaces.
+	AnnotationAllowedSourceNamespaces = "externalsecrets.external-secrets.io/allowed-source-namespaces"
+	// FinalizerAdditionalTargets is used to clean up additional targets
+	// which live outside of the ExternalSecret namespace.
+	FinalizerAdditionalTargets = "externalsecrets.external-secrets.io/additional-targets"
+	// FinalizerGeneratorLeases is used to revoke the leases of generated secrets.
+	FinalizerGeneratorLeases = "externalsecrets.external-secrets.io/generator-leases"
+	// AnnotationSkipLeaseRevocation is set to "true" on an ExternalSecret to delete it
+	// without revoking the leases of its generated secrets.
+	AnnotationSkipLeaseRevocation = "externalsecrets.external-secrets.io/skip-lease-revocation"
+	// AnnotationInheritStoreDefaults is set to "true" on an ExternalSecret to replace the built-in
+	// defaults earlier versions persisted in its spec (1h, Default, None and Retain) with the
+	// defaults of its store.
+	AnnotationInheritStoreDefaults = "externalsecrets.external-secrets.io/inherit-store-defaults"
 )
 
 // +kubebuilder:object:root=true
@@ -19,18 +19,49 @@ import (
 	"errors"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-type ExternalSecretValidator struct{}
+// ExternalSecretValidator validates ExternalSecrets.
+// If Reader is set, the remote keys are also checked against the
+// access rules of the ClusterSecretStores the ExternalSecret references
+// and against the features of the referenced stores.
+// +kubebuilder:object:generate=false
+type ExternalSecretValidator struct {
+	Reader client.Reader
+}
+
+func (esv *ExternalSecretValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return esv.validate(ctx, obj)
+}
 
-func (esv *ExternalSecretValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(obj)
+// ValidateUpdate skips the checks against the referenced stores if the ExternalSecret is being deleted
+// or its spec did not change, so that updates of the controller, like the removal of finalizers,
+// are not rejected because a store changed or is gone.
+func (esv *ExternalSecretValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldES, okOld := oldObj.(*ExternalSecret)
+	newES, okNew := newObj.(*ExternalSecret)
+	if okOld && okNew && (newES.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldES.Spec, newES.Spec)) {
+		return validateExternalSecret(newObj)
+	}
+	return esv.validate(ctx, newObj)
 }
 
-func (esv *ExternalSecretValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(newObj)
+func (esv *ExternalSecretValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := validateExternalSecret(obj)
+	if err != nil || esv.Reader == nil {
+		return warnings, err
+	}
+	es := obj.(*ExternalSecret)
+	if err := esv.validateStoreAccess(ctx, es); err != nil {
+		return warnings, err
+	}
+	return warnings, esv.validateStoreFeatures(ctx, es)
 }
 
 func (esv *ExternalSecretValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
@@ -43,15 +74,8 @@ func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 		return nil, fmt.Errorf("unexpected type")
 	}
 
-	var errs error
-	if (es.Spec.Target.DeletionPolicy == DeletionPolicyDelete && es.Spec.Target.CreationPolicy == CreatePolicyMerge) ||
-		(es.Spec.Target.DeletionPolicy == DeletionPolicyDelete && es.Spec.Target.CreationPolicy == CreatePolicyNone) {
-		errs = errors.Join(errs, fmt.Errorf("deletionPolicy=Delete must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner"))
-	}
-
-	if es.Spec.Target.DeletionPolicy == DeletionPolicyMerge && es.Spec.Target.CreationPolicy == CreatePolicyNone {
-		errs = errors.Join(errs, fmt.Errorf("deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with"))
-	}
+	errs := validatePolicies(&es.Spec.Target)
+	errs = validateAdditionalTargets(es, errs)
 
 	if len(es.Spec.Data) == 0 && len(es.Spec.DataFrom) == 0 {
 		errs = errors.Join(errs, fmt.Errorf("either data or dataFrom should be specified"))
@@ -76,8 +100,52 @@ func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 	return nil, errs
 }
 
+func validatePolicies(target *ExternalSecretTarget) error {
+	var errs error
+	if (target.DeletionPolicy == DeletionPolicyDelete && target.CreationPolicy == CreatePolicyMerge) ||
+		(target.DeletionPolicy == DeletionPolicyDelete && target.CreationPolicy == CreatePolicyNone) {
+		errs = errors.Join(errs, fmt.Errorf("deletionPolicy=Delete must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner"))
+	}
+
+	if target.DeletionPolicy == DeletionPolicyMerge && target.CreationPolicy == CreatePolicyNone {
+		errs = errors.Join(errs, fmt.Errorf("deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with"))
+	}
+	return errs
+}
+
+func validateAdditionalTargets(es *ExternalSecret, errs error) error {
+	primaryName := es.Spec.Target.Name
+	if primaryName == "" {
+		primaryName = es.Name
+	}
+	seenTargets := map[string]struct{}{
+		es.Namespace + "/" + primaryName: {},
+	}
+	for i := range es.Spec.AdditionalTargets {
+		target := &es.Spec.AdditionalTargets[i]
+		if target.Name == "" {
+			errs = errors.Join(errs, fmt.Errorf("spec.additionalTargets[%d]: name must be set", i))
+			continue
+		}
+		namespace := target.Namespace
+		if namespace == "" {
+			namespace = es.Namespace
+		}
+		key := namespace + "/" + target.Name
+		if _, exists := seenTargets[key]; exists {
+			errs = errors.Join(errs, fmt.Errorf("spec.additionalTargets[%d]: duplicate target found: %s", i, key))
+		}
+		seenTargets[key] = struct{}{}
+		if err := validatePolicies(&target.ExternalSecretTarget); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("spec.additionalTargets[%d]: %w", i, err))
+		}
+	}
+	return errs
+}
+
 func validateDuplicateKeys(es *ExternalSecret, errs error) error {
-	if es.Spec.Target.DeletionPolicy == DeletionPolicyRetain {
+	// an unset deletionPolicy defaults to Retain unless the store defaults say otherwise
+	if es.Spec.Target.DeletionPolicy == DeletionPolicyRetain || es.Spec.Target.DeletionPolicy == "" {
 		seenKeys := make(map[string]struct{})
 		for _, data := range es.Spec.Data {
 			secretKey := data.SecretKey
@@ -89,3 +157,176 @@ func validateDuplicateKeys(es *ExternalSecret, errs error) error {
 	}
 	return errs
 }
+
+// validateStoreAccess checks the remote keys of the ExternalSecret against the access rules
+// of the ClusterSecretStores it references. Stores which do not exist yet or can not be read
+// by the webhook are skipped, the access rules are enforced by the controller as well.
+func (esv *ExternalSecretValidator) validateStoreAccess(ctx context.Context, es *ExternalSecret) error {
+	var (
+		errs      error
+		namespace *corev1.Namespace
+		accesses  = make(map[string]*KeyAccess)
+	)
+	getAccess := func(storeRef SecretStoreRef) (*KeyAccess, error) {
+		if storeRef.Kind != ClusterSecretStoreKind {
+			return nil, nil
+		}
+		if a, ok := accesses[storeRef.Name]; ok {
+			return a, nil
+		}
+		var store ClusterSecretStore
+		err := esv.Reader.Get(ctx, client.ObjectKey{Name: storeRef.Name}, &store)
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get ClusterSecretStore %q: %w", storeRef.Name, err)
+		}
+		if namespace == nil {
+			namespace = &corev1.Namespace{}
+			if err := esv.Reader.Get(ctx, client.ObjectKey{Name: es.Namespace}, namespace); err != nil {
+				namespace = nil
+				if apierrors.IsForbidden(err) {
+					return nil, nil
+				}
+				return nil, fmt.Errorf("could not get namespace %q: %w", es.Namespace, err)
+			}
+		}
+		_, r, err := NamespaceStoreAccess(&store, namespace)
+		if err != nil {
+			return nil, err
+		}
+		// the access rules are evaluated against the namespace of the ExternalSecret
+		a, err := NewKeyAccess(r, namespace.ObjectMeta)
+		if err != nil {
+			return nil, err
+		}
+		accesses[storeRef.Name] = a
+		return a, nil
+	}
+
+	for i, data := range es.Spec.Data {
+		storeRef := es.Spec.SecretStoreRef
+		if data.SourceRef != nil {
+			if data.SourceRef.GeneratorRef != nil {
+				continue
+			}
+			if data.SourceRef.SecretStoreRef.Name != "" {
+				storeRef = data.SourceRef.SecretStoreRef
+			}
+		}
+		access, err := getAccess(storeRef)
+		if err != nil {
+			return err
+		}
+		if err := access.CheckKey(data.RemoteRef.Key); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("spec.data[%d]: %w", i, err))
+		}
+	}
+	for i, ref := range es.Spec.DataFrom {
+		storeRef := es.Spec.SecretStoreRef
+		if ref.SourceRef != nil {
+			if ref.SourceRef.GeneratorRef != nil {
+				continue
+			}
+			if ref.SourceRef.SecretStoreRef != nil {
+				storeRef = *ref.SourceRef.SecretStoreRef
+			}
+		}
+		access, err := getAccess(storeRef)
+		if err != nil {
+			return err
+		}
+		if ref.Extract != nil {
+			err = access.CheckKey(ref.Extract.Key)
+		}
+		if ref.Find != nil {
+			err = access.CheckFind(*ref.Find)
+		}
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("spec.dataFrom[%d]: %w", i, err))
+		}
+	}
+	return errs
+}
+
+// validateStoreFeatures rejects find operations, versions and metadata fetches
+// the stores referenced by the ExternalSecret do not support.
+// Stores which do not exist yet or do not describe their features are skipped.
+func (esv *ExternalSecretValidator) validateStoreFeatures(ctx context.Context, es *ExternalSecret) error {
+	type storeFeatures struct {
+		store    GenericStore
+		features *SecretStoreFeatures
+	}
+	stores := make(map[SecretStoreRef]storeFeatures)
+	getFeatures := func(storeRef SecretStoreRef) (storeFeatures, error) {
+		if storeRef.Kind == "" {
+			storeRef.Kind = SecretStoreKind
+		}
+		if sf, ok := stores[storeRef]; ok {
+			return sf, nil
+		}
+		var sf storeFeatures
+		if storeRef.Name == "" {
+			return sf, nil
+		}
+		store, err := LookupStore(ctx, esv.Reader, storeRef, es.Namespace)
+		if err != nil || store == nil {
+			return sf, err
+		}
+		features, err := GetStoreFeatures(store)
+		if err != nil {
+			// invalid stores are rejected by the SecretStore webhook
+			return sf, nil
+		}
+		sf = storeFeatures{store: store, features: features}
+		stores[storeRef] = sf
+		return sf, nil
+	}
+
+	var errs []error
+	for i, data := range es.Spec.Data {
+		storeRef := es.Spec.SecretStoreRef
+		if data.SourceRef != nil {
+			if data.SourceRef.GeneratorRef != nil {
+				continue
+			}
+			if data.SourceRef.SecretStoreRef.Name != "" {
+				storeRef = data.SourceRef.SecretStoreRef
+			}
+		}
+		sf, err := getFeatures(storeRef)
+		if err != nil {
+			return err
+		}
+		if sf.features != nil {
+			errs = append(errs, FeatureErrors(fmt.Sprintf("spec.data[%d]", i), sf.store, sf.features.UnsupportedRemoteRef(data.RemoteRef))...)
+		}
+	}
+	for i, ref := range es.Spec.DataFrom {
+		storeRef := es.Spec.SecretStoreRef
+		if ref.SourceRef != nil {
+			if ref.SourceRef.GeneratorRef != nil {
+				continue
+			}
+			if ref.SourceRef.SecretStoreRef != nil {
+				storeRef = *ref.SourceRef.SecretStoreRef
+			}
+		}
+		sf, err := getFeatures(storeRef)
+		if err != nil {
+			return err
+		}
+		if sf.features == nil {
+			continue
+		}
+		path := fmt.Sprintf("spec.dataFrom[%d]", i)
+		if ref.Extract != nil {
+			errs = append(errs, FeatureErrors(path, sf.store, sf.features.UnsupportedRemoteRef(*ref.Extract))...)
+		}
+		if ref.Find != nil {
+			errs = append(errs, FeatureErrors(path, sf.store, sf.features.UnsupportedFind(*ref.Find))...)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -17,6 +17,7 @@ package v1beta1
 import (
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -165,6 +166,67 @@ either data or dataFrom should be specified`,
 				},
 			},
 		},
+		{
+			name: "additional target without name",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{},
+					},
+					AdditionalTargets: []ExternalSecretAdditionalTarget{
+						{},
+					},
+				},
+			},
+			expectedErr: "spec.additionalTargets[0]: name must be set",
+		},
+		{
+			name: "duplicate additional target",
+			obj: &ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "es",
+					Namespace: "default",
+				},
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{},
+					},
+					AdditionalTargets: []ExternalSecretAdditionalTarget{
+						{
+							ExternalSecretTarget: ExternalSecretTarget{Name: "es"},
+						},
+						{
+							ExternalSecretTarget: ExternalSecretTarget{Name: "copy"},
+							Namespace:            "other",
+						},
+						{
+							ExternalSecretTarget: ExternalSecretTarget{Name: "copy"},
+						},
+					},
+				},
+			},
+			expectedErr: "spec.additionalTargets[0]: duplicate target found: default/es",
+		},
+		{
+			name: "additional target deletion policy delete",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{},
+					},
+					AdditionalTargets: []ExternalSecretAdditionalTarget{
+						{
+							ExternalSecretTarget: ExternalSecretTarget{
+								Name:           "copy",
+								DeletionPolicy: DeletionPolicyDelete,
+								CreationPolicy: CreatePolicyMerge,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "spec.additionalTargets[0]: deletionPolicy=Delete must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner",
+		},
 		{
 			name: "duplicate secretKeys",
 			obj: &ExternalSecret{
@@ -66,6 +66,19 @@ type Provider interface {
 // +k8s:deepcopy-gen:interfaces=nil
 // +k8s:deepcopy-gen=nil
 
+// FeatureProvider is optionally implemented by a Provider to describe
+// the features a store supports beyond its Capabilities.
+// The features of providers which do not implement it are unknown and not checked.
+type FeatureProvider interface {
+	// Features returns the features the given store supports.
+	Features(store GenericStore) SecretStoreFeatures
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
 // SecretsClient provides access to secrets.
 type SecretsClient interface {
 	// GetSecret returns a single secret from the provider
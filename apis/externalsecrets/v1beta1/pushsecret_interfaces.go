@@ -39,3 +39,30 @@ type PushSecretRemoteRef interface {
 	GetRemoteKey() string
 	GetProperty() string
 }
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// PushSecretOwner is optionally implemented by the PushSecretData and PushSecretRemoteRef passed to a Provider.
+// It identifies the PushSecret which pushes to the remote secret, so that providers can stamp an ownership marker
+// on the remote secret and refuse to overwrite or delete remote secrets owned by someone else.
+type PushSecretOwner interface {
+	// GetOwner returns the identity of the PushSecret formatted as [<cluster>/]<namespace>/<name>.
+	GetOwner() string
+	// GetAdopt returns true if remote secrets owned by someone else may be taken over.
+	GetAdopt() bool
+}
+
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// PushSecretVersionRecorder is optionally implemented by the PushSecretData passed to a Provider.
+// Providers which version their secrets report the version of the remote secret after a push through it.
+type PushSecretVersionRecorder interface {
+	// SetRemoteVersion records the version of the remote secret, e.g. the AWS VersionId.
+	SetRemoteVersion(version string)
+}
@@ -0,0 +1,257 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	errKeyAccessDenied  = "access to remote key %q is not allowed from namespace %q"
+	errFindAccessDenied = "find in path %q is not allowed from namespace %q"
+)
+
+// NamespaceStoreAccess returns whether the namespace may use the store and the access rules
+// of the store conditions which choose the namespace. No access rules are returned
+// if the namespace may access any remote key.
+func NamespaceStoreAccess(store GenericStore, namespace *corev1.Namespace) (bool, []ClusterSecretStoreAccess, error) {
+	if store.GetKind() != ClusterSecretStoreKind || len(store.GetSpec().Conditions) == 0 {
+		return true, nil, nil
+	}
+
+	var (
+		allowed bool
+		rules   []ClusterSecretStoreAccess
+	)
+	for _, condition := range store.GetSpec().Conditions {
+		matches, err := condition.matchesNamespace(namespace)
+		if err != nil {
+			return false, nil, err
+		}
+		if !matches {
+			continue
+		}
+		if condition.Access == nil {
+			return true, nil, nil
+		}
+		allowed = true
+		rules = append(rules, *condition.Access)
+	}
+	return allowed, rules, nil
+}
+
+func (c *ClusterSecretStoreCondition) matchesNamespace(namespace *corev1.Namespace) (bool, error) {
+	var labelSelectors []*metav1.LabelSelector
+	if c.NamespaceSelector != nil {
+		labelSelectors = append(labelSelectors, c.NamespaceSelector)
+	}
+	for _, n := range c.Namespaces {
+		labelSelectors = append(labelSelectors, &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"kubernetes.io/metadata.name": n,
+			},
+		})
+	}
+
+	nsLabels := labels.Set(namespace.GetLabels())
+	for _, ls := range labelSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(ls)
+		if err != nil {
+			return false, fmt.Errorf("failed to convert label selector into selector %v: %w", ls, err)
+		}
+		if selector.Matches(nsLabels) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// KeyAccess holds the access rules of a ClusterSecretStore rendered for one namespace,
+// so that the templates are executed and the regular expressions compiled only once.
+// A nil KeyAccess allows access to any remote key.
+type KeyAccess struct {
+	namespace string
+	rules     []keyAccessRule
+}
+
+type keyAccessRule struct {
+	keyPrefixes []string
+	keyRegexes  []*regexp.Regexp
+	findPaths   []string
+}
+
+// NewKeyAccess renders the access rules for the namespace.
+// It returns nil if there are no rules.
+func NewKeyAccess(rules []ClusterSecretStoreAccess, namespace metav1.ObjectMeta) (*KeyAccess, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	data := namespaceTemplateData(namespace)
+	access := &KeyAccess{
+		namespace: namespace.Name,
+		rules:     make([]keyAccessRule, 0, len(rules)),
+	}
+	for i := range rules {
+		rule, err := rules[i].render(data)
+		if err != nil {
+			return nil, err
+		}
+		access.rules = append(access.rules, rule)
+	}
+	return access, nil
+}
+
+// CheckKey returns an error if none of the access rules allows the namespace to access the remote key.
+func (k *KeyAccess) CheckKey(key string) error {
+	if k == nil {
+		return nil
+	}
+	for i := range k.rules {
+		if k.rules[i].allowsKey(key) {
+			return nil
+		}
+	}
+	return fmt.Errorf(errKeyAccessDenied, key, k.namespace)
+}
+
+// CheckFind returns an error if none of the access rules allows the namespace to find remote keys.
+func (k *KeyAccess) CheckFind(find ExternalSecretFind) error {
+	if k == nil {
+		return nil
+	}
+	for i := range k.rules {
+		if k.rules[i].allowsFind(find) {
+			return nil
+		}
+	}
+	path := ""
+	if find.Path != nil {
+		path = *find.Path
+	}
+	return fmt.Errorf(errFindAccessDenied, path, k.namespace)
+}
+
+// CheckKeyAccess returns an error if none of the access rules allows the namespace to access the remote key.
+func CheckKeyAccess(rules []ClusterSecretStoreAccess, namespace metav1.ObjectMeta, key string) error {
+	access, err := NewKeyAccess(rules, namespace)
+	if err != nil {
+		return err
+	}
+	return access.CheckKey(key)
+}
+
+// CheckFindAccess returns an error if none of the access rules allows the namespace to find remote keys.
+func CheckFindAccess(rules []ClusterSecretStoreAccess, namespace metav1.ObjectMeta, find ExternalSecretFind) error {
+	access, err := NewKeyAccess(rules, namespace)
+	if err != nil {
+		return err
+	}
+	return access.CheckFind(find)
+}
+
+// render executes the templates of the access rule and compiles its regular expressions.
+func (a *ClusterSecretStoreAccess) render(data map[string]any) (keyAccessRule, error) {
+	var rule keyAccessRule
+	for i, in := range a.KeyPrefixes {
+		prefix, err := executeNamespaceTemplate(fmt.Sprintf("keyPrefixes[%d]", i), in, data)
+		if err != nil {
+			return rule, err
+		}
+		rule.keyPrefixes = append(rule.keyPrefixes, prefix)
+	}
+	for i, in := range a.KeyRegexes {
+		path := fmt.Sprintf("keyRegexes[%d]", i)
+		expr, err := executeNamespaceTemplate(path, in, data)
+		if err != nil {
+			return rule, err
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return rule, fmt.Errorf("unable to compile regular expression at %s: %w", path, err)
+		}
+		rule.keyRegexes = append(rule.keyRegexes, re)
+	}
+	for i, in := range a.FindPaths {
+		prefix, err := executeNamespaceTemplate(fmt.Sprintf("findPaths[%d]", i), in, data)
+		if err != nil {
+			return rule, err
+		}
+		rule.findPaths = append(rule.findPaths, prefix)
+	}
+	return rule, nil
+}
+
+func (r *keyAccessRule) restrictsKeys() bool {
+	return len(r.keyPrefixes) > 0 || len(r.keyRegexes) > 0
+}
+
+func (r *keyAccessRule) allowsKey(key string) bool {
+	if !r.restrictsKeys() {
+		return true
+	}
+	for _, prefix := range r.keyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	for _, re := range r.keyRegexes {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *keyAccessRule) allowsFind(find ExternalSecretFind) bool {
+	if len(r.findPaths) == 0 {
+		return !r.restrictsKeys()
+	}
+	if find.Path == nil {
+		return false
+	}
+	for _, prefix := range r.findPaths {
+		if strings.HasPrefix(*find.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validate parses the templates of the access rules.
+func (a *ClusterSecretStoreAccess) validate() error {
+	var errs error
+	for _, field := range []struct {
+		path   string
+		values []string
+	}{
+		{"keyPrefixes", a.KeyPrefixes},
+		{"keyRegexes", a.KeyRegexes},
+		{"findPaths", a.FindPaths},
+	} {
+		for i, in := range field.values {
+			if _, err := parseNamespaceTemplate(fmt.Sprintf("%s[%d]", field.path, i), in); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,315 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func makeAccessNamespace(name string, labels map[string]string) *corev1.Namespace {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["kubernetes.io/metadata.name"] = name
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
+
+func makeAccessStore(conditions ...ClusterSecretStoreCondition) *ClusterSecretStore {
+	return &ClusterSecretStore{
+		TypeMeta: metav1.TypeMeta{Kind: ClusterSecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "shared",
+		},
+		Spec: SecretStoreSpec{
+			Conditions: conditions,
+		},
+	}
+}
+
+func TestNamespaceStoreAccess(t *testing.T) {
+	tenantAccess := &ClusterSecretStoreAccess{KeyPrefixes: []string{"{{ .namespace.name }}/"}}
+	tests := []struct {
+		name        string
+		store       GenericStore
+		namespace   *corev1.Namespace
+		wantAllowed bool
+		wantRules   int
+	}{
+		{
+			name:        "secret store is not restricted",
+			store:       &SecretStore{TypeMeta: metav1.TypeMeta{Kind: SecretStoreKind}},
+			namespace:   makeAccessNamespace("foo", nil),
+			wantAllowed: true,
+		},
+		{
+			name:        "cluster secret store without conditions",
+			store:       makeAccessStore(),
+			namespace:   makeAccessNamespace("foo", nil),
+			wantAllowed: true,
+		},
+		{
+			name:        "namespace not chosen",
+			store:       makeAccessStore(ClusterSecretStoreCondition{Namespaces: []string{"bar"}, Access: tenantAccess}),
+			namespace:   makeAccessNamespace("foo", nil),
+			wantAllowed: false,
+		},
+		{
+			name:        "namespace chosen with access rules",
+			store:       makeAccessStore(ClusterSecretStoreCondition{Namespaces: []string{"foo"}, Access: tenantAccess}),
+			namespace:   makeAccessNamespace("foo", nil),
+			wantAllowed: true,
+			wantRules:   1,
+		},
+		{
+			name: "unrestricted condition takes precedence",
+			store: makeAccessStore(
+				ClusterSecretStoreCondition{Namespaces: []string{"foo"}, Access: tenantAccess},
+				ClusterSecretStoreCondition{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}},
+			),
+			namespace:   makeAccessNamespace("foo", map[string]string{"team": "platform"}),
+			wantAllowed: true,
+		},
+		{
+			name: "rules of all chosen conditions",
+			store: makeAccessStore(
+				ClusterSecretStoreCondition{Namespaces: []string{"foo"}, Access: tenantAccess},
+				ClusterSecretStoreCondition{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+					Access:            &ClusterSecretStoreAccess{KeyPrefixes: []string{"platform/"}},
+				},
+			),
+			namespace:   makeAccessNamespace("foo", map[string]string{"team": "platform"}),
+			wantAllowed: true,
+			wantRules:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, rules, err := NamespaceStoreAccess(tt.store, tt.namespace)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("allowed = %v, want %v", allowed, tt.wantAllowed)
+			}
+			if len(rules) != tt.wantRules {
+				t.Errorf("got %d rules, want %d", len(rules), tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestCheckKeyAccess(t *testing.T) {
+	namespace := makeAccessNamespace("foo", map[string]string{"team": "payments"}).ObjectMeta
+	rules := []ClusterSecretStoreAccess{
+		{
+			KeyPrefixes: []string{"{{ .namespace.name }}/"},
+			KeyRegexes:  []string{"^shared/{{ .namespace.labels.team }}-[a-z]+$"},
+		},
+	}
+	tests := []struct {
+		name        string
+		rules       []ClusterSecretStoreAccess
+		key         string
+		expectedErr string
+	}{
+		{
+			name: "no rules",
+			key:  "anything",
+		},
+		{
+			name:  "prefix with namespace name",
+			rules: rules,
+			key:   "foo/db-password",
+		},
+		{
+			name:  "regex with namespace label",
+			rules: rules,
+			key:   "shared/payments-api",
+		},
+		{
+			name:        "key of another namespace",
+			rules:       rules,
+			key:         "bar/db-password",
+			expectedErr: `access to remote key "bar/db-password" is not allowed from namespace "foo"`,
+		},
+		{
+			name:  "rule without key restriction",
+			rules: []ClusterSecretStoreAccess{{FindPaths: []string{"foo/"}}},
+			key:   "bar/db-password",
+		},
+		{
+			name:        "invalid template",
+			rules:       []ClusterSecretStoreAccess{{KeyPrefixes: []string{"{{ .namespace.labels.missing }}"}}},
+			key:         "foo/db-password",
+			expectedErr: `unable to execute template at keyPrefixes[0]: template: keyPrefixes[0]:1:13: executing "keyPrefixes[0]" at <.namespace.labels.missing>: map has no entry for key "missing"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckKeyAccess(tt.rules, namespace, tt.key)
+			if tt.expectedErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedErr != "" && (err == nil || err.Error() != tt.expectedErr) {
+				t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckFindAccess(t *testing.T) {
+	namespace := makeAccessNamespace("foo", nil).ObjectMeta
+	path := func(p string) *string { return &p }
+	tests := []struct {
+		name        string
+		rules       []ClusterSecretStoreAccess
+		find        ExternalSecretFind
+		expectedErr string
+	}{
+		{
+			name: "no rules",
+			find: ExternalSecretFind{},
+		},
+		{
+			name:  "keys not restricted",
+			rules: []ClusterSecretStoreAccess{{}},
+			find:  ExternalSecretFind{},
+		},
+		{
+			name:        "keys restricted without find paths",
+			rules:       []ClusterSecretStoreAccess{{KeyPrefixes: []string{"foo/"}}},
+			find:        ExternalSecretFind{Path: path("foo/")},
+			expectedErr: `find in path "foo/" is not allowed from namespace "foo"`,
+		},
+		{
+			name:  "allowed find path",
+			rules: []ClusterSecretStoreAccess{{KeyPrefixes: []string{"foo/"}, FindPaths: []string{"{{ .namespace.name }}/"}}},
+			find:  ExternalSecretFind{Path: path("foo/app")},
+		},
+		{
+			name:        "find without path",
+			rules:       []ClusterSecretStoreAccess{{FindPaths: []string{"{{ .namespace.name }}/"}}},
+			find:        ExternalSecretFind{},
+			expectedErr: `find in path "" is not allowed from namespace "foo"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckFindAccess(tt.rules, namespace, tt.find)
+			if tt.expectedErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedErr != "" && (err == nil || err.Error() != tt.expectedErr) {
+				t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestExternalSecretValidatorStoreAccess(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = AddToScheme(scheme)
+	store := makeAccessStore(ClusterSecretStoreCondition{
+		Namespaces: []string{"foo"},
+		Access:     &ClusterSecretStoreAccess{KeyPrefixes: []string{"{{ .namespace.name }}/"}},
+	})
+	validator := &ExternalSecretValidator{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(store, makeAccessNamespace("foo", nil)).Build(),
+	}
+	makeES := func(storeName string, keys ...string) *ExternalSecret {
+		es := &ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "foo"},
+			Spec: ExternalSecretSpec{
+				SecretStoreRef: SecretStoreRef{Name: storeName, Kind: ClusterSecretStoreKind},
+			},
+		}
+		for _, key := range keys {
+			es.Spec.Data = append(es.Spec.Data, ExternalSecretData{SecretKey: key, RemoteRef: ExternalSecretDataRemoteRef{Key: key}})
+		}
+		return es
+	}
+
+	tests := []struct {
+		name        string
+		obj         *ExternalSecret
+		expectedErr string
+	}{
+		{
+			name: "allowed keys",
+			obj:  makeES("shared", "foo/a", "foo/b"),
+		},
+		{
+			name:        "denied key",
+			obj:         makeES("shared", "foo/a", "bar/b"),
+			expectedErr: `spec.data[1]: access to remote key "bar/b" is not allowed from namespace "foo"`,
+		},
+		{
+			name: "store does not exist",
+			obj:  makeES("missing", "bar/b"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.obj)
+			if tt.expectedErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedErr != "" && (err == nil || err.Error() != tt.expectedErr) {
+				t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestNewKeyAccess(t *testing.T) {
+	namespace := makeAccessNamespace("foo", nil).ObjectMeta
+	access, err := NewKeyAccess(nil, namespace)
+	if err != nil || access != nil {
+		t.Fatalf("expected no access rules, got %v, %v", access, err)
+	}
+	if err := access.CheckKey("bar/db"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = NewKeyAccess([]ClusterSecretStoreAccess{{KeyRegexes: []string{"{{ .namespace.name }}/("}}}, namespace)
+	if err == nil || !strings.HasPrefix(err.Error(), "unable to compile regular expression at keyRegexes[0]") {
+		t.Fatalf("expected compile error, got %v", err)
+	}
+
+	access, err = NewKeyAccess([]ClusterSecretStoreAccess{{KeyRegexes: []string{"^{{ .namespace.name }}/[a-z]+$"}}}, namespace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for key, allowed := range map[string]bool{"foo/db": true, "foo/db1": false, "bar/db": false} {
+		if err := access.CheckKey(key); (err == nil) != allowed {
+			t.Errorf("key %s: expected allowed %v, got %v", key, allowed, err)
+		}
+	}
+}
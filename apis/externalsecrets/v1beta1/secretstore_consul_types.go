@@ -0,0 +1,69 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// ConsulProvider reads and writes keys of the Consul KV store.
+type ConsulProvider struct {
+	// Address of the Consul HTTP API, for example https://consul.example.com:8501.
+	Address string `json:"address"`
+
+	// PEM encoded CA bundle used to validate the certificate of the server.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Datacenter of the KV store, defaults to the datacenter of the agent.
+	// +optional
+	Datacenter string `json:"datacenter,omitempty"`
+
+	// Namespace of the KV store, requires Consul Enterprise.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Partition is the admin partition of the KV store, requires Consul Enterprise.
+	// +optional
+	Partition string `json:"partition,omitempty"`
+
+	// Auth configures how the operator authenticates to Consul.
+	// Without auth the anonymous token is used.
+	// +optional
+	Auth *ConsulAuth `json:"auth,omitempty"`
+}
+
+// ConsulAuth configures the ACL token of the operator.
+// Exactly one of tokenSecretRef or kubernetes must be set.
+type ConsulAuth struct {
+	// TokenSecretRef references the secret ID of an ACL token.
+	// +optional
+	TokenSecretRef *esmeta.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+
+	// Kubernetes logs in with a Consul auth method of type kubernetes.
+	// +optional
+	Kubernetes *ConsulKubernetesAuth `json:"kubernetes,omitempty"`
+}
+
+// ConsulKubernetesAuth logs in with a service account token,
+// the ACL token is destroyed when the client is closed.
+type ConsulKubernetesAuth struct {
+	// AuthMethod is the name of the Consul auth method.
+	AuthMethod string `json:"authMethod"`
+
+	// ServiceAccountRef is the service account a token is requested for
+	// with the TokenRequest API.
+	ServiceAccountRef esmeta.ServiceAccountSelector `json:"serviceAccountRef"`
+}
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const errFeatureNotSupported = "%s is not supported by %s %q"
+
+// GetStoreFeatures returns the features the provider of the store supports.
+// It returns nil if the provider does not describe its features.
+func GetStoreFeatures(store GenericStore) (*SecretStoreFeatures, error) {
+	provider, err := GetProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	fp, ok := provider.(FeatureProvider)
+	if !ok {
+		return nil, nil
+	}
+	features := fp.Features(store)
+	return &features, nil
+}
+
+// LookupStore returns the store referenced from the given namespace.
+// It returns nil if the store does not exist or can not be read by the reader.
+func LookupStore(ctx context.Context, reader client.Reader, ref SecretStoreRef, namespace string) (GenericStore, error) {
+	var store GenericStore = &SecretStore{}
+	key := client.ObjectKey{Name: ref.Name, Namespace: namespace}
+	if ref.Kind == ClusterSecretStoreKind {
+		store = &ClusterSecretStore{}
+		key.Namespace = ""
+	}
+	err := reader.Get(ctx, key, store)
+	if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get %s %q: %w", store.GetKind(), ref.Name, err)
+	}
+	return store, nil
+}
+
+// UnsupportedFind returns the fields of the find operation the store can not serve.
+func (f *SecretStoreFeatures) UnsupportedFind(find ExternalSecretFind) []string {
+	var fields []string
+	if find.Name != nil && !f.FindByName {
+		fields = append(fields, "find.name")
+	}
+	if len(find.Tags) > 0 && !f.FindByTags {
+		fields = append(fields, "find.tags")
+	}
+	if find.Path != nil && !f.FindByPath {
+		fields = append(fields, "find.path")
+	}
+	return fields
+}
+
+// UnsupportedRemoteRef returns the fields of the remote reference the store can not serve.
+func (f *SecretStoreFeatures) UnsupportedRemoteRef(ref ExternalSecretDataRemoteRef) []string {
+	var fields []string
+	if ref.Version != "" && !f.Versions {
+		fields = append(fields, "remoteRef.version")
+	}
+	if ref.MetadataPolicy == ExternalSecretMetadataPolicyFetch && !f.MetadataFetch {
+		fields = append(fields, "metadataPolicy=Fetch")
+	}
+	return fields
+}
+
+// UnsupportedPushData returns the parts of the push the store can not serve.
+func (f *SecretStoreFeatures) UnsupportedPushData(data PushSecretData) []string {
+	var fields []string
+	if data.GetMetadata() != nil && !f.PushMetadata {
+		fields = append(fields, "metadata")
+	}
+	if data.GetSecretKey() == "" && !f.PushWholeSecret {
+		fields = append(fields, "pushing the whole Secret")
+	}
+	return fields
+}
+
+// FeatureErrors formats the unsupported fields of the store as errors prefixed with path.
+func FeatureErrors(path string, store GenericStore, fields []string) []error {
+	errs := make([]error, 0, len(fields))
+	for _, field := range fields {
+		errs = append(errs, fmt.Errorf("%s: "+errFeatureNotSupported, path, field, store.GetKind(), store.GetName()))
+	}
+	return errs
+}
@@ -0,0 +1,143 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type featurePP struct {
+	PP
+	features SecretStoreFeatures
+}
+
+func (p *featurePP) Features(_ GenericStore) SecretStoreFeatures {
+	return p.features
+}
+
+func TestExternalSecretValidatorStoreFeatures(t *testing.T) {
+	ForceRegister(&featurePP{features: SecretStoreFeatures{FindByName: true}}, &SecretStoreProvider{Fake: &FakeProvider{}})
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = AddToScheme(scheme)
+	store := &SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "foo"},
+		Spec: SecretStoreSpec{
+			Provider: &SecretStoreProvider{Fake: &FakeProvider{}},
+		},
+	}
+	validator := &ExternalSecretValidator{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build(),
+	}
+	path := "team/"
+	makeES := func(storeName string, data []ExternalSecretData, dataFrom []ExternalSecretDataFromRemoteRef) *ExternalSecret {
+		return &ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "foo"},
+			Spec: ExternalSecretSpec{
+				SecretStoreRef: SecretStoreRef{Name: storeName, Kind: SecretStoreKind},
+				Data:           data,
+				DataFrom:       dataFrom,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		obj         *ExternalSecret
+		expectedErr string
+	}{
+		{
+			name: "supported find by name",
+			obj:  makeES("fake", nil, []ExternalSecretDataFromRemoteRef{{Find: &ExternalSecretFind{Name: &FindName{RegExp: ".*"}}}}),
+		},
+		{
+			name:        "unsupported find by tags and path",
+			obj:         makeES("fake", nil, []ExternalSecretDataFromRemoteRef{{Find: &ExternalSecretFind{Tags: map[string]string{"team": "a"}, Path: &path}}}),
+			expectedErr: "spec.dataFrom[0]: find.tags is not supported by SecretStore \"fake\"\nspec.dataFrom[0]: find.path is not supported by SecretStore \"fake\"",
+		},
+		{
+			name: "unsupported version and metadata",
+			obj: makeES("fake", []ExternalSecretData{
+				{SecretKey: "a", RemoteRef: ExternalSecretDataRemoteRef{Key: "a"}},
+				{SecretKey: "b", RemoteRef: ExternalSecretDataRemoteRef{Key: "b", Version: "1", MetadataPolicy: ExternalSecretMetadataPolicyFetch}},
+			}, nil),
+			expectedErr: "spec.data[1]: remoteRef.version is not supported by SecretStore \"fake\"\nspec.data[1]: metadataPolicy=Fetch is not supported by SecretStore \"fake\"",
+		},
+		{
+			name: "store does not exist",
+			obj:  makeES("missing", []ExternalSecretData{{SecretKey: "a", RemoteRef: ExternalSecretDataRemoteRef{Key: "a", Version: "1"}}}, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.obj)
+			if tt.expectedErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedErr != "" && (err == nil || err.Error() != tt.expectedErr) {
+				t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestExternalSecretValidatorUpdate(t *testing.T) {
+	ForceRegister(&featurePP{features: SecretStoreFeatures{FindByName: true}}, &SecretStoreProvider{Fake: &FakeProvider{}})
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = AddToScheme(scheme)
+	store := &SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "foo"},
+		Spec: SecretStoreSpec{
+			Provider: &SecretStoreProvider{Fake: &FakeProvider{}},
+		},
+	}
+	validator := &ExternalSecretValidator{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build(),
+	}
+	// the store does not support versions, e.g. because it was changed after the ExternalSecret was created
+	oldES := &ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "foo", Finalizers: []string{FinalizerAdditionalTargets}},
+		Spec: ExternalSecretSpec{
+			SecretStoreRef: SecretStoreRef{Name: "fake", Kind: SecretStoreKind},
+			Data:           []ExternalSecretData{{SecretKey: "a", RemoteRef: ExternalSecretDataRemoteRef{Key: "a", Version: "1"}}},
+		},
+	}
+
+	unchanged := oldES.DeepCopy()
+	unchanged.Finalizers = nil
+	if _, err := validator.ValidateUpdate(context.Background(), oldES, unchanged); err != nil {
+		t.Errorf("update without spec change must be allowed: %v", err)
+	}
+
+	deleted := oldES.DeepCopy()
+	deleted.DeletionTimestamp = &metav1.Time{}
+	deleted.Spec.Data[0].RemoteRef.Version = "2"
+	if _, err := validator.ValidateUpdate(context.Background(), oldES, deleted); err != nil {
+		t.Errorf("update of a deleted ExternalSecret must be allowed: %v", err)
+	}
+
+	changed := oldES.DeepCopy()
+	changed.Spec.Data[0].RemoteRef.Version = "2"
+	if _, err := validator.ValidateUpdate(context.Background(), oldES, changed); err == nil {
+		t.Errorf("update with spec change must be validated against the store")
+	}
+}
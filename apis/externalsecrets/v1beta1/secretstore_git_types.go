@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// GitProvider reads files of a Git repository at a branch, tag or commit.
+// A file is addressed by its path in the repository.
+type GitProvider struct {
+	// URL of the repository, an https://, http:// or ssh:// URL,
+	// or the scp-like syntax user@host:path.
+	URL string `json:"url"`
+
+	// Ref is the branch, tag or commit the files are read from.
+	// Defaults to the default branch of the repository.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Auth holds the credentials of the repository, public repositories need none.
+	// +optional
+	Auth *GitAuth `json:"auth,omitempty"`
+
+	// PEM encoded CA bundle used to validate the certificate of an HTTPS server.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Keys decrypt SOPS-encrypted documents and age-encrypted files of the repository.
+	// +optional
+	Keys *SopsKeys `json:"keys,omitempty"`
+}
+
+// GitAuth holds the credentials of a repository.
+// Exactly one of basicAuth or ssh must be set.
+type GitAuth struct {
+	// BasicAuth authenticates to an HTTPS server with a username and a password or access token.
+	// +optional
+	BasicAuth *GitBasicAuth `json:"basicAuth,omitempty"`
+
+	// SSH authenticates to an SSH server with a private key.
+	// +optional
+	SSH *GitSSHAuth `json:"ssh,omitempty"`
+}
+
+// GitBasicAuth authenticates with a username and a password or access token.
+type GitBasicAuth struct {
+	// Username, defaults to git. Most servers accept any username with an access token.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef references the password or access token.
+	PasswordSecretRef esmeta.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// GitSSHAuth authenticates with an SSH private key.
+type GitSSHAuth struct {
+	// Username, defaults to the user of the URL or git.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// PrivateKeySecretRef references the PEM or OpenSSH encoded private key.
+	PrivateKeySecretRef esmeta.SecretKeySelector `json:"privateKeySecretRef"`
+
+	// PassphraseSecretRef references the passphrase of the private key, if it is encrypted.
+	// +optional
+	PassphraseSecretRef *esmeta.SecretKeySelector `json:"passphraseSecretRef,omitempty"`
+
+	// KnownHostsSecretRef references the known_hosts entries the host key
+	// of the server is verified against.
+	KnownHostsSecretRef esmeta.SecretKeySelector `json:"knownHostsSecretRef"`
+}
@@ -0,0 +1,59 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// KeePassProvider reads the entries of a KeePass database in the KDBX 3.1 or 4 format.
+// An entry is addressed by the names of its groups and its title, separated by slashes.
+type KeePassProvider struct {
+	// Database references the database file.
+	Database KeePassDatabase `json:"database"`
+
+	// Auth holds the credentials which unlock the database.
+	Auth KeePassAuth `json:"auth"`
+}
+
+// KeePassDatabase references the database file.
+// Exactly one of secretRef or url must be set.
+type KeePassDatabase struct {
+	// SecretRef references the key of a Secret containing the database.
+	// Pushed secrets are written back to the Secret.
+	// +optional
+	SecretRef *esmeta.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// URL the database is downloaded from. A database referenced by URL is read-only.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// PEM encoded CA bundle used to validate the certificate of the server hosting the database.
+	// If not set the system root certificates are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// KeePassAuth holds the credentials which unlock the database.
+// At least one of passwordSecretRef or keyFileSecretRef must be set.
+type KeePassAuth struct {
+	// PasswordSecretRef references the master password of the database.
+	// +optional
+	PasswordSecretRef *esmeta.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// KeyFileSecretRef references the key file of the database.
+	// +optional
+	KeyFileSecretRef *esmeta.SecretKeySelector `json:"keyFileSecretRef,omitempty"`
+}
@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// SopsProvider decrypts SOPS-encrypted YAML and JSON documents.
+type SopsProvider struct {
+	// Documents lists the sources of the encrypted documents.
+	// A document is addressed by its key in the ConfigMap or Secret,
+	// or by its path relative to the directory. If several sources contain
+	// a document with the same name, the first one is used.
+	// +kubebuilder:validation:MinItems=1
+	Documents []SopsDocumentSource `json:"documents"`
+
+	// Keys are the private keys used to decrypt the documents.
+	Keys SopsKeys `json:"keys"`
+}
+
+// SopsDocumentSource contains encrypted documents.
+// Exactly one of configMapRef, secretRef or path must be set.
+type SopsDocumentSource struct {
+	// ConfigMapRef references a ConfigMap, each key is a document.
+	// +optional
+	ConfigMapRef *SopsObjectRef `json:"configMapRef,omitempty"`
+
+	// SecretRef references a Secret, each key is a document.
+	// +optional
+	SecretRef *SopsObjectRef `json:"secretRef,omitempty"`
+
+	// Path of a file or directory mounted into the controller.
+	// Files of the directory and its subdirectories are documents,
+	// hidden files and directories are skipped.
+	// Only a ClusterSecretStore may read documents from the filesystem of the controller.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// SopsObjectRef references a ConfigMap or Secret.
+type SopsObjectRef struct {
+	// Name of the object.
+	Name string `json:"name"`
+
+	// Namespace of the object, ignored for a SecretStore.
+	// If unset for a ClusterSecretStore, the namespace of the ExternalSecret is used.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// SopsKeys are the private keys used to decrypt the data key of the documents.
+// A document can be decrypted with any key it was encrypted for.
+type SopsKeys struct {
+	// Age references age identities, as created by age-keygen.
+	// A Secret key may contain several identities, one per line.
+	// +optional
+	Age []esmeta.SecretKeySelector `json:"age,omitempty"`
+
+	// PGP references armored PGP private keys.
+	// +optional
+	PGP []SopsPGPKey `json:"pgp,omitempty"`
+}
+
+// SopsPGPKey references an armored PGP private key.
+type SopsPGPKey struct {
+	// SecretRef references the armored private key.
+	SecretRef esmeta.SecretKeySelector `json:"secretRef"`
+
+	// PassphraseSecretRef references the passphrase of the private key, if it is encrypted.
+	// +optional
+	PassphraseSecretRef *esmeta.SecretKeySelector `json:"passphraseSecretRef,omitempty"`
+}
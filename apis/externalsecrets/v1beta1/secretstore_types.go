@@ -40,6 +40,52 @@ type SecretStoreSpec struct {
 	// Used to constraint a ClusterSecretStore to specific namespaces. Relevant only to ClusterSecretStore
 	// +optional
 	Conditions []ClusterSecretStoreCondition `json:"conditions,omitempty"`
+
+	// Used to periodically read a canary key from the provider to check that the store can actually read secrets.
+	// +optional
+	HealthCheck *SecretStoreHealthCheck `json:"healthCheck,omitempty"`
+
+	// Defaults are applied to ExternalSecrets which reference the store and leave the fields unset.
+	// +optional
+	Defaults *SecretStoreDefaults `json:"defaults,omitempty"`
+}
+
+// SecretStoreDefaults are applied by the controller to the ExternalSecrets which reference the store
+// in spec.secretStoreRef. Fields set in the ExternalSecret take precedence.
+type SecretStoreDefaults struct {
+	// RefreshInterval of ExternalSecrets which do not set spec.refreshInterval.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// ConversionStrategy of data and dataFrom entries which do not set one.
+	// +optional
+	ConversionStrategy ExternalSecretConversionStrategy `json:"conversionStrategy,omitempty"`
+
+	// DecodingStrategy of data and dataFrom entries which do not set one.
+	// +optional
+	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+
+	// DeletionPolicy of targets which do not set one.
+	// It is not applied to targets whose creationPolicy does not allow it.
+	// +optional
+	DeletionPolicy ExternalSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// TemplateMetadata labels and annotations are added to the target Secrets
+	// unless the ExternalSecret sets them itself.
+	// +optional
+	TemplateMetadata *ExternalSecretTemplateMetadata `json:"templateMetadata,omitempty"`
+}
+
+// SecretStoreHealthCheck configures a periodic read of a canary key through the store.
+// The store is not ready while the canary key can not be read.
+type SecretStoreHealthCheck struct {
+	// RemoteRef points to the canary key which is read by the health check.
+	RemoteRef ExternalSecretDataRemoteRef `json:"remoteRef"`
+
+	// Interval between two health checks.
+	// Empty or 0 falls back to the refresh interval of the store.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
 // ClusterSecretStoreCondition describes a condition by which to choose namespaces to process ExternalSecrets in
@@ -51,6 +97,31 @@ type ClusterSecretStoreCondition struct {
 
 	// Choose namespaces by name
 	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Restricts the remote keys the chosen namespaces may read and write.
+	// If not set, the chosen namespaces may access any remote key.
+	// +optional
+	Access *ClusterSecretStoreAccess `json:"access,omitempty"`
+}
+
+// ClusterSecretStoreAccess restricts the remote keys a namespace may access through a ClusterSecretStore.
+// Prefixes, regular expressions and find paths are Go templates evaluated against the requesting Namespace,
+// e.g. `team-{{ .namespace.name }}/` or `^apps/{{ .namespace.labels.team }}/.+$`.
+// If a namespace is chosen by several conditions, a remote key is allowed if any of them allows it.
+type ClusterSecretStoreAccess struct {
+	// KeyPrefixes lists prefixes of the remote keys which may be accessed.
+	// +optional
+	KeyPrefixes []string `json:"keyPrefixes,omitempty"`
+
+	// KeyRegexes lists regular expressions matching the remote keys which may be accessed.
+	// If neither keyPrefixes nor keyRegexes are set, any remote key may be accessed.
+	// +optional
+	KeyRegexes []string `json:"keyRegexes,omitempty"`
+
+	// FindPaths lists the paths dataFrom.find may search. A find has to set a path starting with one of them.
+	// If no find path is set, dataFrom.find is only allowed if the remote keys are not restricted.
+	// +optional
+	FindPaths []string `json:"findPaths,omitempty"`
 }
 
 // SecretStoreProvider contains the provider-specific configuration.
@@ -163,6 +234,22 @@ type SecretStoreProvider struct {
 
 	// +optional
 	Passbolt *PassboltProvider `json:"passbolt,omitempty"`
+
+	// Sops configures this store to decrypt SOPS-encrypted documents
+	// +optional
+	Sops *SopsProvider `json:"sops,omitempty"`
+
+	// KeePass configures this store to read entries of a KeePass database
+	// +optional
+	KeePass *KeePassProvider `json:"keepass,omitempty"`
+
+	// Git configures this store to read files of a Git repository
+	// +optional
+	Git *GitProvider `json:"git,omitempty"`
+
+	// Consul configures this store to sync secrets using the Consul KV store
+	// +optional
+	Consul *ConsulProvider `json:"consul,omitempty"`
 }
 
 type CAProviderType string
@@ -207,6 +294,7 @@ const (
 	ReasonInvalidProviderConfig = "InvalidProviderConfig"
 	ReasonValidationFailed      = "ValidationFailed"
 	ReasonStoreValid            = "Valid"
+	ReasonStoreDegraded         = "Degraded"
 )
 
 type SecretStoreStatusCondition struct {
@@ -232,12 +320,70 @@ const (
 	SecretStoreReadWrite SecretStoreCapabilities = "ReadWrite"
 )
 
+// SecretStoreFeatures describes the optional features a SecretStore supports
+// beyond its Capabilities. ExternalSecrets and PushSecrets using a feature
+// the store does not support are rejected by the webhook.
+type SecretStoreFeatures struct {
+	// FindByName is true if secrets can be found by name with dataFrom.find.name.
+	// +optional
+	FindByName bool `json:"findByName,omitempty"`
+	// FindByTags is true if secrets can be found by tags with dataFrom.find.tags.
+	// +optional
+	FindByTags bool `json:"findByTags,omitempty"`
+	// FindByPath is true if the search can be limited to a path with dataFrom.find.path.
+	// +optional
+	FindByPath bool `json:"findByPath,omitempty"`
+	// Versions is true if a specific version can be read with remoteRef.version.
+	// +optional
+	Versions bool `json:"versions,omitempty"`
+	// MetadataFetch is true if the metadata of a secret can be read with metadataPolicy=Fetch.
+	// +optional
+	MetadataFetch bool `json:"metadataFetch,omitempty"`
+	// PushMetadata is true if metadata can be attached to pushed secrets.
+	// +optional
+	PushMetadata bool `json:"pushMetadata,omitempty"`
+	// PushWholeSecret is true if a whole Secret can be pushed without secretKey.
+	// +optional
+	PushWholeSecret bool `json:"pushWholeSecret,omitempty"`
+	// DeleteSecret is true if pushed secrets can be deleted with deletionPolicy=Delete.
+	// +optional
+	DeleteSecret bool `json:"deleteSecret,omitempty"`
+	// SecretExists is true if the store can check for existing secrets, which updatePolicy=IfNotExists requires.
+	// +optional
+	SecretExists bool `json:"secretExists,omitempty"`
+}
+
 // SecretStoreStatus defines the observed state of the SecretStore.
 type SecretStoreStatus struct {
 	// +optional
 	Conditions []SecretStoreStatusCondition `json:"conditions,omitempty"`
 	// +optional
 	Capabilities SecretStoreCapabilities `json:"capabilities,omitempty"`
+	// Features lists the optional features the provider of the store supports.
+	// +optional
+	Features *SecretStoreFeatures `json:"features,omitempty"`
+	// HealthCheck reports the result of the last health check.
+	// +optional
+	HealthCheck *SecretStoreHealthCheckStatus `json:"healthCheck,omitempty"`
+}
+
+// SecretStoreHealthCheckStatus reports the result of the last read of the canary key.
+type SecretStoreHealthCheckStatus struct {
+	// LastCheckTime is the time the canary key was read last.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// Latency of the last read of the canary key.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+	// LastError is the error returned by the provider during the last read, if any.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// ConsecutiveFailures is the number of failed reads since the last successful read.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+	// ObservedGeneration is the generation of the store the health check ran for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
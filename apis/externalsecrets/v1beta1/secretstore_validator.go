@@ -54,6 +54,14 @@ func (r *GenericStoreValidator) ValidateDelete(_ context.Context, _ runtime.Obje
 }
 
 func validateStore(store GenericStore) (admission.Warnings, error) {
+	for i, condition := range store.GetSpec().Conditions {
+		if condition.Access == nil {
+			continue
+		}
+		if err := condition.Access.validate(); err != nil {
+			return nil, fmt.Errorf("invalid access rules in conditions[%d]: %w", i, err)
+		}
+	}
 	provider, err := GetProvider(store)
 	if err != nil {
 		return nil, err
@@ -86,6 +86,22 @@ type VaultProvider struct {
 	// https://www.vaultproject.io/docs/configuration/replication#allow_forwarding_via_header
 	// +optional
 	ForwardInconsistent bool `json:"forwardInconsistent,omitempty"`
+
+	// CheckAndSet configures check-and-set for secrets pushed to a KV v2 engine.
+	// Only supported for KV v2.
+	// +optional
+	CheckAndSet *VaultCheckAndSet `json:"checkAndSet,omitempty"`
+}
+
+// VaultCheckAndSet configures check-and-set writes of PushSecrets to a KV v2 engine.
+// With check-and-set the version of the secret read before a push is sent along with the write,
+// Vault rejects the write if the secret was changed in the meantime and the push fails with a conflict
+// instead of overwriting the change.
+type VaultCheckAndSet struct {
+	// Required writes every secret with check-and-set, to protect secrets against concurrent writers.
+	// Secrets whose metadata or mount configuration has cas_required set are always written with check-and-set.
+	// +optional
+	Required bool `json:"required,omitempty"`
 }
 
 // VaultClientTLS is the configuration used for client side related TLS communication,
@@ -151,6 +167,16 @@ type VaultAuth struct {
 	// UserPass authenticates with Vault by passing username/password pair
 	// +optional
 	UserPass *VaultUserPassAuth `json:"userPass,omitempty"`
+
+	// GCP authenticates with Vault by passing a JWT signed for a GCP service account
+	// using the GCP IAM authentication method
+	// +optional
+	GCP *VaultGCPAuth `json:"gcp,omitempty"`
+
+	// Azure authenticates with Vault by passing an access token of an Azure
+	// managed identity or workload identity using the Azure authentication method
+	// +optional
+	Azure *VaultAzureAuth `json:"azure,omitempty"`
 }
 
 // VaultAppRole authenticates with Vault using the App Role auth mechanism,
@@ -356,3 +382,98 @@ type VaultUserPassAuth struct {
 	// method
 	SecretRef esmeta.SecretKeySelector `json:"secretRef,omitempty"`
 }
+
+// VaultGCPAuth authenticates with Vault using the iam type of the GCP authentication method.
+// A JWT for the Vault role is signed as the GCP service account using the IAM Credentials API,
+// with the credentials of secretRef, workloadIdentity or the controller's default credentials.
+type VaultGCPAuth struct {
+	// Path where the GCP auth method is enabled in Vault, e.g: "gcp"
+	// +kubebuilder:default=gcp
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Role configured in the GCP auth method of Vault
+	Role string `json:"role"`
+
+	// ServiceAccountEmail of the GCP service account the JWT is signed for.
+	// Defaults to the client_email of the secretRef key, or the iam.gke.io/gcp-service-account
+	// annotation of the workload identity service account.
+	// +optional
+	ServiceAccountEmail string `json:"serviceAccountEmail,omitempty"`
+
+	// ProjectID of the GKE cluster, used by workloadIdentity if its clusterProjectID is not set
+	// +optional
+	ProjectID string `json:"projectID,omitempty"`
+
+	// SecretRef to a GCP service account key allowed to sign JWTs for the service account
+	// +optional
+	SecretRef *GCPSMAuthSecretRef `json:"secretRef,omitempty"`
+
+	// WorkloadIdentity of a Kubernetes service account allowed to sign JWTs for the service account
+	// +optional
+	WorkloadIdentity *GCPWorkloadIdentity `json:"workloadIdentity,omitempty"`
+}
+
+// VaultAzureAuth authenticates with Vault using the Azure authentication method.
+// An access token of a managed identity or workload identity is passed to Vault.
+type VaultAzureAuth struct {
+	// Path where the Azure auth method is enabled in Vault, e.g: "azure"
+	// +kubebuilder:default=azure
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Role configured in the Azure auth method of Vault
+	Role string `json:"role"`
+
+	// AuthType of the identity the access token is fetched for
+	// +kubebuilder:validation:Enum=ManagedIdentity;WorkloadIdentity
+	// +kubebuilder:default=WorkloadIdentity
+	// +optional
+	AuthType *AzureAuthType `json:"authType,omitempty"`
+
+	// Resource the access token is fetched for, it must match the resource configured
+	// in the Azure auth method of Vault
+	// +kubebuilder:default="https://management.azure.com/"
+	// +optional
+	Resource string `json:"resource,omitempty"`
+
+	// EnvironmentType of the Azure cloud, used to fetch workload identity tokens
+	// +kubebuilder:default=PublicCloud
+	// +optional
+	EnvironmentType AzureEnvironmentType `json:"environmentType,omitempty"`
+
+	// IdentityID is the client ID of a user assigned managed identity.
+	// Defaults to the system assigned identity.
+	// +optional
+	IdentityID *string `json:"identityId,omitempty"`
+
+	// TenantID of the workload identity.
+	// Defaults to the azure.workload.identity/tenant-id annotation of the service account.
+	// +optional
+	TenantID *string `json:"tenantId,omitempty"`
+
+	// ServiceAccountRef of the workload identity.
+	// Defaults to the workload identity of the controller.
+	// +optional
+	ServiceAccountRef *esmeta.ServiceAccountSelector `json:"serviceAccountRef,omitempty"`
+
+	// SubscriptionID of the resource the identity is assigned to, passed to Vault on login
+	// +optional
+	SubscriptionID string `json:"subscriptionId,omitempty"`
+
+	// ResourceGroupName of the resource the identity is assigned to, passed to Vault on login
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// VMName of the virtual machine the identity is assigned to, passed to Vault on login
+	// +optional
+	VMName string `json:"vmName,omitempty"`
+
+	// VMSSName of the virtual machine scale set the identity is assigned to, passed to Vault on login
+	// +optional
+	VMSSName string `json:"vmssName,omitempty"`
+
+	// ResourceID of the resource the identity is assigned to, passed to Vault on login
+	// +optional
+	ResourceID string `json:"resourceId,omitempty"`
+}
@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"context"
+	"time"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,3 +34,52 @@ type Generator interface {
 		namespace string,
 	) (map[string][]byte, error)
 }
+
+// Lease is the lease of a generated secret.
+// +kubebuilder:object:generate=false
+type Lease struct {
+	// ID of the lease, used to renew and revoke it.
+	ID string
+	// Duration the lease is valid for, starting when it was issued or renewed.
+	Duration time.Duration
+	// Renewable is true if the lease can be renewed.
+	Renewable bool
+}
+
+// LeasingGenerator is implemented by generators whose secrets are leased.
+// The controller renews the leases until they expire
+// and revokes them once the generated secret is superseded.
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+type LeasingGenerator interface {
+	Generator
+
+	// GenerateWithLease generates a secret and returns its lease,
+	// the lease is nil if the secret is not leased.
+	GenerateWithLease(
+		ctx context.Context,
+		obj *apiextensions.JSON,
+		kube client.Client,
+		namespace string,
+	) (map[string][]byte, *Lease, error)
+
+	// RenewLease renews the lease and returns it with its new duration.
+	RenewLease(
+		ctx context.Context,
+		obj *apiextensions.JSON,
+		kube client.Client,
+		namespace string,
+		id string,
+	) (*Lease, error)
+
+	// RevokeLease revokes the lease, the generated secret is no longer valid afterwards.
+	RevokeLease(
+		ctx context.Context,
+		obj *apiextensions.JSON,
+		kube client.Client,
+		namespace string,
+		id string,
+	) error
+}
@@ -37,8 +37,11 @@ import (
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret/cesmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret/cpsmetrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
@@ -61,6 +64,7 @@ var (
 	metricsAddr                           string
 	healthzAddr                           string
 	controllerClass                       string
+	clusterName                           string
 	enableLeaderElection                  bool
 	enableSecretsCache                    bool
 	enableConfigMapsCache                 bool
@@ -74,6 +78,7 @@ var (
 	enableClusterStoreReconciler          bool
 	enableClusterExternalSecretReconciler bool
 	enablePushSecretReconciler            bool
+	enableClusterPushSecretReconciler     bool
 	enableFloodGate                       bool
 	enableExtendedMetricLabels            bool
 	storeRequeueInterval                  time.Duration
@@ -85,6 +90,7 @@ var (
 	certLookaheadInterval                 time.Duration
 	tlsCiphers                            string
 	tlsMinVersion                         string
+	auditConfig                           audit.Config
 )
 
 const (
@@ -215,6 +221,7 @@ var rootCmd = &cobra.Command{
 				Log:             ctrl.Log.WithName("controllers").WithName("PushSecret"),
 				Scheme:          mgr.GetScheme(),
 				ControllerClass: controllerClass,
+				ClusterName:     clusterName,
 				RequeueInterval: time.Hour,
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, errCreateController, "controller", "PushSecret")
@@ -236,6 +243,35 @@ var rootCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
+		if enableClusterPushSecretReconciler {
+			cpsmetrics.SetUpMetrics()
+
+			if err = (&clusterpushsecret.Reconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("ClusterPushSecret"),
+				Scheme:          mgr.GetScheme(),
+				RequeueInterval: time.Hour,
+			}).SetupWithManager(mgr, controller.Options{
+				MaxConcurrentReconciles: concurrent,
+			}); err != nil {
+				setupLog.Error(err, errCreateController, "controller", "ClusterPushSecret")
+				os.Exit(1)
+			}
+		}
+
+		auditor, err := audit.NewFromConfig(auditConfig)
+		if err != nil {
+			setupLog.Error(err, "unable to set up audit trail")
+			os.Exit(1)
+		}
+		if auditor != nil {
+			audit.SetAuditor(auditor)
+			defer func() {
+				if err := audit.Shutdown(); err != nil {
+					setupLog.Error(err, "unable to close audit trail")
+				}
+			}()
+		}
 
 		fs := feature.Features()
 		for _, f := range fs {
@@ -259,6 +295,7 @@ func Execute() {
 func init() {
 	rootCmd.Flags().StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	rootCmd.Flags().StringVar(&controllerClass, "controller-class", "default", "The controller is instantiated with a specific controller name and filters ES based on this property")
+	rootCmd.Flags().StringVar(&clusterName, "cluster-name", "", "Name of the cluster, added to the ownership marker of secrets pushed by PushSecrets to tell apart PushSecrets of different clusters")
 	rootCmd.Flags().BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -271,10 +308,21 @@ func init() {
 	rootCmd.Flags().BoolVar(&enableClusterStoreReconciler, "enable-cluster-store-reconciler", true, "Enable cluster store reconciler.")
 	rootCmd.Flags().BoolVar(&enableClusterExternalSecretReconciler, "enable-cluster-external-secret-reconciler", true, "Enable cluster external secret reconciler.")
 	rootCmd.Flags().BoolVar(&enablePushSecretReconciler, "enable-push-secret-reconciler", true, "Enable push secret reconciler.")
+	rootCmd.Flags().BoolVar(&enableClusterPushSecretReconciler, "enable-cluster-push-secret-reconciler", true, "Enable cluster push secret reconciler.")
 	rootCmd.Flags().BoolVar(&enableSecretsCache, "enable-secrets-caching", false, "Enable secrets caching for external-secrets pod.")
 	rootCmd.Flags().BoolVar(&enableConfigMapsCache, "enable-configmaps-caching", false, "Enable secrets caching for external-secrets pod.")
 	rootCmd.Flags().DurationVar(&storeRequeueInterval, "store-requeue-interval", time.Minute*5, "Default Time duration between reconciling (Cluster)SecretStores")
 	rootCmd.Flags().BoolVar(&enableFloodGate, "enable-flood-gate", true, "Enable flood gate. External secret will be reconciled only if the ClusterStore or Store have an healthy or unknown state.")
+	rootCmd.Flags().StringSliceVar(&auditConfig.Sinks, "audit-sinks", nil, "Sinks of the audit trail of provider and generator calls, any of 'stdout', 'file' or 'http'. The audit trail is disabled if empty.")
+	rootCmd.Flags().StringVar(&auditConfig.FilePath, "audit-file-path", "/var/log/external-secrets/audit.log", "Path of the audit log written by the file sink.")
+	rootCmd.Flags().IntVar(&auditConfig.FileMaxSizeMB, "audit-file-max-size", 100, "Size in megabytes after which the audit log is rotated, 0 disables rotation.")
+	rootCmd.Flags().IntVar(&auditConfig.FileMaxBackups, "audit-file-max-backups", 5, "Number of rotated audit logs to keep.")
+	rootCmd.Flags().StringVar(&auditConfig.HTTPURL, "audit-http-url", "", "URL the http sink posts audit records to as JSON lines.")
+	rootCmd.Flags().IntVar(&auditConfig.HTTPBatchSize, "audit-http-batch-size", 100, "Maximum number of audit records posted in one request.")
+	rootCmd.Flags().DurationVar(&auditConfig.HTTPFlushInterval, "audit-http-flush-interval", 5*time.Second, "Maximum time audit records are buffered before they are posted.")
+	rootCmd.Flags().StringSliceVar(&auditConfig.Operations, "audit-operations", nil, "Operations recorded in the audit trail, any of GetSecret, GetSecretMap, GetAllSecrets, PushSecret, DeleteSecret, SecretExists or Generate. All operations are recorded if empty.")
+	rootCmd.Flags().StringSliceVar(&auditConfig.Namespaces, "audit-namespaces", nil, "Namespaces of the objects whose calls are recorded in the audit trail. All namespaces are recorded if empty.")
+	rootCmd.Flags().Float64Var(&auditConfig.SampleRate, "audit-sample-rate", 1, "Fraction of successful calls recorded in the audit trail. Failed calls are always recorded.")
 	rootCmd.Flags().BoolVar(&enableExtendedMetricLabels, "enable-extended-metric-labels", false, "Enable recommended kubernetes annotations as labels in metrics.")
 	fs := feature.Features()
 	for _, f := range fs {
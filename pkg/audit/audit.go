@@ -0,0 +1,199 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records which cluster object accessed which remote key of a store or generator.
+// Records never contain secret values.
+package audit
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Operation is a provider or generator call recorded by the audit trail.
+type Operation string
+
+const (
+	OperationGetSecret     Operation = "GetSecret"
+	OperationGetSecretMap  Operation = "GetSecretMap"
+	OperationGetAllSecrets Operation = "GetAllSecrets"
+	OperationPushSecret    Operation = "PushSecret"
+	OperationDeleteSecret  Operation = "DeleteSecret"
+	OperationSecretExists  Operation = "SecretExists"
+	OperationGenerate      Operation = "Generate"
+)
+
+// Result is the outcome of a recorded call.
+type Result string
+
+const (
+	ResultSuccess Result = "success"
+	ResultError   Result = "error"
+)
+
+// ObjectRef identifies a cluster object taking part in a recorded call.
+type ObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// Find describes the filter of a GetAllSecrets call.
+type Find struct {
+	Path string            `json:"path,omitempty"`
+	Name string            `json:"name,omitempty"`
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Record is a single entry of the audit trail.
+type Record struct {
+	Time      time.Time  `json:"time"`
+	Operation Operation  `json:"operation"`
+	Requester *ObjectRef `json:"requester,omitempty"`
+	Store     *ObjectRef `json:"store,omitempty"`
+	Generator *ObjectRef `json:"generator,omitempty"`
+	RemoteKey string     `json:"remoteKey,omitempty"`
+	Property  string     `json:"property,omitempty"`
+	Version   string     `json:"version,omitempty"`
+	Find      *Find      `json:"find,omitempty"`
+	// Keys are the names of the secrets returned by GetAllSecrets.
+	Keys     []string `json:"keys,omitempty"`
+	Result   Result   `json:"result"`
+	Error    string   `json:"error,omitempty"`
+	Duration float64  `json:"durationSeconds"`
+}
+
+// Sink writes records to their destination.
+type Sink interface {
+	Write(record *Record) error
+	Close() error
+}
+
+// Filter selects the records written to the sinks.
+type Filter struct {
+	// Operations limits the trail to the given operations. All operations are recorded if empty.
+	Operations []Operation
+	// Namespaces limits the trail to requesters in the given namespaces. All namespaces are recorded if empty.
+	Namespaces []string
+	// SampleRate is the fraction of successful calls which are recorded, between 0 and 1.
+	// Failed calls are always recorded.
+	SampleRate float64
+}
+
+func (f *Filter) match(record *Record, sample float64) bool {
+	if len(f.Operations) > 0 && !slices.Contains(f.Operations, record.Operation) {
+		return false
+	}
+	if len(f.Namespaces) > 0 && (record.Requester == nil || !slices.Contains(f.Namespaces, record.Requester.Namespace)) {
+		return false
+	}
+	return record.Result == ResultError || sample < f.SampleRate
+}
+
+// Auditor filters records and writes them to its sinks.
+type Auditor struct {
+	sinks  []Sink
+	filter Filter
+	sample func() float64
+}
+
+// New returns an Auditor writing the records matching filter to sinks.
+func New(filter Filter, sinks ...Sink) *Auditor {
+	return &Auditor{
+		sinks:  sinks,
+		filter: filter,
+		sample: rand.Float64, //nolint:gosec // sampling does not need a secure source
+	}
+}
+
+// Log writes the record to all sinks if it matches the filter.
+// Sink errors are logged, they never fail the audited call.
+func (a *Auditor) Log(record *Record) {
+	if !a.filter.match(record, a.sample()) {
+		return
+	}
+	for _, sink := range a.sinks {
+		if err := sink.Write(record); err != nil {
+			ctrl.Log.WithName("audit").Error(err, "could not write audit record", "operation", record.Operation)
+		}
+	}
+}
+
+// Close flushes and closes all sinks.
+func (a *Auditor) Close() error {
+	var errs []error
+	for _, sink := range a.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
+
+var auditor atomic.Pointer[Auditor]
+
+// SetAuditor installs the Auditor used by Observe. A nil Auditor disables the audit trail.
+func SetAuditor(a *Auditor) {
+	auditor.Store(a)
+}
+
+// Enabled returns true if an Auditor is installed.
+func Enabled() bool {
+	return auditor.Load() != nil
+}
+
+// Shutdown closes the installed Auditor and disables the audit trail.
+func Shutdown() error {
+	a := auditor.Swap(nil)
+	if a == nil {
+		return nil
+	}
+	return a.Close()
+}
+
+type requesterKey struct{}
+
+// WithRequester returns a context which attributes the calls made with it to the given object.
+func WithRequester(ctx context.Context, kind, namespace, name string) context.Context {
+	return context.WithValue(ctx, requesterKey{}, &ObjectRef{Kind: kind, Namespace: namespace, Name: name})
+}
+
+// RequesterFrom returns the object the calls made with ctx are attributed to, or nil.
+func RequesterFrom(ctx context.Context) *ObjectRef {
+	ref, _ := ctx.Value(requesterKey{}).(*ObjectRef)
+	return ref
+}
+
+// Observe records a call described by record which started at start and failed with err.
+// The time, requester, result and duration of the record are set by Observe.
+// It is a no-op if no Auditor is installed.
+func Observe(ctx context.Context, record Record, start time.Time, err error) {
+	a := auditor.Load()
+	if a == nil {
+		return
+	}
+	record.Time = start.UTC()
+	record.Requester = RequesterFrom(ctx)
+	record.Duration = time.Since(start).Seconds()
+	record.Result = ResultSuccess
+	if err != nil {
+		record.Result = ResultError
+		record.Error = err.Error()
+	}
+	a.Log(&record)
+}
@@ -0,0 +1,157 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memorySink struct {
+	records []*Record
+}
+
+func (s *memorySink) Write(record *Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+func TestFilter(t *testing.T) {
+	requester := &ObjectRef{Kind: "ExternalSecret", Namespace: "foo", Name: "bar"}
+	tests := []struct {
+		name   string
+		filter Filter
+		record Record
+		sample float64
+		want   bool
+	}{
+		{
+			name:   "records everything without filter",
+			filter: Filter{SampleRate: 1},
+			record: Record{Operation: OperationGetSecret, Requester: requester, Result: ResultSuccess},
+			sample: 0.99,
+			want:   true,
+		},
+		{
+			name:   "skips other operations",
+			filter: Filter{SampleRate: 1, Operations: []Operation{OperationPushSecret}},
+			record: Record{Operation: OperationGetSecret, Requester: requester, Result: ResultSuccess},
+			want:   false,
+		},
+		{
+			name:   "skips other namespaces",
+			filter: Filter{SampleRate: 1, Namespaces: []string{"baz"}},
+			record: Record{Operation: OperationGetSecret, Requester: requester, Result: ResultSuccess},
+			want:   false,
+		},
+		{
+			name:   "skips records without requester if namespaces are set",
+			filter: Filter{SampleRate: 1, Namespaces: []string{"foo"}},
+			record: Record{Operation: OperationGetSecret, Result: ResultSuccess},
+			want:   false,
+		},
+		{
+			name:   "samples successful calls",
+			filter: Filter{SampleRate: 0.1},
+			record: Record{Operation: OperationGetSecret, Requester: requester, Result: ResultSuccess},
+			sample: 0.5,
+			want:   false,
+		},
+		{
+			name:   "always records failed calls",
+			filter: Filter{SampleRate: 0},
+			record: Record{Operation: OperationGetSecret, Requester: requester, Result: ResultError},
+			sample: 0.5,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.match(&tt.record, tt.sample))
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	sink := &memorySink{}
+	SetAuditor(New(Filter{SampleRate: 1}, sink))
+	defer SetAuditor(nil)
+
+	ctx := WithRequester(context.Background(), "ExternalSecret", "foo", "bar")
+	start := time.Now().Add(-time.Second)
+	Observe(ctx, Record{Operation: OperationGetSecret, RemoteKey: "db"}, start, nil)
+	Observe(ctx, Record{Operation: OperationGetSecret, RemoteKey: "api"}, start, errors.New("access denied"))
+
+	require.Len(t, sink.records, 2)
+	assert.Equal(t, &ObjectRef{Kind: "ExternalSecret", Namespace: "foo", Name: "bar"}, sink.records[0].Requester)
+	assert.Equal(t, ResultSuccess, sink.records[0].Result)
+	assert.Equal(t, start.UTC(), sink.records[0].Time)
+	assert.GreaterOrEqual(t, sink.records[0].Duration, 1.0)
+	assert.Equal(t, ResultError, sink.records[1].Result)
+	assert.Equal(t, "access denied", sink.records[1].Error)
+}
+
+func TestObserveDisabled(t *testing.T) {
+	assert.False(t, Enabled())
+	Observe(context.Background(), Record{Operation: OperationGetSecret}, time.Now(), nil)
+	assert.NoError(t, Shutdown())
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+	require.NoError(t, sink.Write(&Record{Operation: OperationPushSecret, RemoteKey: "db", Result: ResultSuccess}))
+	require.NoError(t, sink.Write(&Record{Operation: OperationDeleteSecret, RemoteKey: "db", Result: ResultSuccess}))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var record Record
+	require.NoError(t, json.Unmarshal(lines[1], &record))
+	assert.Equal(t, OperationDeleteSecret, record.Operation)
+	assert.Equal(t, "db", record.RemoteKey)
+}
+
+func TestNewFromConfig(t *testing.T) {
+	a, err := NewFromConfig(Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+
+	_, err = NewFromConfig(Config{Sinks: []string{"syslog"}, SampleRate: 1})
+	assert.EqualError(t, err, `unknown audit sink "syslog", must be one of stdout, file or http`)
+
+	_, err = NewFromConfig(Config{Sinks: []string{SinkStdout}, SampleRate: 2})
+	assert.Error(t, err)
+
+	_, err = NewFromConfig(Config{Sinks: []string{SinkStdout}, SampleRate: 1, Operations: []string{"Read"}})
+	assert.EqualError(t, err, `unknown audit operation "Read"`)
+
+	_, err = NewFromConfig(Config{Sinks: []string{SinkHTTP}, SampleRate: 1})
+	assert.Error(t, err)
+
+	a, err = NewFromConfig(Config{Sinks: []string{SinkStdout}, SampleRate: 1, Operations: []string{"GetSecret"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Operation{OperationGetSecret}, a.filter.Operations)
+}
@@ -0,0 +1,111 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+const (
+	SinkStdout = "stdout"
+	SinkFile   = "file"
+	SinkHTTP   = "http"
+)
+
+var validOperations = []Operation{
+	OperationGetSecret,
+	OperationGetSecretMap,
+	OperationGetAllSecrets,
+	OperationPushSecret,
+	OperationDeleteSecret,
+	OperationSecretExists,
+	OperationGenerate,
+}
+
+// Config configures the audit trail from the command line.
+type Config struct {
+	// Sinks are the names of the enabled sinks: stdout, file or http.
+	Sinks []string
+
+	FilePath       string
+	FileMaxSizeMB  int
+	FileMaxBackups int
+
+	HTTPURL           string
+	HTTPBatchSize     int
+	HTTPFlushInterval time.Duration
+
+	Operations []string
+	Namespaces []string
+	SampleRate float64
+}
+
+// NewFromConfig returns the Auditor described by cfg, or nil if no sink is enabled.
+func NewFromConfig(cfg Config) (*Auditor, error) {
+	if len(cfg.Sinks) == 0 {
+		return nil, nil
+	}
+	if cfg.SampleRate < 0 || cfg.SampleRate > 1 {
+		return nil, fmt.Errorf("audit sample rate must be between 0 and 1, got %v", cfg.SampleRate)
+	}
+	filter := Filter{
+		Namespaces: cfg.Namespaces,
+		SampleRate: cfg.SampleRate,
+	}
+	for _, op := range cfg.Operations {
+		if !slices.Contains(validOperations, Operation(op)) {
+			return nil, fmt.Errorf("unknown audit operation %q", op)
+		}
+		filter.Operations = append(filter.Operations, Operation(op))
+	}
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		sink, err := newSink(name, &cfg)
+		if err != nil {
+			for _, s := range sinks {
+				_ = s.Close()
+			}
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return New(filter, sinks...), nil
+}
+
+func newSink(name string, cfg *Config) (Sink, error) {
+	switch name {
+	case SinkStdout:
+		return NewWriterSink(os.Stdout), nil
+	case SinkFile:
+		if cfg.FilePath == "" {
+			return nil, errors.New("the file audit sink requires a path")
+		}
+		return NewFileSink(cfg.FilePath, int64(cfg.FileMaxSizeMB)*1024*1024, cfg.FileMaxBackups)
+	case SinkHTTP:
+		if cfg.HTTPURL == "" {
+			return nil, errors.New("the http audit sink requires a url")
+		}
+		return NewHTTPSink(cfg.HTTPURL, HTTPSinkOptions{
+			BatchSize:     cfg.HTTPBatchSize,
+			FlushInterval: cfg.HTTPFlushInterval,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink %q, must be one of %s, %s or %s", name, SinkStdout, SinkFile, SinkHTTP)
+	}
+}
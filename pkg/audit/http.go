@@ -0,0 +1,204 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	defaultHTTPBatchSize     = 100
+	defaultHTTPBufferSize    = 10000
+	defaultHTTPFlushInterval = 5 * time.Second
+	httpContentType          = "application/x-ndjson"
+	// maxHTTPErrorLength is the maximum length of the error of a record sent to the endpoint.
+	maxHTTPErrorLength = 1024
+)
+
+var (
+	errBufferFull = errors.New("audit buffer is full, record dropped")
+	errSinkClosed = errors.New("audit sink is closed, record dropped")
+
+	urlRegexp = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"']+`)
+)
+
+// HTTPSinkOptions configures the HTTP sink.
+type HTTPSinkOptions struct {
+	// BatchSize is the maximum number of records sent in one request.
+	BatchSize int
+	// BufferSize is the number of records buffered while the endpoint is slow or unavailable.
+	// Records are dropped once the buffer is full.
+	BufferSize int
+	// FlushInterval is the maximum time a record is buffered before it is sent.
+	FlushInterval time.Duration
+	Client        *http.Client
+}
+
+// httpSink posts batches of JSON lines to an HTTP endpoint.
+// Records are sent in the background, so a slow endpoint does not block reconciles.
+type httpSink struct {
+	url     string
+	opts    HTTPSinkOptions
+	records chan []byte
+	done    chan struct{}
+	// mu guards closed, so that Write does not send on the closed records channel.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewHTTPSink returns a Sink posting records as JSON lines to url.
+func NewHTTPSink(url string, opts HTTPSinkOptions) Sink {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultHTTPBatchSize
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultHTTPBufferSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultHTTPFlushInterval
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	s := &httpSink{
+		url:     url,
+		opts:    opts,
+		records: make(chan []byte, opts.BufferSize),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *httpSink) Write(record *Record) error {
+	sanitized := *record
+	sanitized.Error = sanitizeError(record.Error)
+	line, err := marshalLine(&sanitized)
+	if err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errSinkClosed
+	}
+	select {
+	case s.records <- line:
+		return nil
+	default:
+		return errBufferFull
+	}
+}
+
+// Close sends the buffered records and stops the sink.
+func (s *httpSink) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.records)
+	}
+	s.mu.Unlock()
+	<-s.done
+	return nil
+}
+
+func (s *httpSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.opts.FlushInterval)
+	defer ticker.Stop()
+	var batch bytes.Buffer
+	count := 0
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		if err := s.send(batch.Bytes()); err != nil {
+			ctrl.Log.WithName("audit").Error(err, "could not send audit records", "records", count)
+		}
+		batch.Reset()
+		count = 0
+	}
+	for {
+		select {
+		case line, ok := <-s.records:
+			if !ok {
+				flush()
+				return
+			}
+			batch.Write(line)
+			count++
+			if count >= s.opts.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (s *httpSink) send(body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", httpContentType)
+	resp, err := s.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// sanitizeError removes credentials and query parameters from the URLs in msg
+// and truncates it to maxHTTPErrorLength, as errors of the providers are sent to a remote endpoint.
+func sanitizeError(msg string) string {
+	msg = urlRegexp.ReplaceAllStringFunc(msg, func(raw string) string {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "<url>"
+		}
+		u.User = nil
+		u.RawQuery = ""
+		u.Fragment = ""
+		return u.String()
+	})
+	if len(msg) <= maxHTTPErrorLength {
+		return msg
+	}
+	// cut at the start of a rune, so that no rune is split
+	n := maxHTTPErrorLength
+	for n > 0 && !utf8.RuneStart(msg[n]) {
+		n--
+	}
+	return strings.TrimSpace(msg[:n]) + "..."
+}
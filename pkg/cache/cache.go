@@ -16,6 +16,7 @@ package cache
 
 import (
 	"fmt"
+	"sync"
 
 	lru "github.com/hashicorp/golang-lru"
 )
@@ -45,6 +46,22 @@ type value[T any] struct {
 
 type cleanupFunc[T any] func(client T)
 
+// caches holds all caches created with New, so values can be invalidated across caches.
+var (
+	cachesMu sync.Mutex
+	caches   []interface{ RemoveFunc(match func(Key) bool) }
+)
+
+// Invalidate removes the values of all keys matching from every cache, e.g. when
+// the credentials a cached client was created with changed.
+func Invalidate(match func(Key) bool) {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+	for _, c := range caches {
+		c.RemoveFunc(match)
+	}
+}
+
 // New constructs a new lru cache with the desired size and cleanup func.
 func New[T any](size int, cleanup cleanupFunc[T]) (*Cache[T], error) {
 	lruCache, err := lru.NewWithEvict(size, func(_, val any) {
@@ -56,11 +73,15 @@ func New[T any](size int, cleanup cleanupFunc[T]) (*Cache[T], error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to create lru: %w", err)
 	}
-	return &Cache[T]{
+	c := &Cache[T]{
 		lru:         lruCache,
 		size:        size,
 		cleanupFunc: cleanup,
-	}, nil
+	}
+	cachesMu.Lock()
+	caches = append(caches, c)
+	cachesMu.Unlock()
+	return c, nil
 }
 
 // Must creates a new lru cache with the desired size and cleanup func
@@ -97,3 +118,13 @@ func (c *Cache[T]) Add(version string, key Key, client T) {
 func (c *Cache[T]) Contains(key Key) bool {
 	return c.lru.Contains(key)
 }
+
+// RemoveFunc removes the values of all keys matching.
+// The cleanup func is called for every removed value.
+func (c *Cache[T]) RemoveFunc(match func(Key) bool) {
+	for _, k := range c.lru.Keys() {
+		if key, ok := k.(Key); ok && match(key) {
+			c.lru.Remove(key)
+		}
+	}
+}
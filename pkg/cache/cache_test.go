@@ -98,3 +98,22 @@ func TestCacheEvict(t *testing.T) {
 	c.Add("", Key{Name: "bar"}, client{})
 	assert.True(t, cleanupCalled)
 }
+
+func TestCacheInvalidate(t *testing.T) {
+	var cleaned []string
+	c := Must[string](10, func(client string) {
+		cleaned = append(cleaned, client)
+	})
+	c.Add("", Key{Name: "foo", Namespace: "a"}, "foo-a")
+	c.Add("", Key{Name: "foo", Namespace: "b"}, "foo-b")
+	c.Add("", Key{Name: "bar", Namespace: "a"}, "bar-a")
+
+	Invalidate(func(key Key) bool {
+		return key.Name == "foo"
+	})
+
+	assert.False(t, c.Contains(Key{Name: "foo", Namespace: "a"}))
+	assert.False(t, c.Contains(Key{Name: "foo", Namespace: "b"}))
+	assert.True(t, c.Contains(Key{Name: "bar", Namespace: "a"}))
+	assert.ElementsMatch(t, []string{"foo-a", "foo-b"}, cleaned)
+}
@@ -23,6 +23,7 @@ const (
 	CallAWSSMCreateSecret        = "CreateSecret"
 	CallAWSSMPutSecretValue      = "PutSecretValue"
 	CallAWSSMListSecrets         = "ListSecrets"
+	CallAWSSMTagResource         = "TagResource"
 
 	ProviderAWSPS                = "AWS/ParameterStore"
 	CallAWSPSGetParameter        = "GetParameter"
@@ -58,6 +59,7 @@ const (
 	CallHCVaultLogin           = "Login"
 	CallHCVaultRevokeSelf      = "RevokeSelf"
 	CallHCVaultLookupSelf      = "LookupSelf"
+	CallHCVaultRenewSelf       = "RenewSelf"
 	CallHCVaultReadSecretData  = "ReadSecretData"
 	CallHCVaultWriteSecretData = "WriteSecretData"
 	CallHCVaultDeleteSecret    = "DeleteSecret"
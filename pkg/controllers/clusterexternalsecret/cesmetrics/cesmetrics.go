@@ -27,6 +27,7 @@ const (
 	ClusterExternalSecretSubsystem            = "clusterexternalsecret"
 	ClusterExternalSecretReconcileDurationKey = "reconcile_duration"
 	ClusterExternalSecretStatusConditionKey   = "status_condition"
+	ClusterExternalSecretExternalSecretsKey   = "external_secrets"
 )
 
 var gaugeVecMetrics = map[string]*prometheus.GaugeVec{}
@@ -46,11 +47,18 @@ func SetUpMetrics() {
 		Help:      "The status condition of a specific Cluster External Secret",
 	}, ctrlmetrics.ConditionMetricLabelNames)
 
-	metrics.Registry.MustRegister(clusterExternalSecretReconcileDuration, clusterExternalSecretCondition)
+	clusterExternalSecretExternalSecrets := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ClusterExternalSecretSubsystem,
+		Name:      ClusterExternalSecretExternalSecretsKey,
+		Help:      "The number of External Secrets provisioned by a specific Cluster External Secret by health",
+	}, append(append([]string{}, ctrlmetrics.NonConditionMetricLabelNames...), "health"))
+
+	metrics.Registry.MustRegister(clusterExternalSecretReconcileDuration, clusterExternalSecretCondition, clusterExternalSecretExternalSecrets)
 
 	gaugeVecMetrics = map[string]*prometheus.GaugeVec{
 		ClusterExternalSecretStatusConditionKey:   clusterExternalSecretCondition,
 		ClusterExternalSecretReconcileDurationKey: clusterExternalSecretReconcileDuration,
+		ClusterExternalSecretExternalSecretsKey:   clusterExternalSecretExternalSecrets,
 	}
 }
 
@@ -89,6 +97,31 @@ func UpdateClusterExternalSecretCondition(ces *esv1beta1.ClusterExternalSecret,
 		})).Set(0)
 }
 
+// UpdateExternalSecretHealth publishes the number of ExternalSecrets
+// provisioned by the ClusterExternalSecret for every health state.
+func UpdateExternalSecretHealth(ces *esv1beta1.ClusterExternalSecret) {
+	cesInfo := make(map[string]string)
+	cesInfo["name"] = ces.Name
+	for k, v := range ces.Labels {
+		cesInfo[k] = v
+	}
+	cesLabels := ctrlmetrics.RefineNonConditionMetricLabels(cesInfo)
+	clusterExternalSecretExternalSecrets := GetGaugeVec(ClusterExternalSecretExternalSecretsKey)
+
+	counts := map[esv1beta1.ExternalSecretHealth]int{
+		esv1beta1.ExternalSecretHealthReady:   ces.Status.ReadyExternalSecrets,
+		esv1beta1.ExternalSecretHealthFailed:  ces.Status.FailedExternalSecrets,
+		esv1beta1.ExternalSecretHealthPending: ces.Status.PendingExternalSecrets,
+	}
+	for health, count := range counts {
+		healthLabels := prometheus.Labels{"health": string(health)}
+		for k, v := range cesLabels {
+			healthLabels[k] = v
+		}
+		clusterExternalSecretExternalSecrets.With(healthLabels).Set(float64(count))
+	}
+}
+
 // RemoveMetrics deletes all metrics published by the resource.
 func RemoveMetrics(namespace, name string) {
 	for _, gaugeVecMetric := range gaugeVecMetrics {
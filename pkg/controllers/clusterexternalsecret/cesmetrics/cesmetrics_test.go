@@ -118,3 +118,73 @@ func TestUpdateClusterExternalSecretCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateExternalSecretHealth(t *testing.T) {
+	// Evacuate the original non condition metric labels
+	tmpNonConditionMetricLabels := metrics.NonConditionMetricLabels
+	defer func() {
+		metrics.NonConditionMetricLabels = tmpNonConditionMetricLabels
+	}()
+	metrics.NonConditionMetricLabels = map[string]string{"name": "", "namespace": ""}
+
+	tests := []struct {
+		desc     string
+		status   esv1beta1.ClusterExternalSecretStatus
+		expected map[string]float64
+	}{
+		{
+			desc: "CountsByHealth",
+			status: esv1beta1.ClusterExternalSecretStatus{
+				ReadyExternalSecrets:  3,
+				FailedExternalSecrets: 1,
+			},
+			expected: map[string]float64{"Ready": 3, "Failed": 1, "Pending": 0},
+		},
+		{
+			desc:     "NoExternalSecrets",
+			expected: map[string]float64{"Ready": 0, "Failed": 0, "Pending": 0},
+		},
+		{
+			desc: "AllPending",
+			status: esv1beta1.ClusterExternalSecretStatus{
+				PendingExternalSecrets: 2,
+			},
+			expected: map[string]float64{"Ready": 0, "Failed": 0, "Pending": 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			// Evacuate the original gauge vec
+			tmpGaugeVec := GetGaugeVec(ClusterExternalSecretExternalSecretsKey)
+			defer func() {
+				gaugeVecMetrics[ClusterExternalSecretExternalSecretsKey] = tmpGaugeVec
+			}()
+
+			gaugeVec := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Subsystem: "csmetrics",
+				Name:      "TestUpdateExternalSecretHealth",
+			}, []string{"name", "namespace", "health"})
+			gaugeVecMetrics[ClusterExternalSecretExternalSecretsKey] = gaugeVec
+
+			ces := &esv1beta1.ClusterExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Status: test.status,
+			}
+			UpdateExternalSecretHealth(ces)
+
+			if got := testutil.CollectAndCount(gaugeVec); got != len(test.expected) {
+				t.Fatalf("unexpected number of calls: got: %d, expected: %d", got, len(test.expected))
+			}
+
+			for health, value := range test.expected {
+				labels := prometheus.Labels{"namespace": "", "name": "test", "health": health}
+				if got := testutil.ToFloat64(gaugeVec.With(labels)); got != value {
+					t.Fatalf("received unexpected gauge value for %s: got: %v, expected: %v", health, got, value)
+				}
+			}
+		})
+	}
+}
@@ -17,8 +17,6 @@ package clusterexternalsecret
 import (
 	"context"
 	"fmt"
-	"reflect"
-	"slices"
 	"sort"
 	"time"
 
@@ -26,7 +24,6 @@ import (
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -41,6 +38,7 @@ import (
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret/cesmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/fanout"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 )
 
@@ -53,11 +51,14 @@ type Reconciler struct {
 }
 
 const (
-	errGetCES               = "could not get ClusterExternalSecret"
-	errPatchStatus          = "unable to patch status"
-	errConvertLabelSelector = "unable to convert labelselector"
-	errGetExistingES        = "could not get existing ExternalSecret"
-	errNamespacesFailed     = "one or more namespaces failed"
+	errGetCES                 = "could not get ClusterExternalSecret"
+	errPatchStatus            = "unable to patch status"
+	errConvertLabelSelector   = "unable to convert labelselector"
+	errGetExistingES          = "could not get existing ExternalSecret"
+	errNamespacesFailed       = "one or more namespaces failed"
+	errRenderTemplates        = "could not render namespace templates"
+	errExternalSecretsFailed  = "one or more ExternalSecrets failed to sync"
+	msgExternalSecretsPending = "waiting for ExternalSecrets to sync"
 )
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -99,7 +100,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	if esName == "" {
 		esName = clusterExternalSecret.ObjectMeta.Name
 	}
-	if prevName := clusterExternalSecret.Status.ExternalSecretName; prevName != esName {
+	// templated names are resolved per namespace, renamed ExternalSecrets are cleaned up below
+	if prevName := clusterExternalSecret.Status.ExternalSecretName; prevName != esName && !clusterExternalSecret.Spec.NamespaceTemplating {
 		// ExternalSecretName has changed, so remove the old ones
 		for _, ns := range clusterExternalSecret.Status.ProvisionedNamespaces {
 			if err := r.deleteExternalSecret(ctx, prevName, clusterExternalSecret.Name, ns); err != nil {
@@ -110,19 +112,30 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 	clusterExternalSecret.Status.ExternalSecretName = esName
 
-	namespaces, err := r.getTargetNamespaces(ctx, &clusterExternalSecret)
+	namespaces, err := namespaceSelection(&clusterExternalSecret).Namespaces(ctx, r)
 	if err != nil {
 		log.Error(err, "failed to get target Namespaces")
 		return ctrl.Result{}, err
 	}
 
-	failedNamespaces := r.deleteOutdatedExternalSecrets(ctx, namespaces, esName, clusterExternalSecret.Name, clusterExternalSecret.Status.ProvisionedNamespaces)
+	failedNamespaces := r.deleteOutdatedExternalSecrets(ctx, namespaces, esName, &clusterExternalSecret)
 
 	provisionedNamespaces := []string{}
+	namespaceStatuses := []esv1beta1.ClusterExternalSecretNamespaceStatus{}
 	for _, namespace := range namespaces {
+		name, spec := esName, clusterExternalSecret.Spec.ExternalSecretSpec
+		if clusterExternalSecret.Spec.NamespaceTemplating {
+			name, spec, err = clusterExternalSecret.RenderNamespaceTemplates(esName, namespace.ObjectMeta)
+			if err != nil {
+				log.Error(err, errRenderTemplates, "namespace", namespace.Name)
+				failedNamespaces[namespace.Name] = err
+				continue
+			}
+		}
+
 		var existingES esv1beta1.ExternalSecret
 		err = r.Get(ctx, types.NamespacedName{
-			Name:      esName,
+			Name:      name,
 			Namespace: namespace.Name,
 		}, &existingES)
 		if err != nil && !apierrors.IsNotFound(err) {
@@ -136,16 +149,27 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			continue
 		}
 
-		if err := r.createOrUpdateExternalSecret(ctx, &clusterExternalSecret, namespace, esName, clusterExternalSecret.Spec.ExternalSecretMetadata); err != nil {
+		if err := r.createOrUpdateExternalSecret(ctx, &clusterExternalSecret, namespace, name, clusterExternalSecret.Spec.ExternalSecretMetadata, spec); err != nil {
 			log.Error(err, "failed to create or update external secret")
 			failedNamespaces[namespace.Name] = err
 			continue
 		}
 
+		if clusterExternalSecret.Spec.NamespaceTemplating {
+			if err := r.deleteOwnedExternalSecrets(ctx, clusterExternalSecret.Name, namespace.Name, name); err != nil {
+				log.Error(err, "could not delete ExternalSecret")
+				failedNamespaces[namespace.Name] = err
+				continue
+			}
+		}
+
 		provisionedNamespaces = append(provisionedNamespaces, namespace.Name)
+		// existingES is empty if the ExternalSecret has just been created, which reports it as Pending
+		namespaceStatuses = append(namespaceStatuses, NewNamespaceStatus(namespace.Name, name, &existingES))
 	}
 
-	condition := NewClusterExternalSecretCondition(failedNamespaces)
+	SetNamespaceStatuses(&clusterExternalSecret, namespaceStatuses)
+	condition := NewClusterExternalSecretCondition(failedNamespaces, &clusterExternalSecret.Status)
 	SetClusterExternalSecretCondition(&clusterExternalSecret, *condition)
 
 	clusterExternalSecret.Status.FailedNamespaces = toNamespaceFailures(failedNamespaces)
@@ -155,47 +179,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	return ctrl.Result{RequeueAfter: refreshInt}, nil
 }
 
-func (r *Reconciler) getTargetNamespaces(ctx context.Context, ces *esv1beta1.ClusterExternalSecret) ([]v1.Namespace, error) {
-	selectors := []*metav1.LabelSelector{}
+// namespaceSelection returns the namespaces selected by the ClusterExternalSecret,
+// including the deprecated NamespaceSelector.
+func namespaceSelection(ces *esv1beta1.ClusterExternalSecret) fanout.Selection {
+	var selectors []*metav1.LabelSelector
 	if s := ces.Spec.NamespaceSelector; s != nil {
 		selectors = append(selectors, s)
 	}
-	for _, ns := range ces.Spec.Namespaces {
-		selectors = append(selectors, &metav1.LabelSelector{
-			MatchLabels: map[string]string{
-				"kubernetes.io/metadata.name": ns,
-			},
-		})
-	}
-	selectors = append(selectors, ces.Spec.NamespaceSelectors...)
-
-	var namespaces []v1.Namespace
-	namespaceSet := make(map[string]struct{})
-	for _, selector := range selectors {
-		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert label selector %s: %w", selector, err)
-		}
-
-		var nl v1.NamespaceList
-		err = r.List(ctx, &nl, &client.ListOptions{LabelSelector: labelSelector})
-		if err != nil {
-			return nil, fmt.Errorf("failed to list namespaces by label selector %s: %w", selector, err)
-		}
-
-		for _, n := range nl.Items {
-			if _, exist := namespaceSet[n.Name]; exist {
-				continue
-			}
-			namespaceSet[n.Name] = struct{}{}
-			namespaces = append(namespaces, n)
-		}
+	return fanout.Selection{
+		Names:     ces.Spec.Namespaces,
+		Selectors: append(selectors, ces.Spec.NamespaceSelectors...),
 	}
-
-	return namespaces, nil
 }
 
-func (r *Reconciler) createOrUpdateExternalSecret(ctx context.Context, clusterExternalSecret *esv1beta1.ClusterExternalSecret, namespace v1.Namespace, esName string, esMetadata esv1beta1.ExternalSecretMetadata) error {
+func (r *Reconciler) createOrUpdateExternalSecret(ctx context.Context, clusterExternalSecret *esv1beta1.ClusterExternalSecret, namespace v1.Namespace, esName string, esMetadata esv1beta1.ExternalSecretMetadata, esSpec esv1beta1.ExternalSecretSpec) error {
 	externalSecret := &esv1beta1.ExternalSecret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace.Name,
@@ -206,7 +203,7 @@ func (r *Reconciler) createOrUpdateExternalSecret(ctx context.Context, clusterEx
 	mutateFunc := func() error {
 		externalSecret.Labels = esMetadata.Labels
 		externalSecret.Annotations = esMetadata.Annotations
-		externalSecret.Spec = clusterExternalSecret.Spec.ExternalSecretSpec
+		externalSecret.Spec = esSpec
 
 		if err := controllerutil.SetControllerReference(clusterExternalSecret, externalSecret, r.Scheme); err != nil {
 			return fmt.Errorf("could not set the controller owner reference %w", err)
@@ -254,11 +251,37 @@ func (r *Reconciler) deferPatch(ctx context.Context, log logr.Logger, clusterExt
 	}
 }
 
-func (r *Reconciler) deleteOutdatedExternalSecrets(ctx context.Context, namespaces []v1.Namespace, esName, cesName string, provisionedNamespaces []string) map[string]error {
+// deleteOwnedExternalSecrets deletes all ExternalSecrets in the namespace
+// which are owned by the ClusterExternalSecret, except the one named keep.
+func (r *Reconciler) deleteOwnedExternalSecrets(ctx context.Context, cesName, namespace, keep string) error {
+	var externalSecrets esv1beta1.ExternalSecretList
+	if err := r.List(ctx, &externalSecrets, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	for i := range externalSecrets.Items {
+		es := &externalSecrets.Items[i]
+		if es.Name == keep || !isExternalSecretOwnedBy(es, cesName) {
+			continue
+		}
+		if err := r.Delete(ctx, es, &client.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("external secret in non matching namespace could not be deleted: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) deleteOutdatedExternalSecrets(ctx context.Context, namespaces []v1.Namespace, esName string, ces *esv1beta1.ClusterExternalSecret) map[string]error {
 	failedNamespaces := map[string]error{}
 	// Loop through existing namespaces first to make sure they still have our labels
-	for _, namespace := range getRemovedNamespaces(namespaces, provisionedNamespaces) {
-		err := r.deleteExternalSecret(ctx, esName, cesName, namespace)
+	for _, namespace := range fanout.RemovedNamespaces(namespaces, ces.Status.ProvisionedNamespaces) {
+		var err error
+		if ces.Spec.NamespaceTemplating {
+			err = r.deleteOwnedExternalSecrets(ctx, ces.Name, namespace, "")
+		} else {
+			err = r.deleteExternalSecret(ctx, esName, ces.Name, namespace)
+		}
 		if err != nil {
 			r.Log.Error(err, "unable to delete external secret")
 			failedNamespaces[namespace] = err
@@ -273,35 +296,10 @@ func isExternalSecretOwnedBy(es *esv1beta1.ExternalSecret, cesName string) bool
 	return owner != nil && owner.APIVersion == esv1beta1.SchemeGroupVersion.String() && owner.Kind == esv1beta1.ClusterExtSecretKind && owner.Name == cesName
 }
 
-func getRemovedNamespaces(currentNSs []v1.Namespace, provisionedNSs []string) []string {
-	currentNSSet := map[string]struct{}{}
-	for _, currentNs := range currentNSs {
-		currentNSSet[currentNs.Name] = struct{}{}
-	}
-
-	var removedNSs []string
-	for _, ns := range provisionedNSs {
-		if _, ok := currentNSSet[ns]; !ok {
-			removedNSs = append(removedNSs, ns)
-		}
-	}
-
-	return removedNSs
-}
-
 func toNamespaceFailures(failedNamespaces map[string]error) []esv1beta1.ClusterExternalSecretNamespaceFailure {
-	namespaceFailures := make([]esv1beta1.ClusterExternalSecretNamespaceFailure, len(failedNamespaces))
-
-	i := 0
-	for namespace, err := range failedNamespaces {
-		namespaceFailures[i] = esv1beta1.ClusterExternalSecretNamespaceFailure{
-			Namespace: namespace,
-			Reason:    err.Error(),
-		}
-		i++
-	}
-	sort.Slice(namespaceFailures, func(i, j int) bool { return namespaceFailures[i].Namespace < namespaceFailures[j].Namespace })
-	return namespaceFailures
+	return fanout.NamespaceFailures(failedNamespaces, func(namespace, reason string) esv1beta1.ClusterExternalSecretNamespaceFailure {
+		return esv1beta1.ClusterExternalSecretNamespaceFailure{Namespace: namespace, Reason: reason}
+	})
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -309,11 +307,11 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options)
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
 		For(&esv1beta1.ClusterExternalSecret{}).
-		Owns(&esv1beta1.ExternalSecret{}).
+		Owns(&esv1beta1.ExternalSecret{}, builder.WithPredicates(externalSecretPredicate())).
 		Watches(
 			&v1.Namespace{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForNamespace),
-			builder.WithPredicates(namespacePredicate()),
+			builder.WithPredicates(fanout.NamespacePredicate()),
 		).
 		Complete(r)
 }
@@ -328,39 +326,11 @@ func (r *Reconciler) findObjectsForNamespace(ctx context.Context, namespace clie
 	var requests []reconcile.Request
 	for i := range clusterExternalSecrets.Items {
 		clusterExternalSecret := &clusterExternalSecrets.Items[i]
-		var selectors []*metav1.LabelSelector
-		if s := clusterExternalSecret.Spec.NamespaceSelector; s != nil {
-			selectors = append(selectors, s)
-		}
-		selectors = append(selectors, clusterExternalSecret.Spec.NamespaceSelectors...)
-
-		var selected bool
-		for _, selector := range selectors {
-			labelSelector, err := metav1.LabelSelectorAsSelector(selector)
-			if err != nil {
-				r.Log.Error(err, errConvertLabelSelector)
-				continue
-			}
-
-			if labelSelector.Matches(labels.Set(namespace.GetLabels())) {
-				requests = append(requests, reconcile.Request{
-					NamespacedName: types.NamespacedName{
-						Name:      clusterExternalSecret.GetName(),
-						Namespace: clusterExternalSecret.GetNamespace(),
-					},
-				})
-				selected = true
-				break
-			}
+		selected, err := namespaceSelection(clusterExternalSecret).Selects(namespace)
+		if err != nil {
+			r.Log.Error(err, errConvertLabelSelector)
 		}
-
-		// Prevent the object from being added twice if it happens to be listed
-		// by Namespaces selector as well.
 		if selected {
-			continue
-		}
-
-		if slices.Contains(clusterExternalSecret.Spec.Namespaces, namespace.GetName()) {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      clusterExternalSecret.GetName(),
@@ -373,19 +343,22 @@ func (r *Reconciler) findObjectsForNamespace(ctx context.Context, namespace clie
 	return requests
 }
 
-func namespacePredicate() predicate.Predicate {
-	return predicate.Funcs{
-		CreateFunc: func(e event.CreateEvent) bool {
-			return true
-		},
-		UpdateFunc: func(e event.UpdateEvent) bool {
-			if e.ObjectOld == nil || e.ObjectNew == nil {
-				return false
-			}
-			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
-		},
-		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
-			return true
+// externalSecretPredicate filters out ExternalSecret updates which only touch
+// the refresh time, so that the aggregated child health is not recomputed on every refresh.
+func externalSecretPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldES, okOld := e.ObjectOld.(*esv1beta1.ExternalSecret)
+				newES, okNew := e.ObjectNew.(*esv1beta1.ExternalSecret)
+				if !okOld || !okNew {
+					return true
+				}
+				return NewNamespaceStatus(oldES.Namespace, oldES.Name, oldES) != NewNamespaceStatus(newES.Namespace, newES.Name, newES)
+			},
 		},
-	}
+	)
 }
@@ -50,6 +50,8 @@ type testCase struct {
 	beforeCheck                   func(ctx context.Context, namespaces []v1.Namespace, created esv1beta1.ClusterExternalSecret)
 	expectedClusterExternalSecret func(namespaces []v1.Namespace, created esv1beta1.ClusterExternalSecret) esv1beta1.ClusterExternalSecret
 	expectedExternalSecrets       func(namespaces []v1.Namespace, created esv1beta1.ClusterExternalSecret) []esv1beta1.ExternalSecret
+	// externalSecretCondition is reported on the provisioned ExternalSecrets, defaults to Ready
+	externalSecretCondition *esv1beta1.ExternalSecretStatusCondition
 }
 
 var _ = Describe("ClusterExternalSecret controller", func() {
@@ -110,7 +112,32 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 			By("checking the cluster external secret")
 			expectedCES := tc.expectedClusterExternalSecret(namespaces, ces)
 
+			condition := tc.externalSecretCondition
+			if condition == nil {
+				condition = &esv1beta1.ExternalSecretStatusCondition{
+					Type:    esv1beta1.ExternalSecretReady,
+					Status:  v1.ConditionTrue,
+					Reason:  esv1beta1.ConditionReasonSecretSynced,
+					Message: "Secret was synced",
+				}
+			}
+
 			Eventually(func(g Gomega) {
+				// there is no ExternalSecret controller running, so report the state of the provisioned ExternalSecrets
+				for _, ns := range namespaces {
+					var externalSecrets esv1beta1.ExternalSecretList
+					err := k8sClient.List(ctx, &externalSecrets, crclient.InNamespace(ns.Name))
+					g.Expect(err).ShouldNot(HaveOccurred())
+					for i := range externalSecrets.Items {
+						es := &externalSecrets.Items[i]
+						if !isExternalSecretOwnedBy(es, ces.Name) || len(es.Status.Conditions) > 0 {
+							continue
+						}
+						es.Status.Conditions = []esv1beta1.ExternalSecretStatusCondition{*condition}
+						g.Expect(k8sClient.Status().Update(ctx, es)).ShouldNot(HaveOccurred())
+					}
+				}
+
 				key := types.NamespacedName{Name: expectedCES.Name}
 				var gotCes esv1beta1.ClusterExternalSecret
 				err = k8sClient.Get(ctx, key, &gotCes)
@@ -171,6 +198,8 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses:     readyNamespaceStatuses(created.Name, namespaces[0].Name),
+						ReadyExternalSecrets:  1,
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
@@ -217,6 +246,8 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    "test-es",
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses:     readyNamespaceStatuses("test-es", namespaces[0].Name),
+						ReadyExternalSecrets:  1,
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
@@ -276,6 +307,8 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    "new-es-name",
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses:     readyNamespaceStatuses("new-es-name", namespaces[0].Name),
+						ReadyExternalSecrets:  1,
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
@@ -343,6 +376,8 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses:     readyNamespaceStatuses(created.Name, namespaces[0].Name),
+						ReadyExternalSecrets:  1,
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
@@ -475,6 +510,8 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses:     readyNamespaceStatuses(created.Name, namespaces[0].Name),
+						ReadyExternalSecrets:  1,
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
@@ -540,6 +577,8 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: []string{namespaces[1].Name},
+						NamespaceStatuses:     readyNamespaceStatuses(created.Name, namespaces[1].Name),
+						ReadyExternalSecrets:  1,
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
@@ -607,6 +646,8 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					Status: esv1beta1.ClusterExternalSecretStatus{
 						ExternalSecretName:    created.Name,
 						ProvisionedNamespaces: provisionedNamespaces,
+						NamespaceStatuses:     readyNamespaceStatuses(created.Name, provisionedNamespaces...),
+						ReadyExternalSecrets:  len(provisionedNamespaces),
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
@@ -722,6 +763,8 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 							"namespace1",
 							"namespace2",
 						},
+						NamespaceStatuses:    readyNamespaceStatuses(created.Name, "namespace1", "namespace2"),
+						ReadyExternalSecrets: 2,
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
@@ -776,6 +819,8 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 						ProvisionedNamespaces: []string{
 							"not-matching-namespace",
 						},
+						NamespaceStatuses:    readyNamespaceStatuses(created.Name, "not-matching-namespace"),
+						ReadyExternalSecrets: 1,
 						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
 							{
 								Type:   esv1beta1.ClusterExternalSecretReady,
@@ -796,6 +841,116 @@ var _ = Describe("ClusterExternalSecret controller", func() {
 					},
 				}
 			},
+		}),
+		Entry("Should render namespace templates in the external secret name and spec", testCase{
+			namespaces: []v1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   randomNamespaceName(),
+						Labels: map[string]string{"team": "blue"},
+					},
+				},
+			},
+			clusterExternalSecret: func(namespaces []v1.Namespace) esv1beta1.ClusterExternalSecret {
+				ces := defaultClusterExternalSecret()
+				ces.Spec.NamespaceTemplating = true
+				ces.Spec.ExternalSecretName = "{{ .namespace.labels.team }}-es"
+				ces.Spec.ExternalSecretSpec.Data[0].RemoteRef.Key = "/tenants/{{ .namespace.name }}/db"
+				ces.Spec.ExternalSecretSpec.Target.Template = &esv1beta1.ExternalSecretTemplate{
+					Data: map[string]string{"password": "{{ .password }}"},
+				}
+				ces.Spec.Namespaces = []string{namespaces[0].Name}
+				return *ces
+			},
+			expectedClusterExternalSecret: func(namespaces []v1.Namespace, created esv1beta1.ClusterExternalSecret) esv1beta1.ClusterExternalSecret {
+				return esv1beta1.ClusterExternalSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: created.Name,
+					},
+					Spec: created.Spec,
+					Status: esv1beta1.ClusterExternalSecretStatus{
+						ExternalSecretName:    created.Spec.ExternalSecretName,
+						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses:     readyNamespaceStatuses("blue-es", namespaces[0].Name),
+						ReadyExternalSecrets:  1,
+						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
+							{
+								Type:   esv1beta1.ClusterExternalSecretReady,
+								Status: v1.ConditionTrue,
+							},
+						},
+					},
+				}
+			},
+			expectedExternalSecrets: func(namespaces []v1.Namespace, created esv1beta1.ClusterExternalSecret) []esv1beta1.ExternalSecret {
+				spec := *created.Spec.ExternalSecretSpec.DeepCopy()
+				spec.Data[0].RemoteRef.Key = fmt.Sprintf("/tenants/%s/db", namespaces[0].Name)
+				return []esv1beta1.ExternalSecret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespaces[0].Name,
+							Name:      "blue-es",
+						},
+						Spec: spec,
+					},
+				}
+			},
+		}),
+		Entry("Should not be ready if an external secret failed to sync", testCase{
+			namespaces: []v1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: randomNamespaceName()}},
+			},
+			externalSecretCondition: &esv1beta1.ExternalSecretStatusCondition{
+				Type:    esv1beta1.ExternalSecretReady,
+				Status:  v1.ConditionFalse,
+				Reason:  esv1beta1.ConditionReasonSecretSyncedError,
+				Message: "could not get secret data from provider",
+			},
+			clusterExternalSecret: func(namespaces []v1.Namespace) esv1beta1.ClusterExternalSecret {
+				ces := defaultClusterExternalSecret()
+				ces.Spec.Namespaces = []string{namespaces[0].Name}
+				return *ces
+			},
+			expectedClusterExternalSecret: func(namespaces []v1.Namespace, created esv1beta1.ClusterExternalSecret) esv1beta1.ClusterExternalSecret {
+				return esv1beta1.ClusterExternalSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: created.Name,
+					},
+					Spec: created.Spec,
+					Status: esv1beta1.ClusterExternalSecretStatus{
+						ExternalSecretName:    created.Name,
+						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses: []esv1beta1.ClusterExternalSecretNamespaceStatus{
+							{
+								Namespace:          namespaces[0].Name,
+								ExternalSecretName: created.Name,
+								Health:             esv1beta1.ExternalSecretHealthFailed,
+								Reason:             esv1beta1.ConditionReasonSecretSyncedError,
+								Message:            "could not get secret data from provider",
+							},
+						},
+						FailedExternalSecrets: 1,
+						Conditions: []esv1beta1.ClusterExternalSecretStatusCondition{
+							{
+								Type:    esv1beta1.ClusterExternalSecretReady,
+								Status:  v1.ConditionFalse,
+								Message: errExternalSecretsFailed,
+							},
+						},
+					},
+				}
+			},
+			expectedExternalSecrets: func(namespaces []v1.Namespace, created esv1beta1.ClusterExternalSecret) []esv1beta1.ExternalSecret {
+				return []esv1beta1.ExternalSecret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespaces[0].Name,
+							Name:      created.Name,
+						},
+						Spec: created.Spec.ExternalSecretSpec,
+					},
+				}
+			},
 		}))
 })
 
@@ -809,6 +964,20 @@ func randString(n int) string {
 	return string(b)
 }
 
+func readyNamespaceStatuses(esName string, namespaces ...string) []esv1beta1.ClusterExternalSecretNamespaceStatus {
+	statuses := make([]esv1beta1.ClusterExternalSecretNamespaceStatus, 0, len(namespaces))
+	for _, ns := range namespaces {
+		statuses = append(statuses, esv1beta1.ClusterExternalSecretNamespaceStatus{
+			Namespace:          ns,
+			ExternalSecretName: esName,
+			Health:             esv1beta1.ExternalSecretHealthReady,
+			Reason:             esv1beta1.ConditionReasonSecretSynced,
+			Message:            "Secret was synced",
+		})
+	}
+	return statuses
+}
+
 func randomNamespaceName() string {
 	return fmt.Sprintf("testns-%s", randString(10))
 }
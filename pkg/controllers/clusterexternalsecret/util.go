@@ -15,29 +15,81 @@ limitations under the License.
 package clusterexternalsecret
 
 import (
+	"sort"
+
 	v1 "k8s.io/api/core/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret/cesmetrics"
 )
 
-func NewClusterExternalSecretCondition(failedNamespaces map[string]error) *esv1beta1.ClusterExternalSecretStatusCondition {
-	if len(failedNamespaces) == 0 {
-		return &esv1beta1.ClusterExternalSecretStatusCondition{
-			Type:   esv1beta1.ClusterExternalSecretReady,
-			Status: v1.ConditionTrue,
-		}
+func NewClusterExternalSecretCondition(failedNamespaces map[string]error, status *esv1beta1.ClusterExternalSecretStatus) *esv1beta1.ClusterExternalSecretStatusCondition {
+	condition := &esv1beta1.ClusterExternalSecretStatusCondition{
+		Type:   esv1beta1.ClusterExternalSecretReady,
+		Status: v1.ConditionFalse,
 	}
 
-	condition := &esv1beta1.ClusterExternalSecretStatusCondition{
-		Type:    esv1beta1.ClusterExternalSecretReady,
-		Status:  v1.ConditionFalse,
-		Message: errNamespacesFailed,
+	switch {
+	case len(failedNamespaces) > 0:
+		condition.Message = errNamespacesFailed
+	case status.FailedExternalSecrets > 0:
+		condition.Message = errExternalSecretsFailed
+	case status.PendingExternalSecrets > 0:
+		condition.Message = msgExternalSecretsPending
+	default:
+		condition.Status = v1.ConditionTrue
 	}
 
 	return condition
 }
 
+// NewNamespaceStatus derives the health of the ExternalSecret from its Ready condition.
+// ExternalSecrets which do not report a Ready condition yet are Pending.
+func NewNamespaceStatus(namespace, esName string, es *esv1beta1.ExternalSecret) esv1beta1.ClusterExternalSecretNamespaceStatus {
+	status := esv1beta1.ClusterExternalSecretNamespaceStatus{
+		Namespace:          namespace,
+		ExternalSecretName: esName,
+		Health:             esv1beta1.ExternalSecretHealthPending,
+	}
+
+	for _, c := range es.Status.Conditions {
+		if c.Type != esv1beta1.ExternalSecretReady {
+			continue
+		}
+		status.Reason = c.Reason
+		status.Message = c.Message
+		switch c.Status {
+		case v1.ConditionTrue:
+			status.Health = esv1beta1.ExternalSecretHealthReady
+		case v1.ConditionFalse:
+			status.Health = esv1beta1.ExternalSecretHealthFailed
+		case v1.ConditionUnknown:
+		}
+	}
+
+	return status
+}
+
+// SetNamespaceStatuses stores the namespace statuses and counts the ExternalSecrets by health.
+func SetNamespaceStatuses(ces *esv1beta1.ClusterExternalSecret, statuses []esv1beta1.ClusterExternalSecretNamespaceStatus) {
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Namespace < statuses[j].Namespace })
+	ces.Status.NamespaceStatuses = statuses
+	ces.Status.ReadyExternalSecrets = 0
+	ces.Status.FailedExternalSecrets = 0
+	ces.Status.PendingExternalSecrets = 0
+	for _, s := range statuses {
+		switch s.Health {
+		case esv1beta1.ExternalSecretHealthReady:
+			ces.Status.ReadyExternalSecrets++
+		case esv1beta1.ExternalSecretHealthFailed:
+			ces.Status.FailedExternalSecrets++
+		case esv1beta1.ExternalSecretHealthPending:
+			ces.Status.PendingExternalSecrets++
+		}
+	}
+	cesmetrics.UpdateExternalSecretHealth(ces)
+}
+
 func SetClusterExternalSecretCondition(ces *esv1beta1.ClusterExternalSecret, condition esv1beta1.ClusterExternalSecretStatusCondition) {
 	ces.Status.Conditions = append(filterOutCondition(ces.Status.Conditions, condition.Type), condition)
 	cesmetrics.UpdateClusterExternalSecretCondition(ces, &condition)
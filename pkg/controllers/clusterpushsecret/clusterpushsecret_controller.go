@@ -0,0 +1,308 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpushsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret/cpsmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/fanout"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+)
+
+// Reconciler reconciles a ClusterPushSecret object.
+type Reconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	RequeueInterval time.Duration
+}
+
+const (
+	errGetCPS               = "could not get ClusterPushSecret"
+	errPatchStatus          = "unable to patch status"
+	errConvertLabelSelector = "unable to convert labelselector"
+	errGetExistingPS        = "could not get existing PushSecret"
+	errPushSecretExists     = "push secret already exists in namespace"
+	errNamespacesFailed     = "one or more namespaces failed"
+	errPushSecretsFailed    = "one or more PushSecrets failed to sync"
+	msgPushSecretsPending   = "waiting for PushSecrets to sync"
+)
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("ClusterPushSecret", req.NamespacedName)
+
+	resourceLabels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": req.Name, "namespace": req.Namespace})
+	start := time.Now()
+
+	pushSecretReconcileDuration := cpsmetrics.GetGaugeVec(cpsmetrics.ClusterPushSecretReconcileDurationKey)
+	defer func() { pushSecretReconcileDuration.With(resourceLabels).Set(float64(time.Since(start))) }()
+
+	var clusterPushSecret esapi.ClusterPushSecret
+	err := r.Get(ctx, req.NamespacedName, &clusterPushSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			cpsmetrics.RemoveMetrics(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, errGetCPS)
+		return ctrl.Result{}, err
+	}
+
+	// skip reconciliation if deletion timestamp is set on cluster push secret
+	if clusterPushSecret.DeletionTimestamp != nil {
+		log.Info("skipping as it is in deletion")
+		return ctrl.Result{}, nil
+	}
+
+	p := client.MergeFrom(clusterPushSecret.DeepCopy())
+	defer r.deferPatch(ctx, log, &clusterPushSecret, p)
+
+	refreshInt := r.RequeueInterval
+	if clusterPushSecret.Spec.RefreshInterval != nil {
+		refreshInt = clusterPushSecret.Spec.RefreshInterval.Duration
+	}
+
+	psName := clusterPushSecret.Spec.PushSecretName
+	if psName == "" {
+		psName = clusterPushSecret.ObjectMeta.Name
+	}
+	if prevName := clusterPushSecret.Status.PushSecretName; prevName != psName {
+		// PushSecretName has changed, so remove the old ones
+		for _, ns := range clusterPushSecret.Status.ProvisionedNamespaces {
+			if err := r.deletePushSecret(ctx, prevName, clusterPushSecret.Name, ns); err != nil {
+				log.Error(err, "could not delete PushSecret")
+				return ctrl.Result{}, err
+			}
+		}
+	}
+	clusterPushSecret.Status.PushSecretName = psName
+
+	namespaces, err := namespaceSelection(&clusterPushSecret).Namespaces(ctx, r)
+	if err != nil {
+		log.Error(err, "failed to get target Namespaces")
+		return ctrl.Result{}, err
+	}
+
+	failedNamespaces := r.deleteOutdatedPushSecrets(ctx, namespaces, psName, &clusterPushSecret)
+
+	provisionedNamespaces := []string{}
+	namespaceStatuses := []esapi.ClusterPushSecretNamespaceStatus{}
+	for _, namespace := range namespaces {
+		var existingPS esapi.PushSecret
+		err = r.Get(ctx, types.NamespacedName{
+			Name:      psName,
+			Namespace: namespace.Name,
+		}, &existingPS)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, errGetExistingPS)
+			failedNamespaces[namespace.Name] = err
+			continue
+		}
+
+		if err == nil && !isPushSecretOwnedBy(&existingPS, clusterPushSecret.Name) {
+			failedNamespaces[namespace.Name] = errors.New(errPushSecretExists)
+			continue
+		}
+
+		if err := r.createOrUpdatePushSecret(ctx, &clusterPushSecret, namespace.Name, psName); err != nil {
+			log.Error(err, "failed to create or update push secret")
+			failedNamespaces[namespace.Name] = err
+			continue
+		}
+
+		provisionedNamespaces = append(provisionedNamespaces, namespace.Name)
+		// existingPS is empty if the PushSecret has just been created, which reports an Unknown status
+		namespaceStatuses = append(namespaceStatuses, NewNamespaceStatus(namespace.Name, psName, &existingPS))
+	}
+
+	sort.Slice(namespaceStatuses, func(i, j int) bool { return namespaceStatuses[i].Namespace < namespaceStatuses[j].Namespace })
+	clusterPushSecret.Status.NamespaceStatuses = namespaceStatuses
+	condition := NewClusterPushSecretCondition(failedNamespaces, namespaceStatuses)
+	SetClusterPushSecretCondition(&clusterPushSecret, *condition)
+
+	clusterPushSecret.Status.FailedNamespaces = toNamespaceFailures(failedNamespaces)
+	sort.Strings(provisionedNamespaces)
+	clusterPushSecret.Status.ProvisionedNamespaces = provisionedNamespaces
+
+	return ctrl.Result{RequeueAfter: refreshInt}, nil
+}
+
+// namespaceSelection returns the namespaces selected by the ClusterPushSecret.
+func namespaceSelection(cps *esapi.ClusterPushSecret) fanout.Selection {
+	return fanout.Selection{
+		Names:     cps.Spec.Namespaces,
+		Selectors: cps.Spec.NamespaceSelectors,
+	}
+}
+
+func (r *Reconciler) createOrUpdatePushSecret(ctx context.Context, clusterPushSecret *esapi.ClusterPushSecret, namespace, psName string) error {
+	pushSecret := &esapi.PushSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      psName,
+		},
+	}
+
+	mutateFunc := func() error {
+		pushSecret.Labels = clusterPushSecret.Spec.PushSecretMetadata.Labels
+		pushSecret.Annotations = clusterPushSecret.Spec.PushSecretMetadata.Annotations
+		pushSecret.Spec = *clusterPushSecret.Spec.PushSecretSpec.DeepCopy()
+
+		if err := controllerutil.SetControllerReference(clusterPushSecret, pushSecret, r.Scheme); err != nil {
+			return fmt.Errorf("could not set the controller owner reference %w", err)
+		}
+
+		return nil
+	}
+
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, pushSecret, mutateFunc); err != nil {
+		return fmt.Errorf("could not create or update PushSecret: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) deletePushSecret(ctx context.Context, psName, cpsName, namespace string) error {
+	var existingPS esapi.PushSecret
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      psName,
+		Namespace: namespace,
+	}, &existingPS)
+	if err != nil {
+		// If we can't find it then just leave
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !isPushSecretOwnedBy(&existingPS, cpsName) {
+		return nil
+	}
+
+	err = r.Delete(ctx, &existingPS, &client.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("push secret in non matching namespace could not be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) deferPatch(ctx context.Context, log logr.Logger, clusterPushSecret *esapi.ClusterPushSecret, p client.Patch) {
+	if err := r.Status().Patch(ctx, clusterPushSecret, p); err != nil {
+		log.Error(err, errPatchStatus)
+	}
+}
+
+func (r *Reconciler) deleteOutdatedPushSecrets(ctx context.Context, namespaces []v1.Namespace, psName string, cps *esapi.ClusterPushSecret) map[string]error {
+	failedNamespaces := map[string]error{}
+	// Loop through existing namespaces first to make sure they still have our labels
+	for _, namespace := range fanout.RemovedNamespaces(namespaces, cps.Status.ProvisionedNamespaces) {
+		if err := r.deletePushSecret(ctx, psName, cps.Name, namespace); err != nil {
+			r.Log.Error(err, "unable to delete push secret")
+			failedNamespaces[namespace] = err
+		}
+	}
+
+	return failedNamespaces
+}
+
+func isPushSecretOwnedBy(ps *esapi.PushSecret, cpsName string) bool {
+	owner := metav1.GetControllerOf(ps)
+	return owner != nil && owner.APIVersion == esapi.SchemeGroupVersion.String() && owner.Kind == esapi.ClusterPushSecretKind && owner.Name == cpsName
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(opts).
+		For(&esapi.ClusterPushSecret{}).
+		Owns(&esapi.PushSecret{}, builder.WithPredicates(pushSecretPredicate())).
+		Watches(
+			&v1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForNamespace),
+			builder.WithPredicates(fanout.NamespacePredicate()),
+		).
+		Complete(r)
+}
+
+func (r *Reconciler) findObjectsForNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	var clusterPushSecrets esapi.ClusterPushSecretList
+	if err := r.List(ctx, &clusterPushSecrets); err != nil {
+		r.Log.Error(err, errGetCPS)
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range clusterPushSecrets.Items {
+		clusterPushSecret := &clusterPushSecrets.Items[i]
+		selected, err := namespaceSelection(clusterPushSecret).Selects(namespace)
+		if err != nil {
+			r.Log.Error(err, errConvertLabelSelector)
+		}
+		if selected {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: clusterPushSecret.GetName(),
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// pushSecretPredicate filters out PushSecret updates which only touch
+// the refresh time, so that the namespace statuses are not recomputed on every refresh.
+func pushSecretPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldPS, okOld := e.ObjectOld.(*esapi.PushSecret)
+				newPS, okNew := e.ObjectNew.(*esapi.PushSecret)
+				if !okOld || !okNew {
+					return true
+				}
+				return NewNamespaceStatus(oldPS.Namespace, oldPS.Name, oldPS) != NewNamespaceStatus(newPS.Namespace, newPS.Name, newPS)
+			},
+		},
+	)
+}
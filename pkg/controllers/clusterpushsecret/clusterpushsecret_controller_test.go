@@ -0,0 +1,498 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpushsecret
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret/cpsmetrics"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func init() {
+	ctrlmetrics.SetUpLabelNames(false)
+	cpsmetrics.SetUpMetrics()
+}
+
+var (
+	timeout  = time.Second * 10
+	interval = time.Millisecond * 250
+)
+
+type testCase struct {
+	namespaces                []v1.Namespace
+	clusterPushSecret         func(namespaces []v1.Namespace) esapi.ClusterPushSecret
+	beforeCheck               func(ctx context.Context, namespaces []v1.Namespace, created esapi.ClusterPushSecret)
+	expectedClusterPushSecret func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) esapi.ClusterPushSecret
+	expectedPushSecrets       func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) []esapi.PushSecret
+	// pushSecretCondition is reported on the provisioned PushSecrets, defaults to Ready
+	pushSecretCondition *esapi.PushSecretStatusCondition
+}
+
+var _ = Describe("ClusterPushSecret controller", func() {
+	defaultClusterPushSecret := func() *esapi.ClusterPushSecret {
+		return &esapi.ClusterPushSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("test-cps-%s", randString(10)),
+			},
+			Spec: esapi.ClusterPushSecretSpec{
+				PushSecretSpec: esapi.PushSecretSpec{
+					SecretStoreRefs: []esapi.PushSecretStoreRef{
+						{
+							Name: "test-store",
+							Kind: "ClusterSecretStore",
+						},
+					},
+					Selector: esapi.PushSecretSelector{
+						Secret: &esapi.PushSecretSecret{
+							Name: "test-secret",
+						},
+					},
+					Data: []esapi.PushSecretData{
+						{
+							Match: esapi.PushSecretMatch{
+								SecretKey: "test-secret-key",
+								RemoteRef: esapi.PushSecretRemoteRef{
+									RemoteKey: "test-remote-key",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	DescribeTable("When reconciling a ClusterPushSecret",
+		func(tc testCase) {
+			ctx := context.Background()
+			By("creating namespaces")
+			var namespaces []v1.Namespace
+			for _, ns := range tc.namespaces {
+				err := k8sClient.Create(ctx, &ns)
+				Expect(err).ShouldNot(HaveOccurred())
+				namespaces = append(namespaces, ns)
+			}
+
+			By("creating a cluster push secret")
+			cps := tc.clusterPushSecret(tc.namespaces)
+			err := k8sClient.Create(ctx, &cps)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("running before check")
+			if tc.beforeCheck != nil {
+				tc.beforeCheck(ctx, namespaces, cps)
+			}
+
+			// the before check above may have updated the namespaces, so refresh them
+			for i, ns := range namespaces {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: ns.Name}, &ns)
+				Expect(err).ShouldNot(HaveOccurred())
+				namespaces[i] = ns
+			}
+
+			By("checking the cluster push secret")
+			expectedCPS := tc.expectedClusterPushSecret(namespaces, cps)
+
+			condition := tc.pushSecretCondition
+			if condition == nil {
+				condition = &esapi.PushSecretStatusCondition{
+					Type:    esapi.PushSecretReady,
+					Status:  v1.ConditionTrue,
+					Reason:  esapi.ReasonSynced,
+					Message: "PushSecret synced successfully",
+				}
+			}
+
+			Eventually(func(g Gomega) {
+				// there is no PushSecret controller running, so report the state of the provisioned PushSecrets
+				for _, ns := range namespaces {
+					var pushSecrets esapi.PushSecretList
+					err := k8sClient.List(ctx, &pushSecrets, crclient.InNamespace(ns.Name))
+					g.Expect(err).ShouldNot(HaveOccurred())
+					for i := range pushSecrets.Items {
+						ps := &pushSecrets.Items[i]
+						if !isPushSecretOwnedBy(ps, cps.Name) || len(ps.Status.Conditions) > 0 {
+							continue
+						}
+						ps.Status.Conditions = []esapi.PushSecretStatusCondition{*condition}
+						g.Expect(k8sClient.Status().Update(ctx, ps)).ShouldNot(HaveOccurred())
+					}
+				}
+
+				key := types.NamespacedName{Name: expectedCPS.Name}
+				var gotCPS esapi.ClusterPushSecret
+				err = k8sClient.Get(ctx, key, &gotCPS)
+				g.Expect(err).ShouldNot(HaveOccurred())
+
+				g.Expect(gotCPS.Labels).To(Equal(expectedCPS.Labels))
+				g.Expect(gotCPS.Annotations).To(Equal(expectedCPS.Annotations))
+				g.Expect(gotCPS.Spec).To(Equal(expectedCPS.Spec))
+				g.Expect(gotCPS.Status).To(Equal(expectedCPS.Status))
+			}).WithTimeout(timeout).WithPolling(interval).Should(Succeed())
+
+			By("checking the push secrets")
+			expectedPSs := tc.expectedPushSecrets(namespaces, cps)
+
+			Eventually(func(g Gomega) {
+				var gotPSs []esapi.PushSecret
+				for _, ns := range namespaces {
+					var pushSecrets esapi.PushSecretList
+					err := k8sClient.List(ctx, &pushSecrets, crclient.InNamespace(ns.Name))
+					g.Expect(err).ShouldNot(HaveOccurred())
+
+					gotPSs = append(gotPSs, pushSecrets.Items...)
+				}
+
+				g.Expect(len(gotPSs)).Should(Equal(len(expectedPSs)))
+				for _, gotPS := range gotPSs {
+					found := false
+					for _, expectedPS := range expectedPSs {
+						if gotPS.Namespace == expectedPS.Namespace && gotPS.Name == expectedPS.Name {
+							found = true
+							g.Expect(gotPS.Labels).To(Equal(expectedPS.Labels))
+							g.Expect(gotPS.Annotations).To(Equal(expectedPS.Annotations))
+							g.Expect(gotPS.Spec).To(Equal(expectedPS.Spec))
+						}
+					}
+					g.Expect(found).To(Equal(true))
+				}
+			}).WithTimeout(timeout).WithPolling(interval).Should(Succeed())
+		},
+
+		Entry("Should use cluster push secret name if push secret name isn't defined", testCase{
+			namespaces: []v1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: randomNamespaceName()}},
+			},
+			clusterPushSecret: func(namespaces []v1.Namespace) esapi.ClusterPushSecret {
+				cps := defaultClusterPushSecret()
+				cps.Spec.Namespaces = []string{namespaces[0].Name}
+				return *cps
+			},
+			expectedClusterPushSecret: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) esapi.ClusterPushSecret {
+				return esapi.ClusterPushSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: created.Name,
+					},
+					Spec: created.Spec,
+					Status: esapi.ClusterPushSecretStatus{
+						PushSecretName:        created.Name,
+						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses:     readyNamespaceStatuses(created.Name, namespaces[0].Name),
+						Conditions: []esapi.PushSecretStatusCondition{
+							{
+								Type:   esapi.PushSecretReady,
+								Status: v1.ConditionTrue,
+								Reason: esapi.ReasonSynced,
+							},
+						},
+					},
+				}
+			},
+			expectedPushSecrets: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) []esapi.PushSecret {
+				return []esapi.PushSecret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespaces[0].Name,
+							Name:      created.Name,
+						},
+						Spec: created.Spec.PushSecretSpec,
+					},
+				}
+			},
+		}),
+		Entry("Should set push secret name and metadata if the fields are set", testCase{
+			namespaces: []v1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: randomNamespaceName(), Labels: map[string]string{"team": "blue"}}},
+			},
+			clusterPushSecret: func(namespaces []v1.Namespace) esapi.ClusterPushSecret {
+				cps := defaultClusterPushSecret()
+				cps.Spec.NamespaceSelectors = []*metav1.LabelSelector{
+					{MatchLabels: map[string]string{"kubernetes.io/metadata.name": namespaces[0].Name, "team": "blue"}},
+				}
+				cps.Spec.PushSecretName = "test-ps"
+				cps.Spec.PushSecretMetadata = esapi.PushSecretMetadata{
+					Labels:      map[string]string{"test-label-key": "test-label-value"},
+					Annotations: map[string]string{"test-annotation-key": "test-annotation-value"},
+				}
+				return *cps
+			},
+			expectedClusterPushSecret: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) esapi.ClusterPushSecret {
+				return esapi.ClusterPushSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: created.Name,
+					},
+					Spec: created.Spec,
+					Status: esapi.ClusterPushSecretStatus{
+						PushSecretName:        "test-ps",
+						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses:     readyNamespaceStatuses("test-ps", namespaces[0].Name),
+						Conditions: []esapi.PushSecretStatusCondition{
+							{
+								Type:   esapi.PushSecretReady,
+								Status: v1.ConditionTrue,
+								Reason: esapi.ReasonSynced,
+							},
+						},
+					},
+				}
+			},
+			expectedPushSecrets: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) []esapi.PushSecret {
+				return []esapi.PushSecret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:   namespaces[0].Name,
+							Name:        "test-ps",
+							Labels:      map[string]string{"test-label-key": "test-label-value"},
+							Annotations: map[string]string{"test-annotation-key": "test-annotation-value"},
+						},
+						Spec: created.Spec.PushSecretSpec,
+					},
+				}
+			},
+		}),
+		Entry("Should not overwrite existing push secrets and error out if one is present", testCase{
+			namespaces: []v1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: randomNamespaceName()}},
+			},
+			clusterPushSecret: func(namespaces []v1.Namespace) esapi.ClusterPushSecret {
+				cps := defaultClusterPushSecret()
+				cps.Spec.Namespaces = []string{namespaces[0].Name}
+
+				ps := &esapi.PushSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      cps.Name,
+						Namespace: namespaces[0].Name,
+					},
+					Spec: esapi.PushSecretSpec{
+						SecretStoreRefs: []esapi.PushSecretStoreRef{{Name: "other-store"}},
+						Selector: esapi.PushSecretSelector{
+							Secret: &esapi.PushSecretSecret{Name: "other-secret"},
+						},
+					},
+				}
+				Expect(k8sClient.Create(context.Background(), ps)).ShouldNot(HaveOccurred())
+
+				return *cps
+			},
+			expectedClusterPushSecret: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) esapi.ClusterPushSecret {
+				return esapi.ClusterPushSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: created.Name,
+					},
+					Spec: created.Spec,
+					Status: esapi.ClusterPushSecretStatus{
+						PushSecretName: created.Name,
+						FailedNamespaces: []esapi.ClusterPushSecretNamespaceFailure{
+							{
+								Namespace: namespaces[0].Name,
+								Reason:    errPushSecretExists,
+							},
+						},
+						Conditions: []esapi.PushSecretStatusCondition{
+							{
+								Type:    esapi.PushSecretReady,
+								Status:  v1.ConditionFalse,
+								Reason:  esapi.ReasonErrored,
+								Message: errNamespacesFailed,
+							},
+						},
+					},
+				}
+			},
+			expectedPushSecrets: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) []esapi.PushSecret {
+				return []esapi.PushSecret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespaces[0].Name,
+							Name:      created.Name,
+						},
+						Spec: esapi.PushSecretSpec{
+							SecretStoreRefs: []esapi.PushSecretStoreRef{{Name: "other-store", Kind: "SecretStore"}},
+							UpdatePolicy:    esapi.PushSecretUpdatePolicyReplace,
+							DeletionPolicy:  esapi.PushSecretDeletionPolicyNone,
+							Selector: esapi.PushSecretSelector{
+								Secret: &esapi.PushSecretSecret{Name: "other-secret"},
+							},
+						},
+					},
+				}
+			},
+		}),
+		Entry("Should delete push secrets when namespaces no longer match", testCase{
+			namespaces: []v1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   randomNamespaceName(),
+						Labels: map[string]string{"no-longer-match-label-key": "no-longer-match-label-value"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   randomNamespaceName(),
+						Labels: map[string]string{"no-longer-match-label-key": "no-longer-match-label-value"},
+					},
+				},
+			},
+			clusterPushSecret: func(namespaces []v1.Namespace) esapi.ClusterPushSecret {
+				cps := defaultClusterPushSecret()
+				cps.Spec.RefreshInterval = &metav1.Duration{Duration: 100 * time.Millisecond}
+				cps.Spec.NamespaceSelectors = []*metav1.LabelSelector{
+					{MatchLabels: map[string]string{"no-longer-match-label-key": "no-longer-match-label-value"}},
+				}
+				return *cps
+			},
+			beforeCheck: func(ctx context.Context, namespaces []v1.Namespace, created esapi.ClusterPushSecret) {
+				// Wait until the target PSs have been created
+				Eventually(func(g Gomega) {
+					for _, ns := range namespaces {
+						key := types.NamespacedName{Namespace: ns.Name, Name: created.Name}
+						g.Expect(k8sClient.Get(ctx, key, &esapi.PushSecret{})).ShouldNot(HaveOccurred())
+					}
+				}).WithTimeout(timeout).WithPolling(interval).Should(Succeed())
+
+				namespaces[0].Labels = map[string]string{}
+				Expect(k8sClient.Update(ctx, &namespaces[0])).ShouldNot(HaveOccurred())
+			},
+			expectedClusterPushSecret: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) esapi.ClusterPushSecret {
+				return esapi.ClusterPushSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: created.Name,
+					},
+					Spec: created.Spec,
+					Status: esapi.ClusterPushSecretStatus{
+						PushSecretName:        created.Name,
+						ProvisionedNamespaces: []string{namespaces[1].Name},
+						NamespaceStatuses:     readyNamespaceStatuses(created.Name, namespaces[1].Name),
+						Conditions: []esapi.PushSecretStatusCondition{
+							{
+								Type:   esapi.PushSecretReady,
+								Status: v1.ConditionTrue,
+								Reason: esapi.ReasonSynced,
+							},
+						},
+					},
+				}
+			},
+			expectedPushSecrets: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) []esapi.PushSecret {
+				return []esapi.PushSecret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespaces[1].Name,
+							Name:      created.Name,
+						},
+						Spec: created.Spec.PushSecretSpec,
+					},
+				}
+			},
+		}),
+		Entry("Should not be ready if a push secret failed to sync", testCase{
+			namespaces: []v1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: randomNamespaceName()}},
+			},
+			clusterPushSecret: func(namespaces []v1.Namespace) esapi.ClusterPushSecret {
+				cps := defaultClusterPushSecret()
+				cps.Spec.Namespaces = []string{namespaces[0].Name}
+				return *cps
+			},
+			pushSecretCondition: &esapi.PushSecretStatusCondition{
+				Type:    esapi.PushSecretReady,
+				Status:  v1.ConditionFalse,
+				Reason:  esapi.ReasonErrored,
+				Message: "could not get source secret",
+			},
+			expectedClusterPushSecret: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) esapi.ClusterPushSecret {
+				return esapi.ClusterPushSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: created.Name,
+					},
+					Spec: created.Spec,
+					Status: esapi.ClusterPushSecretStatus{
+						PushSecretName:        created.Name,
+						ProvisionedNamespaces: []string{namespaces[0].Name},
+						NamespaceStatuses: []esapi.ClusterPushSecretNamespaceStatus{
+							{
+								Namespace:      namespaces[0].Name,
+								PushSecretName: created.Name,
+								Status:         v1.ConditionFalse,
+								Reason:         esapi.ReasonErrored,
+								Message:        "could not get source secret",
+							},
+						},
+						Conditions: []esapi.PushSecretStatusCondition{
+							{
+								Type:    esapi.PushSecretReady,
+								Status:  v1.ConditionFalse,
+								Reason:  esapi.ReasonErrored,
+								Message: errPushSecretsFailed,
+							},
+						},
+					},
+				}
+			},
+			expectedPushSecrets: func(namespaces []v1.Namespace, created esapi.ClusterPushSecret) []esapi.PushSecret {
+				return []esapi.PushSecret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespaces[0].Name,
+							Name:      created.Name,
+						},
+						Spec: created.Spec.PushSecretSpec,
+					},
+				}
+			},
+		}),
+	)
+})
+
+var letterRunes = []rune("abcdefghijklmnopqrstuvwxyz")
+
+func randString(n int) string {
+	b := make([]rune, n)
+	for i := range b {
+		b[i] = letterRunes[rand.Intn(len(letterRunes))]
+	}
+	return string(b)
+}
+
+func readyNamespaceStatuses(psName string, namespaces ...string) []esapi.ClusterPushSecretNamespaceStatus {
+	statuses := make([]esapi.ClusterPushSecretNamespaceStatus, 0, len(namespaces))
+	for _, ns := range namespaces {
+		statuses = append(statuses, esapi.ClusterPushSecretNamespaceStatus{
+			Namespace:      ns,
+			PushSecretName: psName,
+			Status:         v1.ConditionTrue,
+			Reason:         esapi.ReasonSynced,
+			Message:        "PushSecret synced successfully",
+		})
+	}
+	return statuses
+}
+
+func randomNamespaceName() string {
+	return fmt.Sprintf("testns-%s", randString(10))
+}
@@ -0,0 +1,101 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpushsecret
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret/cpsmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/fanout"
+)
+
+func NewClusterPushSecretCondition(failedNamespaces map[string]error, statuses []esapi.ClusterPushSecretNamespaceStatus) *esapi.PushSecretStatusCondition {
+	condition := &esapi.PushSecretStatusCondition{
+		Type:   esapi.PushSecretReady,
+		Status: v1.ConditionFalse,
+		Reason: esapi.ReasonErrored,
+	}
+
+	var failed, pending bool
+	for _, s := range statuses {
+		switch s.Status {
+		case v1.ConditionFalse:
+			failed = true
+		case v1.ConditionTrue:
+		default:
+			pending = true
+		}
+	}
+
+	switch {
+	case len(failedNamespaces) > 0:
+		condition.Message = errNamespacesFailed
+	case failed:
+		condition.Message = errPushSecretsFailed
+	case pending:
+		condition.Reason = esapi.ReasonPending
+		condition.Message = msgPushSecretsPending
+	default:
+		condition.Status = v1.ConditionTrue
+		condition.Reason = esapi.ReasonSynced
+	}
+
+	return condition
+}
+
+// NewNamespaceStatus copies the Ready condition of the PushSecret.
+// PushSecrets which do not report a Ready condition yet have an Unknown status.
+func NewNamespaceStatus(namespace, psName string, ps *esapi.PushSecret) esapi.ClusterPushSecretNamespaceStatus {
+	status := esapi.ClusterPushSecretNamespaceStatus{
+		Namespace:      namespace,
+		PushSecretName: psName,
+		Status:         v1.ConditionUnknown,
+	}
+
+	for _, c := range ps.Status.Conditions {
+		if c.Type != esapi.PushSecretReady {
+			continue
+		}
+		status.Status = c.Status
+		status.Reason = c.Reason
+		status.Message = c.Message
+	}
+
+	return status
+}
+
+func SetClusterPushSecretCondition(cps *esapi.ClusterPushSecret, condition esapi.PushSecretStatusCondition) {
+	cps.Status.Conditions = append(filterOutCondition(cps.Status.Conditions, condition.Type), condition)
+	cpsmetrics.UpdateClusterPushSecretCondition(cps, &condition)
+}
+
+// filterOutCondition returns an empty set of conditions with the provided type.
+func filterOutCondition(conditions []esapi.PushSecretStatusCondition, condType esapi.PushSecretConditionType) []esapi.PushSecretStatusCondition {
+	newConditions := make([]esapi.PushSecretStatusCondition, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Type == condType {
+			continue
+		}
+		newConditions = append(newConditions, c)
+	}
+	return newConditions
+}
+
+func toNamespaceFailures(failedNamespaces map[string]error) []esapi.ClusterPushSecretNamespaceFailure {
+	return fanout.NamespaceFailures(failedNamespaces, func(namespace, reason string) esapi.ClusterPushSecretNamespaceFailure {
+		return esapi.ClusterPushSecretNamespaceFailure{Namespace: namespace, Reason: reason}
+	})
+}
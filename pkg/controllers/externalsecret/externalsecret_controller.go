@@ -41,6 +41,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
 	// Metrics.
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
@@ -53,30 +54,43 @@ import (
 )
 
 const (
-	fieldOwnerTemplate      = "externalsecrets.external-secrets.io/%v"
-	errGetES                = "could not get ExternalSecret"
-	errConvert              = "could not apply conversion strategy to keys: %v"
-	errDecode               = "could not apply decoding strategy to %v[%d]: %v"
-	errGenerate             = "could not generate [%d]: %w"
-	errRewrite              = "could not rewrite spec.dataFrom[%d]: %v"
-	errInvalidKeys          = "secret keys from spec.dataFrom.%v[%d] can only have alphanumeric,'-', '_' or '.' characters. Convert them using rewrite (https://external-secrets.io/latest/guides-datafrom-rewrite)"
-	errUpdateSecret         = "could not update Secret"
-	errPatchStatus          = "unable to patch status"
-	errGetExistingSecret    = "could not get existing secret: %w"
-	errSetCtrlReference     = "could not set ExternalSecret controller reference: %w"
-	errFetchTplFrom         = "error fetching templateFrom data: %w"
-	errGetSecretData        = "could not get secret data from provider"
-	errDeleteSecret         = "could not delete secret"
-	errApplyTemplate        = "could not apply template: %w"
-	errExecTpl              = "could not execute template: %w"
-	errInvalidCreatePolicy  = "invalid creationPolicy=%s. Can not delete secret i do not own"
-	errPolicyMergeNotFound  = "the desired secret %s was not found. With creationPolicy=Merge the secret won't be created"
-	errPolicyMergeGetSecret = "unable to get secret %s: %w"
-	errPolicyMergeMutate    = "unable to mutate secret %s: %w"
-	errPolicyMergePatch     = "unable to patch secret %s: %w"
+	fieldOwnerTemplate       = "externalsecrets.external-secrets.io/%v"
+	errGetES                 = "could not get ExternalSecret"
+	errConvert               = "could not apply conversion strategy to keys: %v"
+	errDecode                = "could not apply decoding strategy to %v[%d]: %v"
+	errGenerate              = "could not generate [%d]: %w"
+	errRewrite               = "could not rewrite spec.dataFrom[%d]: %v"
+	errInvalidKeys           = "secret keys from spec.dataFrom.%v[%d] can only have alphanumeric,'-', '_' or '.' characters. Convert them using rewrite (https://external-secrets.io/latest/guides-datafrom-rewrite)"
+	errUpdateSecret          = "could not update Secret"
+	errPatchStatus           = "unable to patch status"
+	errApplyDefaults         = "could not apply store defaults"
+	errGetExistingSecret     = "could not get existing secret: %w"
+	errSetCtrlReference      = "could not set ExternalSecret controller reference: %w"
+	errFetchTplFrom          = "error fetching templateFrom data: %w"
+	errGetSecretData         = "could not get secret data from provider"
+	errDeleteSecret          = "could not delete secret"
+	errApplyTemplate         = "could not apply template: %w"
+	errExecTpl               = "could not execute template: %w"
+	errInvalidCreatePolicy   = "invalid creationPolicy=%s. Can not delete secret i do not own"
+	errPolicyMergeNotFound   = "the desired secret %s was not found. With creationPolicy=Merge the secret won't be created"
+	errPolicyMergeGetSecret  = "unable to get secret %s: %w"
+	errPolicyMergeMutate     = "unable to mutate secret %s: %w"
+	errPolicyMergePatch      = "unable to patch secret %s: %w"
+	errSyncTargets           = "could not sync one or more additional targets"
+	errAddFinalizer          = "could not add finalizer"
+	errFinalizeTargets       = "could not clean up additional targets"
+	errFinalizeLeases        = "could not revoke leases"
+	errRenewLeases           = "could not renew leases"
+	errRenewLease            = "could not renew lease %s: %w"
+	errRevokeLease           = "could not revoke lease %s: %w"
+	errDeleteOrphanedTargets = "could not delete orphaned additional targets"
 )
 
-const externalSecretSecretNameKey = ".spec.target.name"
+const (
+	externalSecretSecretNameKey        = ".spec.target.name"
+	externalSecretAdditionalTargetsKey = ".spec.additionalTargets"
+	externalSecretStoreRefKey          = ".spec.secretStoreRef"
+)
 
 // Reconciler reconciles a ExternalSecret object.
 type Reconciler struct {
@@ -96,6 +110,7 @@ type Reconciler struct {
 // and updates/creates a Kubernetes secret based on them.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("ExternalSecret", req.NamespacedName)
+	ctx = audit.WithRequester(ctx, esv1beta1.ExtSecretKind, req.Namespace, req.Name)
 
 	resourceLabels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": req.Name, "namespace": req.Namespace})
 	start := time.Now()
@@ -137,6 +152,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	// skip reconciliation if deletion timestamp is set on external secret
 	if externalSecret.DeletionTimestamp != nil {
+		if err := r.finalizeAdditionalTargets(ctx, &externalSecret); err != nil {
+			log.Error(err, errFinalizeTargets)
+			return ctrl.Result{}, err
+		}
+		if err := r.finalizeLeases(ctx, log, &externalSecret); err != nil {
+			log.Error(err, errFinalizeLeases)
+			return ctrl.Result{}, err
+		}
 		log.Info("skipping as it is in deletion")
 		return ctrl.Result{}, nil
 	}
@@ -156,6 +179,32 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
+	// additional targets in other namespaces can not be garbage collected through owner references
+	if needsAdditionalTargetsFinalizer(&externalSecret) && controllerutil.AddFinalizer(&externalSecret, esv1beta1.FinalizerAdditionalTargets) {
+		if err := r.Update(ctx, &externalSecret); err != nil {
+			log.Error(err, errAddFinalizer)
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.deleteOrphanedTargets(ctx, &externalSecret); err != nil {
+		log.Error(err, errDeleteOrphanedTargets)
+		return ctrl.Result{}, err
+	}
+
+	// leases which are due are renewed, or the secrets generated again if they can not be renewed
+	rotateLeases, err := r.renewLeases(ctx, log, &externalSecret)
+	if err != nil {
+		log.Error(err, errRenewLeases)
+		return ctrl.Result{}, err
+	}
+
+	// the spec is only defaulted in memory, it must not be updated afterwards
+	effective, err := r.applyStoreDefaults(ctx, &externalSecret)
+	if err != nil {
+		log.Error(err, errApplyDefaults)
+		return ctrl.Result{}, err
+	}
+
 	refreshInt := r.RequeueInterval
 	if externalSecret.Spec.RefreshInterval != nil {
 		refreshInt = externalSecret.Spec.RefreshInterval.Duration
@@ -182,8 +231,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	// 1. resource generation hasn't changed
 	// 2. refresh interval is 0
 	// 3. if we're still within refresh-interval
-	if !shouldRefresh(externalSecret) && isSecretValid(existingSecret) {
+	targetsValid, err := r.areAdditionalTargetsValid(ctx, &externalSecret)
+	if err != nil {
+		log.Error(err, errGetExistingSecret)
+		return ctrl.Result{}, err
+	}
+	// ExternalSecrets reconciled before the effective settings were reported have none in their status,
+	// they are recorded without forcing a refresh of every ExternalSecret after an upgrade
+	effectiveChanged := externalSecret.Status.Effective != nil && !equality.Semantic.DeepEqual(externalSecret.Status.Effective, effective)
+	if !effectiveChanged && !rotateLeases && !shouldRefresh(externalSecret) && isSecretValid(existingSecret) && targetsValid {
+		if externalSecret.Status.Effective == nil {
+			p := client.MergeFrom(externalSecret.DeepCopy())
+			externalSecret.Status.Effective = effective
+			if err := r.Status().Patch(ctx, &externalSecret, p); err != nil {
+				log.Error(err, errPatchStatus)
+			}
+		}
 		refreshInt = (externalSecret.Spec.RefreshInterval.Duration - timeSinceLastRefresh) + 5*time.Second
+		refreshInt = leaseRequeue(externalSecret.Status.Leases, refreshInt, time.Now())
 		log.V(1).Info("skipping refresh", "rv", getResourceVersion(externalSecret), "nr", refreshInt.Seconds())
 		return ctrl.Result{RequeueAfter: refreshInt}, nil
 	}
@@ -200,6 +265,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			log.Error(err, errPatchStatus)
 		}
 	}()
+	externalSecret.Status.Effective = effective
 
 	secret := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -210,11 +276,28 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		Data:      make(map[string][]byte),
 	}
 
-	dataMap, err := r.getProviderSecretData(ctx, &externalSecret)
+	// the leases of the secrets which are no longer used are revoked, deferred calls run
+	// in reverse order so they are part of the status patch
+	dataMap, leases, err := r.getProviderSecretData(ctx, &externalSecret)
+	synced := false
+	defer func() {
+		externalSecret.Status.Leases = r.rotateLeases(ctx, log, &externalSecret, leases, synced)
+	}()
 	if err != nil {
 		r.markAsFailed(log, errGetSecretData, err, &externalSecret, syncCallsError.With(resourceLabels))
 		return ctrl.Result{}, err
 	}
+	if len(leases) > 0 {
+		if err := r.addLeasesFinalizer(ctx, &externalSecret); err != nil {
+			r.markAsFailed(log, errAddFinalizer, err, &externalSecret, syncCallsError.With(resourceLabels))
+			return ctrl.Result{}, err
+		}
+	}
+	refreshInt = leaseRequeue(leases, refreshInt, time.Now())
+
+	// additional targets are rendered from the same provider data,
+	// their errors are reported once the primary target has been processed
+	targetsErr := r.syncAdditionalTargets(ctx, log, &externalSecret, dataMap)
 
 	// if no data was found we can delete the secret if needed.
 	if len(dataMap) == 0 {
@@ -234,54 +317,29 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 				return ctrl.Result{}, err
 			}
 
+			if targetsErr != nil {
+				r.markAsFailed(log, errSyncTargets, targetsErr, &externalSecret, syncCallsError.With(resourceLabels))
+				return ctrl.Result{}, targetsErr
+			}
 			conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, esv1beta1.ConditionReasonSecretDeleted, "secret deleted due to DeletionPolicy")
 			SetExternalSecretCondition(&externalSecret, *conditionSynced)
+			synced = true
 			return ctrl.Result{RequeueAfter: refreshInt}, nil
 		// In case provider secrets don't exist the kubernetes secret will be kept as-is.
 		case esv1beta1.DeletionPolicyRetain:
+			if targetsErr != nil {
+				r.markAsFailed(log, errSyncTargets, targetsErr, &externalSecret, syncCallsError.With(resourceLabels))
+				return ctrl.Result{}, targetsErr
+			}
 			r.markAsDone(&externalSecret, start, log)
+			synced = true
 			return ctrl.Result{RequeueAfter: refreshInt}, nil
 		// noop, handled below
 		case esv1beta1.DeletionPolicyMerge:
 		}
 	}
 
-	mutationFunc := func() error {
-		if externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
-			err = controllerutil.SetControllerReference(&externalSecret, &secret.ObjectMeta, r.Scheme)
-			if err != nil {
-				return fmt.Errorf(errSetCtrlReference, err)
-			}
-		}
-		if secret.Data == nil {
-			secret.Data = make(map[string][]byte)
-		}
-		// diff existing keys
-		keys, err := getManagedDataKeys(&existingSecret, externalSecret.Name)
-		if err != nil {
-			return err
-		}
-		// Sanitize data map for any updates on the ES
-		for _, key := range keys {
-			if dataMap[key] == nil {
-				secret.Data[key] = nil
-				// Sanitizing any templated / updated keys
-				delete(secret.Data, key)
-			}
-		}
-		err = r.applyTemplate(ctx, &externalSecret, secret, dataMap)
-		if err != nil {
-			return fmt.Errorf(errApplyTemplate, err)
-		}
-		if externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
-			lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", externalSecret.Namespace, externalSecret.Name))
-			secret.Labels[esv1beta1.LabelOwner] = lblValue
-		}
-
-		secret.Annotations[esv1beta1.AnnotationDataHash] = r.computeDataHashAnnotation(&existingSecret, secret)
-
-		return nil
-	}
+	mutationFunc := r.targetMutationFunc(ctx, &externalSecret, &externalSecret.Spec.Target, secret, &existingSecret, dataMap)
 
 	switch externalSecret.Spec.Target.CreationPolicy { //nolint:exhaustive
 	case esv1beta1.CreatePolicyMerge:
@@ -314,7 +372,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	if targetsErr != nil {
+		r.markAsFailed(log, errSyncTargets, targetsErr, &externalSecret, syncCallsError.With(resourceLabels))
+		return ctrl.Result{}, targetsErr
+	}
+
 	r.markAsDone(&externalSecret, start, log)
+	synced = true
 
 	return ctrl.Result{
 		RequeueAfter: refreshInt,
@@ -342,6 +406,48 @@ func (r *Reconciler) markAsFailed(log logr.Logger, msg string, err error, extern
 	counter.Inc()
 }
 
+// targetMutationFunc returns a func which renders dataMap into the given target secret.
+func (r *Reconciler) targetMutationFunc(ctx context.Context, es *esv1beta1.ExternalSecret, target *esv1beta1.ExternalSecretTarget, secret, existingSecret *v1.Secret, dataMap map[string][]byte) func() error {
+	return func() error {
+		// owner references can not point across namespaces,
+		// such targets are cleaned up through the additional-targets finalizer
+		if target.CreationPolicy == esv1beta1.CreatePolicyOwner && secret.Namespace == es.Namespace {
+			err := controllerutil.SetControllerReference(es, &secret.ObjectMeta, r.Scheme)
+			if err != nil {
+				return fmt.Errorf(errSetCtrlReference, err)
+			}
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		// diff existing keys
+		keys, err := getManagedDataKeys(existingSecret, es.Name)
+		if err != nil {
+			return err
+		}
+		// Sanitize data map for any updates on the ES
+		for _, key := range keys {
+			if dataMap[key] == nil {
+				secret.Data[key] = nil
+				// Sanitizing any templated / updated keys
+				delete(secret.Data, key)
+			}
+		}
+		err = r.applyTemplate(ctx, es, target, secret, dataMap)
+		if err != nil {
+			return fmt.Errorf(errApplyTemplate, err)
+		}
+		if target.CreationPolicy == esv1beta1.CreatePolicyOwner {
+			lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", es.Namespace, es.Name))
+			secret.Labels[esv1beta1.LabelOwner] = lblValue
+		}
+
+		secret.Annotations[esv1beta1.AnnotationDataHash] = r.computeDataHashAnnotation(existingSecret, secret)
+
+		return nil
+	}
+}
+
 func deleteOrphanedSecrets(ctx context.Context, cl client.Client, externalSecret *esv1beta1.ExternalSecret) error {
 	secretList := v1.SecretList{}
 	lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", externalSecret.Namespace, externalSecret.Name))
@@ -359,6 +465,9 @@ func deleteOrphanedSecrets(ctx context.Context, cl client.Client, externalSecret
 		return err
 	}
 	for key, secret := range secretList.Items {
+		if isAdditionalTarget(externalSecret, &secretList.Items[key]) {
+			continue
+		}
 		if externalSecret.Spec.Target.Name != "" && secret.Name != externalSecret.Spec.Target.Name {
 			err = cl.Delete(ctx, &secretList.Items[key])
 			if err != nil {
@@ -645,7 +754,26 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options)
 		return err
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Index .Spec.AdditionalTargets to reconcile ExternalSecrets when secrets in other namespaces have changed
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &esv1beta1.ExternalSecret{}, externalSecretAdditionalTargetsKey, func(obj client.Object) []string {
+		es := obj.(*esv1beta1.ExternalSecret)
+
+		keys := make([]string, 0, len(es.Spec.AdditionalTargets))
+		for i := range es.Spec.AdditionalTargets {
+			target := &es.Spec.AdditionalTargets[i]
+			keys = append(keys, types.NamespacedName{Namespace: additionalTargetNamespace(es, target), Name: target.Name}.String())
+		}
+		return keys
+	}); err != nil {
+		return err
+	}
+
+	// Index .Spec.SecretStoreRef to reconcile ExternalSecrets when the defaults of their store have changed
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &esv1beta1.ExternalSecret{}, externalSecretStoreRefKey, indexStoreRef); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
 		For(&esv1beta1.ExternalSecret{}).
 		// Cannot use Owns since the controller does not set owner reference when creation policy is not Owner
@@ -655,7 +783,19 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options)
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 			builder.OnlyMetadata,
 		).
-		Complete(r)
+		Watches(
+			&esv1beta1.SecretStore{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForStore),
+			builder.WithPredicates(storeDefaultsChangedPredicate()),
+		)
+	if r.ClusterSecretStoreEnabled {
+		b = b.Watches(
+			&esv1beta1.ClusterSecretStore{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForStore),
+			builder.WithPredicates(storeDefaultsChangedPredicate()),
+		)
+	}
+	return b.Complete(r)
 }
 
 func (r *Reconciler) findObjectsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
@@ -670,14 +810,29 @@ func (r *Reconciler) findObjectsForSecret(ctx context.Context, secret client.Obj
 		return []reconcile.Request{}
 	}
 
-	requests := make([]reconcile.Request, len(externalSecrets.Items))
-	for i := range externalSecrets.Items {
-		requests[i] = reconcile.Request{
-			NamespacedName: types.NamespacedName{
-				Name:      externalSecrets.Items[i].GetName(),
-				Namespace: externalSecrets.Items[i].GetNamespace(),
-			},
+	var targetExternalSecrets esv1beta1.ExternalSecretList
+	err = r.List(
+		ctx,
+		&targetExternalSecrets,
+		client.MatchingFields{externalSecretAdditionalTargetsKey: client.ObjectKeyFromObject(secret).String()},
+	)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	items := append(externalSecrets.Items, targetExternalSecrets.Items...)
+	requests := make([]reconcile.Request, 0, len(items))
+	seen := make(map[types.NamespacedName]struct{}, len(items))
+	for i := range items {
+		key := types.NamespacedName{
+			Name:      items[i].GetName(),
+			Namespace: items[i].GetNamespace(),
+		}
+		if _, ok := seen[key]; ok {
+			continue
 		}
+		seen[key] = struct{}{}
+		requests = append(requests, reconcile.Request{NamespacedName: key})
 	}
 	return requests
 }
@@ -0,0 +1,212 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// defaultRefreshInterval is used if neither the ExternalSecret nor its store set a refresh interval.
+const defaultRefreshInterval = time.Hour
+
+// The API server used to persist these built-in defaults in the spec of every ExternalSecret.
+// ExternalSecrets annotated with AnnotationInheritStoreDefaults treat them as unset where the store
+// sets a default, all other ExternalSecrets keep them.
+const (
+	legacyRefreshInterval    = time.Hour
+	legacyConversionStrategy = esv1beta1.ExternalSecretConversionDefault
+	legacyDecodingStrategy   = esv1beta1.ExternalSecretDecodeNone
+	legacyDeletionPolicy     = esv1beta1.DeletionPolicyRetain
+)
+
+// applyStoreDefaults fills the fields the ExternalSecret leaves unset from the defaults of the store
+// referenced in spec.secretStoreRef and the built-in defaults.
+// The spec is only changed in memory, it must not be written back to the API server.
+func (r *Reconciler) applyStoreDefaults(ctx context.Context, es *esv1beta1.ExternalSecret) (*esv1beta1.ExternalSecretEffectiveSettings, error) {
+	var (
+		defaults  esv1beta1.SecretStoreDefaults
+		effective esv1beta1.ExternalSecretEffectiveSettings
+	)
+	if ref := es.Spec.SecretStoreRef; ref.Name != "" {
+		store, err := esv1beta1.LookupStore(ctx, r.Client, ref, es.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if store != nil && store.GetSpec().Defaults != nil {
+			defaults = *store.GetSpec().Defaults
+			effective.DefaultsFrom = store.GetKind() + "/" + store.GetName()
+		}
+	}
+	applyDefaults(es, &defaults, &effective)
+	return &effective, nil
+}
+
+// applyDefaults fills the unset fields of the ExternalSecret from defaults
+// and records the resulting values in effective.
+func applyDefaults(es *esv1beta1.ExternalSecret, defaults *esv1beta1.SecretStoreDefaults, effective *esv1beta1.ExternalSecretEffectiveSettings) {
+	inherit := es.Annotations[esv1beta1.AnnotationInheritStoreDefaults] == "true"
+	if inherit && defaults.RefreshInterval != nil && es.Spec.RefreshInterval != nil && es.Spec.RefreshInterval.Duration == legacyRefreshInterval {
+		es.Spec.RefreshInterval = nil
+	}
+	if es.Spec.RefreshInterval == nil {
+		es.Spec.RefreshInterval = &metav1.Duration{Duration: defaultRefreshInterval}
+		if defaults.RefreshInterval != nil {
+			es.Spec.RefreshInterval = defaults.RefreshInterval.DeepCopy()
+		}
+	}
+	effective.RefreshInterval = es.Spec.RefreshInterval.DeepCopy()
+
+	effective.ConversionStrategy = defaults.ConversionStrategy
+	if effective.ConversionStrategy == "" {
+		effective.ConversionStrategy = esv1beta1.ExternalSecretConversionDefault
+	}
+	effective.DecodingStrategy = defaults.DecodingStrategy
+	if effective.DecodingStrategy == "" {
+		effective.DecodingStrategy = esv1beta1.ExternalSecretDecodeNone
+	}
+	setStrategies := func(conversion *esv1beta1.ExternalSecretConversionStrategy, decoding *esv1beta1.ExternalSecretDecodingStrategy) {
+		if *conversion == "" || (inherit && defaults.ConversionStrategy != "" && *conversion == legacyConversionStrategy) {
+			*conversion = effective.ConversionStrategy
+		}
+		if *decoding == "" || (inherit && defaults.DecodingStrategy != "" && *decoding == legacyDecodingStrategy) {
+			*decoding = effective.DecodingStrategy
+		}
+	}
+	for i := range es.Spec.Data {
+		ref := &es.Spec.Data[i].RemoteRef
+		setStrategies(&ref.ConversionStrategy, &ref.DecodingStrategy)
+	}
+	for i := range es.Spec.DataFrom {
+		if ref := es.Spec.DataFrom[i].Extract; ref != nil {
+			setStrategies(&ref.ConversionStrategy, &ref.DecodingStrategy)
+		}
+		if ref := es.Spec.DataFrom[i].Find; ref != nil {
+			setStrategies(&ref.ConversionStrategy, &ref.DecodingStrategy)
+		}
+	}
+
+	effective.DeletionPolicy = setDeletionPolicy(&es.Spec.Target, defaults.DeletionPolicy, inherit)
+	for i := range es.Spec.AdditionalTargets {
+		setDeletionPolicy(&es.Spec.AdditionalTargets[i].ExternalSecretTarget, defaults.DeletionPolicy, inherit)
+	}
+
+	if defaults.TemplateMetadata != nil {
+		effective.TemplateMetadata = defaults.TemplateMetadata.DeepCopy()
+	}
+}
+
+// setDeletionPolicy sets the deletion policy of the target to policy if it is unset,
+// or equal to the former built-in default and inherit is set, and policy is allowed
+// with the creation policy of the target, otherwise to Retain.
+func setDeletionPolicy(target *esv1beta1.ExternalSecretTarget, policy esv1beta1.ExternalSecretDeletionPolicy, inherit bool) esv1beta1.ExternalSecretDeletionPolicy {
+	if target.DeletionPolicy != "" && (!inherit || policy == "" || target.DeletionPolicy != legacyDeletionPolicy) {
+		return target.DeletionPolicy
+	}
+	target.DeletionPolicy = esv1beta1.DeletionPolicyRetain
+	switch policy {
+	case esv1beta1.DeletionPolicyDelete:
+		if target.CreationPolicy != esv1beta1.CreatePolicyMerge && target.CreationPolicy != esv1beta1.CreatePolicyNone {
+			target.DeletionPolicy = policy
+		}
+	case esv1beta1.DeletionPolicyMerge:
+		if target.CreationPolicy != esv1beta1.CreatePolicyNone {
+			target.DeletionPolicy = policy
+		}
+	case esv1beta1.DeletionPolicyRetain:
+	}
+	return target.DeletionPolicy
+}
+
+// storeRefKey is the index key of an ExternalSecret which references the store in spec.secretStoreRef.
+func storeRefKey(kind, name string) string {
+	if kind == "" {
+		kind = esv1beta1.SecretStoreKind
+	}
+	return kind + "/" + name
+}
+
+// indexStoreRef indexes the ExternalSecret by the store it takes its defaults from.
+func indexStoreRef(obj client.Object) []string {
+	es := obj.(*esv1beta1.ExternalSecret)
+	if ref := es.Spec.SecretStoreRef; ref.Name != "" {
+		return []string{storeRefKey(ref.Kind, ref.Name)}
+	}
+	return nil
+}
+
+// findObjectsForStore returns the ExternalSecrets which take their defaults from the store.
+func (r *Reconciler) findObjectsForStore(ctx context.Context, store client.Object) []reconcile.Request {
+	opts := []client.ListOption{}
+	switch store.(type) {
+	case *esv1beta1.SecretStore:
+		opts = append(opts, client.InNamespace(store.GetNamespace()), client.MatchingFields{externalSecretStoreRefKey: storeRefKey(esv1beta1.SecretStoreKind, store.GetName())})
+	case *esv1beta1.ClusterSecretStore:
+		opts = append(opts, client.MatchingFields{externalSecretStoreRefKey: storeRefKey(esv1beta1.ClusterSecretStoreKind, store.GetName())})
+	default:
+		return nil
+	}
+
+	var externalSecrets esv1beta1.ExternalSecretList
+	if err := r.List(ctx, &externalSecrets, opts...); err != nil {
+		r.Log.Error(err, errGetES)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(externalSecrets.Items))
+	for i := range externalSecrets.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      externalSecrets.Items[i].Name,
+			Namespace: externalSecrets.Items[i].Namespace,
+		}})
+	}
+	return requests
+}
+
+// storeDefaultsChangedPredicate passes the stores whose defaults have changed.
+// Other changes of the store are picked up by the ExternalSecrets with their next refresh.
+func storeDefaultsChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			store, ok := e.Object.(esv1beta1.GenericStore)
+			return ok && store.GetSpec().Defaults != nil
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldStore, okOld := e.ObjectOld.(esv1beta1.GenericStore)
+			newStore, okNew := e.ObjectNew.(esv1beta1.GenericStore)
+			if !okOld || !okNew {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldStore.GetSpec().Defaults, newStore.GetSpec().Defaults)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			store, ok := e.Object.(esv1beta1.GenericStore)
+			return ok && store.GetSpec().Defaults != nil
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
@@ -0,0 +1,335 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestApplyStoreDefaults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+	store := &esv1beta1.ClusterSecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Defaults: &esv1beta1.SecretStoreDefaults{
+				RefreshInterval:    &metav1.Duration{Duration: 15 * time.Minute},
+				ConversionStrategy: esv1beta1.ExternalSecretConversionUnicode,
+				DecodingStrategy:   esv1beta1.ExternalSecretDecodeBase64,
+				DeletionPolicy:     esv1beta1.DeletionPolicyDelete,
+				TemplateMetadata: &esv1beta1.ExternalSecretTemplateMetadata{
+					Labels: map[string]string{"team": "platform"},
+				},
+			},
+		},
+	}
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(store).Build()}
+
+	tests := []struct {
+		name          string
+		es            *esv1beta1.ExternalSecret
+		wantEffective *esv1beta1.ExternalSecretEffectiveSettings
+		wantSpec      esv1beta1.ExternalSecretSpec
+	}{
+		{
+			name: "store defaults fill unset fields",
+			es: &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "foo"},
+				Spec: esv1beta1.ExternalSecretSpec{
+					SecretStoreRef: esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind},
+					Target:         esv1beta1.ExternalSecretTarget{CreationPolicy: esv1beta1.CreatePolicyOwner},
+					Data: []esv1beta1.ExternalSecretData{
+						{SecretKey: "a", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "a"}},
+						{SecretKey: "b", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "b", DecodingStrategy: esv1beta1.ExternalSecretDecodeAuto}},
+					},
+				},
+			},
+			wantEffective: &esv1beta1.ExternalSecretEffectiveSettings{
+				DefaultsFrom:       "ClusterSecretStore/vault",
+				RefreshInterval:    &metav1.Duration{Duration: 15 * time.Minute},
+				DeletionPolicy:     esv1beta1.DeletionPolicyDelete,
+				ConversionStrategy: esv1beta1.ExternalSecretConversionUnicode,
+				DecodingStrategy:   esv1beta1.ExternalSecretDecodeBase64,
+				TemplateMetadata: &esv1beta1.ExternalSecretTemplateMetadata{
+					Labels: map[string]string{"team": "platform"},
+				},
+			},
+			wantSpec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind},
+				RefreshInterval: &metav1.Duration{Duration: 15 * time.Minute},
+				Target: esv1beta1.ExternalSecretTarget{
+					CreationPolicy: esv1beta1.CreatePolicyOwner,
+					DeletionPolicy: esv1beta1.DeletionPolicyDelete,
+				},
+				Data: []esv1beta1.ExternalSecretData{
+					{SecretKey: "a", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:                "a",
+						ConversionStrategy: esv1beta1.ExternalSecretConversionUnicode,
+						DecodingStrategy:   esv1beta1.ExternalSecretDecodeBase64,
+					}},
+					{SecretKey: "b", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:                "b",
+						ConversionStrategy: esv1beta1.ExternalSecretConversionUnicode,
+						DecodingStrategy:   esv1beta1.ExternalSecretDecodeAuto,
+					}},
+				},
+			},
+		},
+		{
+			name: "fields set in the ExternalSecret take precedence",
+			es: &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "foo"},
+				Spec: esv1beta1.ExternalSecretSpec{
+					SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind},
+					RefreshInterval: &metav1.Duration{Duration: time.Minute},
+					Target: esv1beta1.ExternalSecretTarget{
+						CreationPolicy: esv1beta1.CreatePolicyMerge,
+					},
+				},
+			},
+			wantEffective: &esv1beta1.ExternalSecretEffectiveSettings{
+				DefaultsFrom:       "ClusterSecretStore/vault",
+				RefreshInterval:    &metav1.Duration{Duration: time.Minute},
+				DeletionPolicy:     esv1beta1.DeletionPolicyRetain,
+				ConversionStrategy: esv1beta1.ExternalSecretConversionUnicode,
+				DecodingStrategy:   esv1beta1.ExternalSecretDecodeBase64,
+				TemplateMetadata: &esv1beta1.ExternalSecretTemplateMetadata{
+					Labels: map[string]string{"team": "platform"},
+				},
+			},
+			wantSpec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind},
+				RefreshInterval: &metav1.Duration{Duration: time.Minute},
+				Target: esv1beta1.ExternalSecretTarget{
+					CreationPolicy: esv1beta1.CreatePolicyMerge,
+					DeletionPolicy: esv1beta1.DeletionPolicyRetain,
+				},
+			},
+		},
+		{
+			name: "persisted built-in defaults take precedence without the annotation",
+			es: &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "foo"},
+				Spec: esv1beta1.ExternalSecretSpec{
+					SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind},
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+					Target: esv1beta1.ExternalSecretTarget{
+						CreationPolicy: esv1beta1.CreatePolicyOwner,
+						DeletionPolicy: esv1beta1.DeletionPolicyRetain,
+					},
+					Data: []esv1beta1.ExternalSecretData{
+						{SecretKey: "a", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{
+							Key:                "a",
+							ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+							DecodingStrategy:   esv1beta1.ExternalSecretDecodeNone,
+						}},
+					},
+				},
+			},
+			wantEffective: &esv1beta1.ExternalSecretEffectiveSettings{
+				DefaultsFrom:       "ClusterSecretStore/vault",
+				RefreshInterval:    &metav1.Duration{Duration: time.Hour},
+				DeletionPolicy:     esv1beta1.DeletionPolicyRetain,
+				ConversionStrategy: esv1beta1.ExternalSecretConversionUnicode,
+				DecodingStrategy:   esv1beta1.ExternalSecretDecodeBase64,
+				TemplateMetadata: &esv1beta1.ExternalSecretTemplateMetadata{
+					Labels: map[string]string{"team": "platform"},
+				},
+			},
+			wantSpec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind},
+				RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				Target: esv1beta1.ExternalSecretTarget{
+					CreationPolicy: esv1beta1.CreatePolicyOwner,
+					DeletionPolicy: esv1beta1.DeletionPolicyRetain,
+				},
+				Data: []esv1beta1.ExternalSecretData{
+					{SecretKey: "a", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:                "a",
+						ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+						DecodingStrategy:   esv1beta1.ExternalSecretDecodeNone,
+					}},
+				},
+			},
+		},
+		{
+			name: "store defaults replace persisted built-in defaults with the annotation",
+			es: &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "es",
+					Namespace:   "foo",
+					Annotations: map[string]string{esv1beta1.AnnotationInheritStoreDefaults: "true"},
+				},
+				Spec: esv1beta1.ExternalSecretSpec{
+					SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind},
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+					Target: esv1beta1.ExternalSecretTarget{
+						CreationPolicy: esv1beta1.CreatePolicyOwner,
+						DeletionPolicy: esv1beta1.DeletionPolicyRetain,
+					},
+					Data: []esv1beta1.ExternalSecretData{
+						{SecretKey: "a", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{
+							Key:                "a",
+							ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+							DecodingStrategy:   esv1beta1.ExternalSecretDecodeNone,
+						}},
+					},
+				},
+			},
+			wantEffective: &esv1beta1.ExternalSecretEffectiveSettings{
+				DefaultsFrom:       "ClusterSecretStore/vault",
+				RefreshInterval:    &metav1.Duration{Duration: 15 * time.Minute},
+				DeletionPolicy:     esv1beta1.DeletionPolicyDelete,
+				ConversionStrategy: esv1beta1.ExternalSecretConversionUnicode,
+				DecodingStrategy:   esv1beta1.ExternalSecretDecodeBase64,
+				TemplateMetadata: &esv1beta1.ExternalSecretTemplateMetadata{
+					Labels: map[string]string{"team": "platform"},
+				},
+			},
+			wantSpec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind},
+				RefreshInterval: &metav1.Duration{Duration: 15 * time.Minute},
+				Target: esv1beta1.ExternalSecretTarget{
+					CreationPolicy: esv1beta1.CreatePolicyOwner,
+					DeletionPolicy: esv1beta1.DeletionPolicyDelete,
+				},
+				Data: []esv1beta1.ExternalSecretData{
+					{SecretKey: "a", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:                "a",
+						ConversionStrategy: esv1beta1.ExternalSecretConversionUnicode,
+						DecodingStrategy:   esv1beta1.ExternalSecretDecodeBase64,
+					}},
+				},
+			},
+		},
+		{
+			name: "built-in defaults without store defaults",
+			es: &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "foo"},
+				Spec: esv1beta1.ExternalSecretSpec{
+					SecretStoreRef: esv1beta1.SecretStoreRef{Name: "missing", Kind: esv1beta1.SecretStoreKind},
+					DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+						{Find: &esv1beta1.ExternalSecretFind{}},
+					},
+				},
+			},
+			wantEffective: &esv1beta1.ExternalSecretEffectiveSettings{
+				RefreshInterval:    &metav1.Duration{Duration: time.Hour},
+				DeletionPolicy:     esv1beta1.DeletionPolicyRetain,
+				ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+				DecodingStrategy:   esv1beta1.ExternalSecretDecodeNone,
+			},
+			wantSpec: esv1beta1.ExternalSecretSpec{
+				SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "missing", Kind: esv1beta1.SecretStoreKind},
+				RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				Target:          esv1beta1.ExternalSecretTarget{DeletionPolicy: esv1beta1.DeletionPolicyRetain},
+				DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+					{Find: &esv1beta1.ExternalSecretFind{
+						ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+						DecodingStrategy:   esv1beta1.ExternalSecretDecodeNone,
+					}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			effective, err := r.applyStoreDefaults(context.Background(), tt.es)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantEffective, effective); diff != "" {
+				t.Errorf("unexpected effective settings (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantSpec, tt.es.Spec); diff != "" {
+				t.Errorf("unexpected spec (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindObjectsForStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+	makeES := func(namespace, name string, ref esv1beta1.SecretStoreRef) *esv1beta1.ExternalSecret {
+		return &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       esv1beta1.ExternalSecretSpec{SecretStoreRef: ref},
+		}
+	}
+	kube := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&esv1beta1.ExternalSecret{}, externalSecretStoreRefKey, indexStoreRef).
+		WithObjects(
+			makeES("foo", "implicit-kind", esv1beta1.SecretStoreRef{Name: "vault"}),
+			makeES("foo", "store", esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.SecretStoreKind}),
+			makeES("bar", "other-namespace", esv1beta1.SecretStoreRef{Name: "vault"}),
+			makeES("bar", "cluster-store", esv1beta1.SecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind}),
+		).
+		Build()
+	r := &Reconciler{Client: kube}
+
+	names := func(requests []reconcile.Request) []string {
+		var out []string
+		for _, req := range requests {
+			out = append(out, req.String())
+		}
+		sort.Strings(out)
+		return out
+	}
+	store := &esv1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "foo"}}
+	if diff := cmp.Diff([]string{"foo/implicit-kind", "foo/store"}, names(r.findObjectsForStore(context.Background(), store))); diff != "" {
+		t.Errorf("unexpected requests for SecretStore (-want +got):\n%s", diff)
+	}
+	clusterStore := &esv1beta1.ClusterSecretStore{ObjectMeta: metav1.ObjectMeta{Name: "vault"}}
+	if diff := cmp.Diff([]string{"bar/cluster-store"}, names(r.findObjectsForStore(context.Background(), clusterStore))); diff != "" {
+		t.Errorf("unexpected requests for ClusterSecretStore (-want +got):\n%s", diff)
+	}
+}
+
+func TestStoreDefaultsChangedPredicate(t *testing.T) {
+	withDefaults := &esv1beta1.SecretStore{Spec: esv1beta1.SecretStoreSpec{
+		Defaults: &esv1beta1.SecretStoreDefaults{DeletionPolicy: esv1beta1.DeletionPolicyDelete},
+	}}
+	withoutDefaults := &esv1beta1.SecretStore{}
+	changedStatus := withDefaults.DeepCopy()
+	changedStatus.Status.Conditions = []esv1beta1.SecretStoreStatusCondition{{Type: esv1beta1.SecretStoreReady}}
+
+	p := storeDefaultsChangedPredicate()
+	if !p.Update(event.UpdateEvent{ObjectOld: withoutDefaults, ObjectNew: withDefaults}) {
+		t.Error("expected an update of the defaults to pass")
+	}
+	if p.Update(event.UpdateEvent{ObjectOld: withDefaults, ObjectNew: changedStatus}) {
+		t.Error("expected an update without changed defaults to be filtered")
+	}
+	if !p.Create(event.CreateEvent{Object: withDefaults}) || p.Create(event.CreateEvent{Object: withoutDefaults}) {
+		t.Error("expected only stores with defaults to pass on create")
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+const (
+	// leaseMinRequeue is the lower bound of the requeue interval for leases which are due.
+	leaseMinRequeue = time.Second
+	// leaseRevocationTimeout bounds the time the deletion of an ExternalSecret waits for its leases to be revoked.
+	leaseRevocationTimeout = time.Hour
+)
+
+// newGeneratorLease returns the status of a lease issued by the generator.
+func newGeneratorLease(ref esv1beta1.GeneratorRef, lease *genv1alpha1.Lease, now time.Time) esv1beta1.GeneratorLease {
+	gl := esv1beta1.GeneratorLease{
+		GeneratorRef: ref,
+		ID:           lease.ID,
+		Renewable:    lease.Renewable,
+		RenewTime:    metav1.NewTime(now),
+	}
+	if lease.Duration > 0 {
+		expiration := metav1.NewTime(now.Add(lease.Duration))
+		gl.ExpirationTime = &expiration
+	}
+	return gl
+}
+
+// leaseDue returns the time the lease must be renewed, or the secret rotated, which is
+// two thirds into its lifetime. It returns false for leases which do not expire.
+func leaseDue(lease *esv1beta1.GeneratorLease) (time.Time, bool) {
+	if lease.Superseded || lease.ExpirationTime == nil {
+		return time.Time{}, false
+	}
+	ttl := lease.ExpirationTime.Sub(lease.RenewTime.Time)
+	return lease.RenewTime.Add(ttl * 2 / 3), true
+}
+
+// leaseRequeue returns the refresh interval shortened to the next lease which is due.
+// Leases drive the refresh even if the refresh interval is 0.
+func leaseRequeue(leases []esv1beta1.GeneratorLease, refreshInt time.Duration, now time.Time) time.Duration {
+	for i := range leases {
+		due, ok := leaseDue(&leases[i])
+		if !ok {
+			continue
+		}
+		next := due.Sub(now)
+		if next < leaseMinRequeue {
+			next = leaseMinRequeue
+		}
+		if refreshInt <= 0 || next < refreshInt {
+			refreshInt = next
+		}
+	}
+	return refreshInt
+}
+
+// getLeasingGenerator returns the generator which issued the lease.
+func (r *Reconciler) getLeasingGenerator(ctx context.Context, namespace string, lease *esv1beta1.GeneratorLease) (genv1alpha1.LeasingGenerator, *apiextensions.JSON, error) {
+	genDef, err := r.getGeneratorDefinition(ctx, namespace, &lease.GeneratorRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	gen, err := genv1alpha1.GetGenerator(genDef)
+	if err != nil {
+		return nil, nil, err
+	}
+	leasing, ok := gen.(genv1alpha1.LeasingGenerator)
+	if !ok {
+		return nil, nil, fmt.Errorf("generator %s does not support leases", lease.GeneratorRef.Kind)
+	}
+	return leasing, genDef, nil
+}
+
+// revokeLease revokes the lease. Leases of deleted generators can not be revoked anymore, they are dropped.
+func (r *Reconciler) revokeLease(ctx context.Context, namespace string, lease *esv1beta1.GeneratorLease) error {
+	gen, genDef, err := r.getLeasingGenerator(ctx, namespace, lease)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf(errRevokeLease, lease.ID, err)
+	}
+	if err := gen.RevokeLease(ctx, genDef, r.Client, namespace, lease.ID); err != nil {
+		return fmt.Errorf(errRevokeLease, lease.ID, err)
+	}
+	return nil
+}
+
+// renewLeases renews the leases which are due and retries the revocation of superseded leases.
+// It returns true if the secrets must be generated again, because a lease can not be renewed
+// or is about to reach its max TTL.
+func (r *Reconciler) renewLeases(ctx context.Context, log logr.Logger, es *esv1beta1.ExternalSecret) (bool, error) {
+	if len(es.Status.Leases) == 0 {
+		return false, nil
+	}
+	base := es.DeepCopy()
+	now := time.Now()
+	rotate := false
+	leases := make([]esv1beta1.GeneratorLease, 0, len(es.Status.Leases))
+	for _, lease := range es.Status.Leases {
+		if lease.Superseded {
+			if err := r.revokeLease(ctx, es.Namespace, &lease); err != nil {
+				log.Error(err, "could not revoke superseded lease")
+				leases = append(leases, lease)
+			}
+			continue
+		}
+		due, ok := leaseDue(&lease)
+		if !ok || now.Before(due) {
+			leases = append(leases, lease)
+			continue
+		}
+		if !lease.Renewable {
+			rotate = true
+			leases = append(leases, lease)
+			continue
+		}
+		renewed, err := r.renewLease(ctx, es.Namespace, &lease)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			log.Error(err, "could not renew lease, generating new secrets")
+			rotate = true
+			leases = append(leases, lease)
+			continue
+		}
+		// Vault caps the lease at its max TTL, the secrets must be rotated before they expire
+		prevTTL := lease.ExpirationTime.Sub(lease.RenewTime.Time)
+		if renewed.Duration < prevTTL/2 {
+			rotate = true
+		}
+		leases = append(leases, newGeneratorLease(lease.GeneratorRef, renewed, now))
+	}
+	if equality.Semantic.DeepEqual(es.Status.Leases, leases) {
+		return rotate, nil
+	}
+	es.Status.Leases = leases
+	if err := r.Status().Patch(ctx, es, client.MergeFrom(base)); err != nil {
+		return false, err
+	}
+	return rotate, nil
+}
+
+func (r *Reconciler) renewLease(ctx context.Context, namespace string, lease *esv1beta1.GeneratorLease) (*genv1alpha1.Lease, error) {
+	gen, genDef, err := r.getLeasingGenerator(ctx, namespace, lease)
+	if err != nil {
+		return nil, err
+	}
+	renewed, err := gen.RenewLease(ctx, genDef, r.Client, namespace, lease.ID)
+	if err != nil {
+		return nil, fmt.Errorf(errRenewLease, lease.ID, err)
+	}
+	return renewed, nil
+}
+
+// rotateLeases revokes the leases of the secrets which are no longer used and returns the leases to keep.
+// If the sync succeeded the previous leases are superseded, otherwise the new ones are.
+// Leases which fail to be revoked are kept as superseded, their revocation is retried on the next reconcile.
+func (r *Reconciler) rotateLeases(ctx context.Context, log logr.Logger, es *esv1beta1.ExternalSecret, leases []esv1beta1.GeneratorLease, synced bool) []esv1beta1.GeneratorLease {
+	superseded, kept := leases, es.Status.Leases
+	if synced {
+		superseded, kept = es.Status.Leases, leases
+	}
+	for _, lease := range superseded {
+		if err := r.revokeLease(ctx, es.Namespace, &lease); err != nil {
+			log.Error(err, "could not revoke superseded lease")
+			lease.Superseded = true
+			kept = append(kept, lease)
+		}
+	}
+	return kept
+}
+
+// addLeasesFinalizer adds the finalizer which revokes the leases once the ExternalSecret is deleted.
+// The ExternalSecret is patched through a copy, its spec is defaulted in memory and must be preserved.
+func (r *Reconciler) addLeasesFinalizer(ctx context.Context, es *esv1beta1.ExternalSecret) error {
+	if controllerutil.ContainsFinalizer(es, esv1beta1.FinalizerGeneratorLeases) {
+		return nil
+	}
+	patched := es.DeepCopy()
+	controllerutil.AddFinalizer(patched, esv1beta1.FinalizerGeneratorLeases)
+	if err := r.Patch(ctx, patched, client.MergeFrom(es)); err != nil {
+		return err
+	}
+	es.Finalizers = patched.Finalizers
+	return nil
+}
+
+// finalizeLeases revokes all leases of the ExternalSecret and removes the finalizer.
+// Expired leases are not revoked anymore. The revocation is given up if the ExternalSecret is annotated
+// to skip it or if it still fails leaseRevocationTimeout after the deletion, e.g. because the generator's
+// backend is unreachable, so the deletion is not blocked forever.
+func (r *Reconciler) finalizeLeases(ctx context.Context, log logr.Logger, es *esv1beta1.ExternalSecret) error {
+	if !controllerutil.ContainsFinalizer(es, esv1beta1.FinalizerGeneratorLeases) {
+		return nil
+	}
+	now := time.Now()
+	if es.Annotations[esv1beta1.AnnotationSkipLeaseRevocation] == "true" {
+		log.Info("skipping the revocation of leases", "leases", len(es.Status.Leases))
+	} else {
+		for i := range es.Status.Leases {
+			lease := &es.Status.Leases[i]
+			if lease.ExpirationTime != nil && lease.ExpirationTime.Time.Before(now) {
+				continue
+			}
+			err := r.revokeLease(ctx, es.Namespace, lease)
+			if err == nil {
+				continue
+			}
+			if es.DeletionTimestamp == nil || now.Sub(es.DeletionTimestamp.Time) < leaseRevocationTimeout {
+				return err
+			}
+			log.Error(err, "giving up to revoke lease")
+			r.recorder.Event(es, v1.EventTypeWarning, esv1beta1.ReasonLeaseRevocationSkipped,
+				fmt.Sprintf("lease %s was not revoked within %v: %v", lease.ID, leaseRevocationTimeout, err))
+		}
+	}
+
+	controllerutil.RemoveFinalizer(es, esv1beta1.FinalizerGeneratorLeases)
+	return r.Update(ctx, es)
+}
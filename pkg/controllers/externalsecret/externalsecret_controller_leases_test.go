@@ -0,0 +1,220 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+func TestNewGeneratorLease(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ref := esv1beta1.GeneratorRef{APIVersion: "generators.external-secrets.io/v1alpha1", Kind: "VaultDynamicSecret", Name: "db"}
+
+	got := newGeneratorLease(ref, &genv1alpha1.Lease{ID: "database/creds/app/abc", Duration: time.Hour, Renewable: true}, now)
+	expiration := metav1.NewTime(now.Add(time.Hour))
+	want := esv1beta1.GeneratorLease{
+		GeneratorRef:   ref,
+		ID:             "database/creds/app/abc",
+		Renewable:      true,
+		RenewTime:      metav1.NewTime(now),
+		ExpirationTime: &expiration,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected lease (-want +got):\n%s", diff)
+	}
+
+	got = newGeneratorLease(ref, &genv1alpha1.Lease{ID: "database/creds/app/abc"}, now)
+	if got.ExpirationTime != nil {
+		t.Errorf("leases without duration must not expire, got %v", got.ExpirationTime)
+	}
+}
+
+func TestLeaseRequeue(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lease := func(renewed, ttl time.Duration, superseded bool) esv1beta1.GeneratorLease {
+		expiration := metav1.NewTime(now.Add(renewed + ttl))
+		return esv1beta1.GeneratorLease{
+			ID:             "lease",
+			RenewTime:      metav1.NewTime(now.Add(renewed)),
+			ExpirationTime: &expiration,
+			Superseded:     superseded,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		leases     []esv1beta1.GeneratorLease
+		refreshInt time.Duration
+		want       time.Duration
+	}{
+		{
+			name:       "no leases",
+			refreshInt: time.Hour,
+			want:       time.Hour,
+		},
+		{
+			name:       "lease due before refresh",
+			leases:     []esv1beta1.GeneratorLease{lease(0, 30*time.Minute, false)},
+			refreshInt: time.Hour,
+			want:       20 * time.Minute,
+		},
+		{
+			name:       "refresh before lease due",
+			leases:     []esv1beta1.GeneratorLease{lease(0, 3*time.Hour, false)},
+			refreshInt: time.Hour,
+			want:       time.Hour,
+		},
+		{
+			name:       "leases drive the refresh without refresh interval",
+			leases:     []esv1beta1.GeneratorLease{lease(0, 3*time.Hour, false), lease(-30*time.Minute, 3*time.Hour, false)},
+			refreshInt: 0,
+			want:       90 * time.Minute,
+		},
+		{
+			name:       "overdue lease",
+			leases:     []esv1beta1.GeneratorLease{lease(-time.Hour, 30*time.Minute, false)},
+			refreshInt: time.Hour,
+			want:       leaseMinRequeue,
+		},
+		{
+			name:       "superseded lease",
+			leases:     []esv1beta1.GeneratorLease{lease(0, time.Minute, true)},
+			refreshInt: time.Hour,
+			want:       time.Hour,
+		},
+		{
+			name:       "lease without expiration",
+			leases:     []esv1beta1.GeneratorLease{{ID: "lease", RenewTime: metav1.NewTime(now)}},
+			refreshInt: time.Hour,
+			want:       time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := leaseRequeue(tt.leases, tt.refreshInt, now); got != tt.want {
+				t.Errorf("leaseRequeue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinalizeLeases(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = esv1beta1.AddToScheme(scheme)
+	// the generators can not be looked up, so revoking a lease fails
+	server := httptest.NewServer(nil)
+	server.Close()
+	now := time.Now()
+	expiration := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(d))
+		return &t
+	}
+	lease := func(exp *metav1.Time) esv1beta1.GeneratorLease {
+		return esv1beta1.GeneratorLease{
+			GeneratorRef:   esv1beta1.GeneratorRef{APIVersion: "generators.external-secrets.io/v1alpha1", Kind: "VaultDynamicSecret", Name: "db"},
+			ID:             "database/creds/app/abc",
+			RenewTime:      metav1.NewTime(now.Add(-time.Hour)),
+			ExpirationTime: exp,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		deleted     time.Duration
+		annotations map[string]string
+		leases      []esv1beta1.GeneratorLease
+		wantErr     bool
+	}{
+		{
+			name:    "revocation fails",
+			deleted: time.Minute,
+			leases:  []esv1beta1.GeneratorLease{lease(expiration(time.Hour))},
+			wantErr: true,
+		},
+		{
+			name:    "revocation fails beyond the timeout",
+			deleted: leaseRevocationTimeout + time.Minute,
+			leases:  []esv1beta1.GeneratorLease{lease(expiration(time.Hour))},
+		},
+		{
+			name:        "revocation is skipped",
+			deleted:     time.Minute,
+			annotations: map[string]string{esv1beta1.AnnotationSkipLeaseRevocation: "true"},
+			leases:      []esv1beta1.GeneratorLease{lease(expiration(time.Hour))},
+		},
+		{
+			name:    "expired leases are not revoked",
+			deleted: time.Minute,
+			leases:  []esv1beta1.GeneratorLease{lease(expiration(-time.Minute))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletion := metav1.NewTime(now.Add(-tt.deleted))
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "es",
+					Namespace:         "foo",
+					Annotations:       tt.annotations,
+					Finalizers:        []string{esv1beta1.FinalizerGeneratorLeases},
+					DeletionTimestamp: &deletion,
+				},
+				Status: esv1beta1.ExternalSecretStatus{Leases: tt.leases},
+			}
+			r := &Reconciler{
+				Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(es).Build(),
+				RestConfig: &rest.Config{Host: server.URL},
+				recorder:   record.NewFakeRecorder(10),
+			}
+			var current esv1beta1.ExternalSecret
+			if err := r.Get(context.Background(), client.ObjectKeyFromObject(es), &current); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err := r.finalizeLeases(context.Background(), logr.Discard(), &current)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if err := r.Get(context.Background(), client.ObjectKeyFromObject(es), &current); err != nil {
+					t.Fatalf("the ExternalSecret must not be deleted: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := r.Get(context.Background(), client.ObjectKeyFromObject(es), &current); !apierrors.IsNotFound(err) {
+				t.Errorf("the ExternalSecret must be deleted once the finalizer is removed, got %v", err)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -30,6 +31,7 @@ import (
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
 	// Loading registered providers.
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	"github.com/external-secrets/external-secrets/pkg/utils"
@@ -39,8 +41,9 @@ import (
 	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
 )
 
-// getProviderSecretData returns the provider's secret data with the provided ExternalSecret.
-func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *esv1beta1.ExternalSecret) (map[string][]byte, error) {
+// getProviderSecretData returns the provider's secret data with the provided ExternalSecret
+// and the leases of the generated secrets. The leases are returned on error as well, so they can be revoked.
+func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *esv1beta1.ExternalSecret) (map[string][]byte, []esv1beta1.GeneratorLease, error) {
 	// We MUST NOT create multiple instances of a provider client (mostly due to limitations with GCP)
 	// Clientmanager keeps track of the client instances
 	// that are created during the fetching process and closes clients
@@ -49,8 +52,10 @@ func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *
 	defer mgr.Close(ctx)
 
 	providerData := make(map[string][]byte)
+	var leases []esv1beta1.GeneratorLease
 	for i, remoteRef := range externalSecret.Spec.DataFrom {
 		var secretMap map[string][]byte
+		var lease *esv1beta1.GeneratorLease
 		var err error
 
 		if remoteRef.Find != nil {
@@ -58,7 +63,10 @@ func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *
 		} else if remoteRef.Extract != nil {
 			secretMap, err = r.handleExtractSecrets(ctx, externalSecret, remoteRef, mgr, i)
 		} else if remoteRef.SourceRef != nil && remoteRef.SourceRef.GeneratorRef != nil {
-			secretMap, err = r.handleGenerateSecrets(ctx, externalSecret.Namespace, remoteRef, i)
+			secretMap, lease, err = r.handleGenerateSecrets(ctx, externalSecret.Namespace, remoteRef, i)
+		}
+		if lease != nil {
+			leases = append(leases, *lease)
 		}
 		if errors.Is(err, esv1beta1.NoSecretErr) && externalSecret.Spec.Target.DeletionPolicy != esv1beta1.DeletionPolicyRetain {
 			r.recorder.Event(
@@ -70,7 +78,7 @@ func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *
 			continue
 		}
 		if err != nil {
-			return nil, err
+			return nil, leases, err
 		}
 		providerData = utils.MergeByteMap(providerData, secretMap)
 	}
@@ -82,11 +90,11 @@ func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *
 			continue
 		}
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving secret at .data[%d], key: %s, err: %w", i, secretRef.RemoteRef.Key, err)
+			return nil, leases, fmt.Errorf("error retrieving secret at .data[%d], key: %s, err: %w", i, secretRef.RemoteRef.Key, err)
 		}
 	}
 
-	return providerData, nil
+	return providerData, leases, nil
 }
 
 func (r *Reconciler) handleSecretData(ctx context.Context, i int, externalSecret esv1beta1.ExternalSecret, secretRef esv1beta1.ExternalSecretData, providerData map[string][]byte, cmgr *secretstore.Manager) error {
@@ -115,27 +123,44 @@ func toStoreGenSourceRef(ref *esv1beta1.StoreSourceRef) *esv1beta1.StoreGenerato
 	}
 }
 
-func (r *Reconciler) handleGenerateSecrets(ctx context.Context, namespace string, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, i int) (map[string][]byte, error) {
+// handleGenerateSecrets generates the secrets and returns the lease of them, if the generator issued one.
+func (r *Reconciler) handleGenerateSecrets(ctx context.Context, namespace string, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, i int) (map[string][]byte, *esv1beta1.GeneratorLease, error) {
 	genDef, err := r.getGeneratorDefinition(ctx, namespace, remoteRef.SourceRef.GeneratorRef)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	gen, err := genv1alpha1.GetGenerator(genDef)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	start := time.Now()
+	var secretMap map[string][]byte
+	var genLease *genv1alpha1.Lease
+	if leasing, ok := gen.(genv1alpha1.LeasingGenerator); ok {
+		secretMap, genLease, err = leasing.GenerateWithLease(ctx, genDef, r.Client, namespace)
+	} else {
+		secretMap, err = gen.Generate(ctx, genDef, r.Client, namespace)
 	}
-	secretMap, err := gen.Generate(ctx, genDef, r.Client, namespace)
+	audit.Observe(ctx, audit.Record{
+		Operation: audit.OperationGenerate,
+		Generator: &audit.ObjectRef{Kind: remoteRef.SourceRef.GeneratorRef.Kind, Namespace: namespace, Name: remoteRef.SourceRef.GeneratorRef.Name},
+	}, start, err)
 	if err != nil {
-		return nil, fmt.Errorf(errGenerate, i, err)
+		return nil, nil, fmt.Errorf(errGenerate, i, err)
+	}
+	var lease *esv1beta1.GeneratorLease
+	if genLease != nil {
+		gl := newGeneratorLease(*remoteRef.SourceRef.GeneratorRef, genLease, start)
+		lease = &gl
 	}
 	secretMap, err = utils.RewriteMap(remoteRef.Rewrite, secretMap)
 	if err != nil {
-		return nil, fmt.Errorf(errRewrite, i, err)
+		return nil, lease, fmt.Errorf(errRewrite, i, err)
 	}
 	if !utils.ValidateKeys(secretMap) {
-		return nil, fmt.Errorf(errInvalidKeys, "generator", i)
+		return nil, lease, fmt.Errorf(errInvalidKeys, "generator", i)
 	}
-	return secretMap, err
+	return secretMap, lease, err
 }
 
 // getGeneratorDefinition returns the generator JSON for a given sourceRef
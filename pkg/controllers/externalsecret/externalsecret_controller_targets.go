@@ -0,0 +1,270 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errSyncTarget             = "could not sync target %s: %w"
+	errGetTargetNamespace     = "could not get target namespace %s: %w"
+	errTargetNamespaceAllowed = "namespace %s does not allow targets from namespace %s"
+)
+
+// syncAdditionalTargets renders dataMap into every secret of .spec.additionalTargets
+// and records the outcome of each target in the ExternalSecret status.
+func (r *Reconciler) syncAdditionalTargets(ctx context.Context, log logr.Logger, es *esv1beta1.ExternalSecret, dataMap map[string][]byte) error {
+	if len(es.Spec.AdditionalTargets) == 0 {
+		es.Status.AdditionalTargets = nil
+		return nil
+	}
+
+	var errs error
+	statuses := make([]esv1beta1.ExternalSecretTargetStatus, 0, len(es.Spec.AdditionalTargets))
+	for i := range es.Spec.AdditionalTargets {
+		target := &es.Spec.AdditionalTargets[i]
+		key := types.NamespacedName{Namespace: additionalTargetNamespace(es, target), Name: target.Name}
+		status := esv1beta1.ExternalSecretTargetStatus{
+			Name:               key.Name,
+			Namespace:          key.Namespace,
+			Status:             v1.ConditionTrue,
+			Reason:             esv1beta1.ConditionReasonSecretSynced,
+			Message:            "Secret was synced",
+			LastTransitionTime: metav1.Now(),
+		}
+
+		deleted, err := r.syncAdditionalTarget(ctx, es, target, key, dataMap)
+		switch {
+		case err != nil:
+			log.Error(err, "could not sync additional target", "target", key)
+			status.Status = v1.ConditionFalse
+			status.Reason = esv1beta1.ConditionReasonSecretSyncedError
+			status.Message = err.Error()
+			errs = errors.Join(errs, fmt.Errorf(errSyncTarget, key, err))
+		case deleted:
+			status.Reason = esv1beta1.ConditionReasonSecretDeleted
+			status.Message = "secret deleted due to DeletionPolicy"
+		}
+
+		// Do not update lastTransitionTime if the status of the target doesn't change.
+		if current := getTargetStatus(es.Status.AdditionalTargets, key); current != nil && current.Status == status.Status {
+			status.LastTransitionTime = current.LastTransitionTime
+		}
+		statuses = append(statuses, status)
+	}
+	es.Status.AdditionalTargets = statuses
+	return errs
+}
+
+// syncAdditionalTarget creates, updates or deletes a single additional target.
+// It returns true if the target secret was deleted due to its DeletionPolicy.
+func (r *Reconciler) syncAdditionalTarget(ctx context.Context, es *esv1beta1.ExternalSecret, target *esv1beta1.ExternalSecretAdditionalTarget, key types.NamespacedName, dataMap map[string][]byte) (bool, error) {
+	if err := r.checkTargetNamespace(ctx, es.Namespace, key.Namespace); err != nil {
+		return false, err
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Immutable: &target.Immutable,
+		Data:      make(map[string][]byte),
+	}
+
+	var existingSecret v1.Secret
+	err := r.Get(ctx, key, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf(errGetExistingSecret, err)
+	}
+
+	// if no data was found we can delete the secret if needed.
+	if len(dataMap) == 0 {
+		switch target.DeletionPolicy {
+		case esv1beta1.DeletionPolicyDelete:
+			// safeguard that we only can delete secrets we own
+			if target.CreationPolicy != esv1beta1.CreatePolicyOwner {
+				return false, fmt.Errorf(errInvalidCreatePolicy, target.CreationPolicy)
+			}
+			if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+				return false, err
+			}
+			return true, nil
+		case esv1beta1.DeletionPolicyRetain:
+			return false, nil
+		case esv1beta1.DeletionPolicyMerge:
+		}
+	}
+
+	mutationFunc := r.targetMutationFunc(ctx, es, &target.ExternalSecretTarget, secret, &existingSecret, dataMap)
+	switch target.CreationPolicy { //nolint:exhaustive
+	case esv1beta1.CreatePolicyMerge:
+		return false, r.patchSecret(ctx, secret, mutationFunc, es)
+	case esv1beta1.CreatePolicyNone:
+		return false, nil
+	default:
+		_, err = r.createOrUpdateSecret(ctx, secret, mutationFunc, es)
+		return false, err
+	}
+}
+
+// checkTargetNamespace verifies that the target namespace allows
+// ExternalSecrets from the source namespace to manage secrets in it.
+func (r *Reconciler) checkTargetNamespace(ctx context.Context, sourceNamespace, targetNamespace string) error {
+	if sourceNamespace == targetNamespace {
+		return nil
+	}
+
+	var ns v1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: targetNamespace}, &ns); err != nil {
+		return fmt.Errorf(errGetTargetNamespace, targetNamespace, err)
+	}
+	for _, allowed := range strings.Split(ns.Annotations[esv1beta1.AnnotationAllowedSourceNamespaces], ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || allowed == sourceNamespace {
+			return nil
+		}
+	}
+	return fmt.Errorf(errTargetNamespaceAllowed, targetNamespace, sourceNamespace)
+}
+
+// areAdditionalTargetsValid checks if all additional target secrets exist and are consistent with their hash.
+func (r *Reconciler) areAdditionalTargetsValid(ctx context.Context, es *esv1beta1.ExternalSecret) (bool, error) {
+	for i := range es.Spec.AdditionalTargets {
+		target := &es.Spec.AdditionalTargets[i]
+		var existingSecret v1.Secret
+		err := r.Get(ctx, types.NamespacedName{Namespace: additionalTargetNamespace(es, target), Name: target.Name}, &existingSecret)
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if !isSecretValid(existingSecret) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// finalizeAdditionalTargets deletes owned additional targets outside of the ExternalSecret namespace
+// and removes the finalizer. Targets within the same namespace are garbage collected through their owner reference.
+func (r *Reconciler) finalizeAdditionalTargets(ctx context.Context, es *esv1beta1.ExternalSecret) error {
+	if !controllerutil.ContainsFinalizer(es, esv1beta1.FinalizerAdditionalTargets) {
+		return nil
+	}
+
+	secretList := v1.SecretList{}
+	lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", es.Namespace, es.Name))
+	err := r.List(ctx, &secretList, client.MatchingLabels{esv1beta1.LabelOwner: lblValue})
+	if err != nil {
+		return err
+	}
+	for i := range secretList.Items {
+		if secretList.Items[i].Namespace == es.Namespace {
+			continue
+		}
+		if err := r.Delete(ctx, &secretList.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(es, esv1beta1.FinalizerAdditionalTargets)
+	return r.Update(ctx, es)
+}
+
+// deleteOrphanedTargets deletes owned secrets in other namespaces which are no longer additional targets,
+// for example after a target was removed from .spec.additionalTargets. The finalizer is removed
+// once the ExternalSecret has no targets in other namespaces left.
+func (r *Reconciler) deleteOrphanedTargets(ctx context.Context, es *esv1beta1.ExternalSecret) error {
+	if !controllerutil.ContainsFinalizer(es, esv1beta1.FinalizerAdditionalTargets) {
+		return nil
+	}
+
+	secretList := v1.SecretList{}
+	lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", es.Namespace, es.Name))
+	err := r.List(ctx, &secretList, client.MatchingLabels{esv1beta1.LabelOwner: lblValue})
+	if err != nil {
+		return err
+	}
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if secret.Namespace == es.Namespace || isAdditionalTarget(es, secret) {
+			continue
+		}
+		if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if needsAdditionalTargetsFinalizer(es) {
+		return nil
+	}
+	controllerutil.RemoveFinalizer(es, esv1beta1.FinalizerAdditionalTargets)
+	return r.Update(ctx, es)
+}
+
+// needsAdditionalTargetsFinalizer returns true if the ExternalSecret owns targets in other namespaces.
+func needsAdditionalTargetsFinalizer(es *esv1beta1.ExternalSecret) bool {
+	for i := range es.Spec.AdditionalTargets {
+		target := &es.Spec.AdditionalTargets[i]
+		if target.CreationPolicy == esv1beta1.CreatePolicyOwner && additionalTargetNamespace(es, target) != es.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// isAdditionalTarget returns true if the secret is one of the ExternalSecret's additional targets.
+func isAdditionalTarget(es *esv1beta1.ExternalSecret, secret *v1.Secret) bool {
+	for i := range es.Spec.AdditionalTargets {
+		target := &es.Spec.AdditionalTargets[i]
+		if target.Name == secret.Name && additionalTargetNamespace(es, target) == secret.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func additionalTargetNamespace(es *esv1beta1.ExternalSecret, target *esv1beta1.ExternalSecretAdditionalTarget) string {
+	if target.Namespace != "" {
+		return target.Namespace
+	}
+	return es.Namespace
+}
+
+func getTargetStatus(statuses []esv1beta1.ExternalSecretTargetStatus, key types.NamespacedName) *esv1beta1.ExternalSecretTargetStatus {
+	for i := range statuses {
+		if statuses[i].Name == key.Name && statuses[i].Namespace == key.Namespace {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+func TestDeleteOrphanedTargets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+
+	target := func(namespace string) esv1beta1.ExternalSecretAdditionalTarget {
+		return esv1beta1.ExternalSecretAdditionalTarget{
+			ExternalSecretTarget: esv1beta1.ExternalSecretTarget{Name: "copy", CreationPolicy: esv1beta1.CreatePolicyOwner},
+			Namespace:            namespace,
+		}
+	}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "es",
+			Namespace:  "default",
+			Finalizers: []string{esv1beta1.FinalizerAdditionalTargets},
+		},
+		Spec: esv1beta1.ExternalSecretSpec{AdditionalTargets: []esv1beta1.ExternalSecretAdditionalTarget{target("team-a")}},
+	}
+	ownerLabel := map[string]string{esv1beta1.LabelOwner: utils.ObjectHash("default/es")}
+	secret := func(namespace string) *v1.Secret {
+		return &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "copy", Namespace: namespace, Labels: ownerLabel}}
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(es, secret("default"), secret("team-a"), secret("team-b")).Build()
+	r := &Reconciler{Client: kube}
+	ctx := context.Background()
+	exists := func(namespace string) bool {
+		err := kube.Get(ctx, client.ObjectKey{Name: "copy", Namespace: namespace}, &v1.Secret{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	// the target removed from the spec is deleted, the remaining target keeps the finalizer
+	if err := r.deleteOrphanedTargets(ctx, es); err != nil {
+		t.Fatal(err)
+	}
+	if !exists("default") || !exists("team-a") || exists("team-b") {
+		t.Errorf("only the orphaned target in team-b must be deleted")
+	}
+	if len(es.Finalizers) != 1 {
+		t.Errorf("finalizer must be kept while targets in other namespaces exist")
+	}
+
+	// without targets in other namespaces the finalizer is removed
+	es.Spec.AdditionalTargets = []esv1beta1.ExternalSecretAdditionalTarget{target("")}
+	if err := r.deleteOrphanedTargets(ctx, es); err != nil {
+		t.Fatal(err)
+	}
+	if !exists("default") || exists("team-a") {
+		t.Errorf("the target in team-a must be deleted")
+	}
+	stored := &esv1beta1.ExternalSecret{}
+	if err := kube.Get(ctx, client.ObjectKeyFromObject(es), stored); err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Finalizers) != 0 {
+		t.Errorf("finalizer must be removed, got %v", stored.Finalizers)
+	}
+}
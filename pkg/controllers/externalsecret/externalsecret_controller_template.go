@@ -32,23 +32,23 @@ import (
 // * template.Data (highest precedence)
 // * template.templateFrom
 // * secret via es.data or es.dataFrom.
-func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret, dataMap map[string][]byte) error {
-	if err := setMetadata(secret, es); err != nil {
+func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSecret, target *esv1beta1.ExternalSecretTarget, secret *v1.Secret, dataMap map[string][]byte) error {
+	if err := setMetadata(secret, es, target); err != nil {
 		return err
 	}
 
 	// no template: copy data and return
-	if es.Spec.Target.Template == nil {
+	if target.Template == nil {
 		secret.Data = dataMap
 		return nil
 	}
 	// Merge Policy should merge secrets
-	if es.Spec.Target.Template.MergePolicy == esv1beta1.MergePolicyMerge {
+	if target.Template.MergePolicy == esv1beta1.MergePolicyMerge {
 		for k, v := range dataMap {
 			secret.Data[k] = v
 		}
 	}
-	execute, err := template.EngineForVersion(es.Spec.Target.Template.EngineVersion)
+	execute, err := template.EngineForVersion(target.Template.EngineVersion)
 	if err != nil {
 		return err
 	}
@@ -60,36 +60,36 @@ func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSe
 		Exec:         execute,
 	}
 	// apply templates defined in template.templateFrom
-	err = p.MergeTemplateFrom(ctx, es.Namespace, es.Spec.Target.Template)
+	err = p.MergeTemplateFrom(ctx, es.Namespace, target.Template)
 	if err != nil {
 		return fmt.Errorf(errFetchTplFrom, err)
 	}
 	// explicitly defined template.Data takes precedence over templateFrom
-	err = p.MergeMap(es.Spec.Target.Template.Data, esv1beta1.TemplateTargetData)
+	err = p.MergeMap(target.Template.Data, esv1beta1.TemplateTargetData)
 	if err != nil {
 		return fmt.Errorf(errExecTpl, err)
 	}
 
 	// get template data for labels
-	err = p.MergeMap(es.Spec.Target.Template.Metadata.Labels, esv1beta1.TemplateTargetLabels)
+	err = p.MergeMap(target.Template.Metadata.Labels, esv1beta1.TemplateTargetLabels)
 	if err != nil {
 		return fmt.Errorf(errExecTpl, err)
 	}
 	// get template data for annotations
-	err = p.MergeMap(es.Spec.Target.Template.Metadata.Annotations, esv1beta1.TemplateTargetAnnotations)
+	err = p.MergeMap(target.Template.Metadata.Annotations, esv1beta1.TemplateTargetAnnotations)
 	if err != nil {
 		return fmt.Errorf(errExecTpl, err)
 	}
 	// if no data was provided by template fallback
 	// to value from the provider
-	if len(es.Spec.Target.Template.Data) == 0 && len(es.Spec.Target.Template.TemplateFrom) == 0 {
+	if len(target.Template.Data) == 0 && len(target.Template.TemplateFrom) == 0 {
 		secret.Data = dataMap
 	}
 	return nil
 }
 
 // setMetadata sets Labels and Annotations to the given secret.
-func setMetadata(secret *v1.Secret, es *esv1beta1.ExternalSecret) error {
+func setMetadata(secret *v1.Secret, es *esv1beta1.ExternalSecret, target *esv1beta1.ExternalSecretTarget) error {
 	if secret.Labels == nil {
 		secret.Labels = make(map[string]string)
 	}
@@ -114,14 +114,20 @@ func setMetadata(secret *v1.Secret, es *esv1beta1.ExternalSecret) error {
 		delete(secret.ObjectMeta.Annotations, key)
 	}
 
-	if es.Spec.Target.Template == nil {
+	// labels and annotations from the store defaults have the lowest precedence
+	if effective := es.Status.Effective; effective != nil && effective.TemplateMetadata != nil {
+		utils.MergeStringMap(secret.ObjectMeta.Labels, effective.TemplateMetadata.Labels)
+		utils.MergeStringMap(secret.ObjectMeta.Annotations, effective.TemplateMetadata.Annotations)
+	}
+
+	if target.Template == nil {
 		utils.MergeStringMap(secret.ObjectMeta.Labels, es.ObjectMeta.Labels)
 		utils.MergeStringMap(secret.ObjectMeta.Annotations, es.ObjectMeta.Annotations)
 		return nil
 	}
 
-	secret.Type = es.Spec.Target.Template.Type
-	utils.MergeStringMap(secret.ObjectMeta.Labels, es.Spec.Target.Template.Metadata.Labels)
-	utils.MergeStringMap(secret.ObjectMeta.Annotations, es.Spec.Target.Template.Metadata.Annotations)
+	secret.Type = target.Template.Type
+	utils.MergeStringMap(secret.ObjectMeta.Labels, target.Template.Metadata.Labels)
+	utils.MergeStringMap(secret.ObjectMeta.Annotations, target.Template.Metadata.Annotations)
 	return nil
 }
@@ -665,6 +665,113 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// additional targets are rendered from the same provider data
+	// into the ExternalSecret namespace and into namespaces which allow it
+	syncAdditionalTargets := func(tc *testCase) {
+		const secretVal = "someValue"
+		const copySecretName = "test-secret-copy"
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+
+		otherNamespace := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "ctrl-test-targets",
+				Annotations: map[string]string{
+					esv1beta1.AnnotationAllowedSourceNamespaces: ExternalSecretNamespace,
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), otherNamespace)).To(Succeed())
+
+		tc.externalSecret.Spec.AdditionalTargets = []esv1beta1.ExternalSecretAdditionalTarget{
+			{
+				ExternalSecretTarget: esv1beta1.ExternalSecretTarget{
+					Name:           copySecretName,
+					CreationPolicy: esv1beta1.CreatePolicyOwner,
+					Template: &esv1beta1.ExternalSecretTemplate{
+						EngineVersion: esv1beta1.TemplateEngineV2,
+						Data: map[string]string{
+							"copy": "{{ .targetProperty }}",
+						},
+					},
+				},
+			},
+			{
+				ExternalSecretTarget: esv1beta1.ExternalSecretTarget{
+					Name:           copySecretName,
+					CreationPolicy: esv1beta1.CreatePolicyOwner,
+				},
+				Namespace: otherNamespace.Name,
+			},
+		}
+
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(secretVal))
+
+			copySecret := &v1.Secret{}
+			Eventually(func() error {
+				return k8sClient.Get(context.Background(), types.NamespacedName{Name: copySecretName, Namespace: ExternalSecretNamespace}, copySecret)
+			}, timeout, interval).Should(Succeed())
+			Expect(string(copySecret.Data["copy"])).To(Equal(secretVal))
+			Expect(ctest.HasOwnerRef(copySecret.ObjectMeta, "ExternalSecret", ExternalSecretName)).To(BeTrue())
+
+			otherSecret := &v1.Secret{}
+			Eventually(func() error {
+				return k8sClient.Get(context.Background(), types.NamespacedName{Name: copySecretName, Namespace: otherNamespace.Name}, otherSecret)
+			}, timeout, interval).Should(Succeed())
+			Expect(string(otherSecret.Data[targetProp])).To(Equal(secretVal))
+			Expect(otherSecret.OwnerReferences).To(BeEmpty())
+			Expect(otherSecret.Labels).To(HaveKey(esv1beta1.LabelOwner))
+
+			Expect(es.Finalizers).To(ContainElement(esv1beta1.FinalizerAdditionalTargets))
+			Expect(es.Status.AdditionalTargets).To(HaveLen(2))
+			for _, status := range es.Status.AdditionalTargets {
+				Expect(status.Status).To(Equal(v1.ConditionTrue))
+			}
+
+			// deleting the ExternalSecret must clean up targets in other namespaces
+			Expect(k8sClient.Delete(context.Background(), es)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), types.NamespacedName{Name: copySecretName, Namespace: otherNamespace.Name}, otherSecret)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// additional targets must not be written into namespaces which do not allow it
+	additionalTargetNamespaceNotAllowed := func(tc *testCase) {
+		const secretVal = "someValue"
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+
+		otherNamespace, err := ctest.CreateNamespace("targets", k8sClient)
+		Expect(err).ToNot(HaveOccurred())
+
+		tc.externalSecret.Spec.AdditionalTargets = []esv1beta1.ExternalSecretAdditionalTarget{
+			{
+				ExternalSecretTarget: esv1beta1.ExternalSecretTarget{
+					Name:           "test-secret-copy",
+					CreationPolicy: esv1beta1.CreatePolicyOrphan,
+				},
+				Namespace: otherNamespace,
+			},
+		}
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != esv1beta1.ConditionReasonSecretSyncedError {
+				return false
+			}
+			return true
+		}
+		tc.checkExternalSecret = func(es *esv1beta1.ExternalSecret) {
+			Expect(es.Status.AdditionalTargets).To(HaveLen(1))
+			Expect(es.Status.AdditionalTargets[0].Status).To(Equal(v1.ConditionFalse))
+			Expect(es.Status.AdditionalTargets[0].Message).To(ContainSubstring("does not allow targets"))
+		}
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			// the primary target is synced regardless
+			Expect(string(secret.Data[targetProp])).To(Equal(secretVal))
+		}
+	}
+
 	ignoreMismatchControllerForGeneratorRef := func(tc *testCase) {
 		const secretKey = "somekey"
 		const secretVal = "someValue"
@@ -2265,6 +2372,8 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		Entry("should not update unchanged secret using creationPolicy=Merge", mergeWithSecretNoChange),
 		Entry("should not delete pre-existing secret with creationPolicy=Orphan", createSecretPolicyOrphan),
 		Entry("should sync with generatorRef", syncWithGeneratorRef),
+		Entry("should sync additional targets", syncAdditionalTargets),
+		Entry("should not sync additional targets into namespaces which do not allow it", additionalTargetNamespaceNotAllowed),
 		Entry("should not process generatorRef with mismatching controller field", ignoreMismatchControllerForGeneratorRef),
 		Entry("should sync with multiple secret stores via sourceRef", syncWithMultipleSecretStores),
 		Entry("should sync with template", syncWithTemplate),
@@ -0,0 +1,153 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fanout implements the namespace selection shared by the cluster scoped
+// controllers which create a namespaced resource in every selected namespace.
+package fanout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	errConvertLabelSelector = "failed to convert label selector %s: %w"
+	errListNamespaces       = "failed to list namespaces by label selector %s: %w"
+)
+
+// Selection selects namespaces by name or by label selector.
+type Selection struct {
+	Names     []string
+	Selectors []*metav1.LabelSelector
+}
+
+// Namespaces lists the selected namespaces. Namespaces matched by several selectors are returned once.
+func (s Selection) Namespaces(ctx context.Context, c client.Reader) ([]v1.Namespace, error) {
+	selectors := make([]*metav1.LabelSelector, 0, len(s.Names)+len(s.Selectors))
+	for _, ns := range s.Names {
+		selectors = append(selectors, &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"kubernetes.io/metadata.name": ns,
+			},
+		})
+	}
+	selectors = append(selectors, s.Selectors...)
+
+	var namespaces []v1.Namespace
+	namespaceSet := make(map[string]struct{})
+	for _, selector := range selectors {
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, fmt.Errorf(errConvertLabelSelector, selector, err)
+		}
+
+		var nl v1.NamespaceList
+		err = c.List(ctx, &nl, &client.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			return nil, fmt.Errorf(errListNamespaces, selector, err)
+		}
+
+		for _, n := range nl.Items {
+			if _, exist := namespaceSet[n.Name]; exist {
+				continue
+			}
+			namespaceSet[n.Name] = struct{}{}
+			namespaces = append(namespaces, n)
+		}
+	}
+
+	return namespaces, nil
+}
+
+// Selects returns true if the namespace is selected. Selectors which can not be converted are skipped
+// and their errors are returned together with the result of the remaining selectors.
+func (s Selection) Selects(namespace client.Object) (bool, error) {
+	if slices.Contains(s.Names, namespace.GetName()) {
+		return true, nil
+	}
+	var errs []error
+	for _, selector := range s.Selectors {
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(errConvertLabelSelector, selector, err))
+			continue
+		}
+		if labelSelector.Matches(labels.Set(namespace.GetLabels())) {
+			return true, nil
+		}
+	}
+	return false, errors.Join(errs...)
+}
+
+// RemovedNamespaces returns the provisioned namespaces which are no longer selected.
+func RemovedNamespaces(current []v1.Namespace, provisioned []string) []string {
+	currentSet := map[string]struct{}{}
+	for _, ns := range current {
+		currentSet[ns.Name] = struct{}{}
+	}
+
+	var removed []string
+	for _, ns := range provisioned {
+		if _, ok := currentSet[ns]; !ok {
+			removed = append(removed, ns)
+		}
+	}
+
+	return removed
+}
+
+// NamespaceFailures converts the failed namespaces to status entries sorted by namespace.
+func NamespaceFailures[T any](failed map[string]error, newFailure func(namespace, reason string) T) []T {
+	namespaces := make([]string, 0, len(failed))
+	for namespace := range failed {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	failures := make([]T, 0, len(failed))
+	for _, namespace := range namespaces {
+		failures = append(failures, newFailure(namespace, failed[namespace].Error()))
+	}
+	return failures
+}
+
+// NamespacePredicate passes namespace creations, deletions and label changes,
+// which are the events that can change the selected namespaces.
+func NamespacePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return true
+		},
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func makeNamespace(name string, lbls map[string]string) *v1.Namespace {
+	l := map[string]string{"kubernetes.io/metadata.name": name}
+	for k, v := range lbls {
+		l[k] = v
+	}
+	return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: l}}
+}
+
+func TestSelectionNamespaces(t *testing.T) {
+	kube := fakeclient.NewClientBuilder().WithObjects(
+		makeNamespace("a", map[string]string{"team": "x"}),
+		makeNamespace("b", map[string]string{"team": "x"}),
+		makeNamespace("c", nil),
+	).Build()
+
+	tests := []struct {
+		name      string
+		selection Selection
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name: "selects by name and label without duplicates",
+			selection: Selection{
+				Names:     []string{"a", "c"},
+				Selectors: []*metav1.LabelSelector{{MatchLabels: map[string]string{"team": "x"}}},
+			},
+			want: []string{"a", "c", "b"},
+		},
+		{
+			name:      "selects nothing without names or selectors",
+			selection: Selection{},
+		},
+		{
+			name: "fails on an invalid selector",
+			selection: Selection{
+				Selectors: []*metav1.LabelSelector{{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "bad"}}}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespaces, err := tt.selection.Namespaces(context.Background(), kube)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var got []string
+			for _, ns := range namespaces {
+				got = append(got, ns.Name)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSelectionSelects(t *testing.T) {
+	invalid := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "bad"}}}
+	selection := Selection{
+		Names:     []string{"a"},
+		Selectors: []*metav1.LabelSelector{invalid, {MatchLabels: map[string]string{"team": "x"}}},
+	}
+
+	selected, err := selection.Selects(makeNamespace("a", nil))
+	assert.NoError(t, err)
+	assert.True(t, selected)
+
+	selected, err = selection.Selects(makeNamespace("b", map[string]string{"team": "x"}))
+	assert.NoError(t, err)
+	assert.True(t, selected)
+
+	selected, err = selection.Selects(makeNamespace("c", nil))
+	assert.Error(t, err)
+	assert.False(t, selected)
+}
+
+func TestRemovedNamespaces(t *testing.T) {
+	current := []v1.Namespace{*makeNamespace("a", nil), *makeNamespace("b", nil)}
+	assert.Equal(t, []string{"c"}, RemovedNamespaces(current, []string{"a", "c"}))
+	assert.Empty(t, RemovedNamespaces(current, []string{"b"}))
+}
+
+func TestNamespaceFailures(t *testing.T) {
+	type failure struct{ namespace, reason string }
+	got := NamespaceFailures(map[string]error{
+		"b": errors.New("denied"),
+		"a": errors.New("not found"),
+	}, func(namespace, reason string) failure {
+		return failure{namespace, reason}
+	})
+	assert.Equal(t, []failure{{"a", "not found"}, {"b", "denied"}}, got)
+}
@@ -24,8 +24,11 @@ import (
 const (
 	PushSecretSubsystem            = "pushsecret"
 	PushSecretReconcileDurationKey = "reconcile_duration"
+	PushSecretDriftDetectedKey     = "drift_detected_total"
 )
 
+var counterVecMetrics = map[string]*prometheus.CounterVec{}
+
 var gaugeVecMetrics = map[string]*prometheus.GaugeVec{}
 
 // SetUpMetrics is called at the root to set-up the metric logic using the
@@ -37,13 +40,27 @@ func SetUpMetrics() {
 		Help:      "The duration time to reconcile the Push Secret",
 	}, ctrlmetrics.NonConditionMetricLabelNames)
 
-	metrics.Registry.MustRegister(pushSecretReconcileDuration)
+	pushSecretDriftDetected := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: PushSecretSubsystem,
+		Name:      PushSecretDriftDetectedKey,
+		Help:      "Total number of remote values which drifted from the values pushed by the Push Secret",
+	}, ctrlmetrics.NonConditionMetricLabelNames)
+
+	metrics.Registry.MustRegister(pushSecretReconcileDuration, pushSecretDriftDetected)
+
+	counterVecMetrics = map[string]*prometheus.CounterVec{
+		PushSecretDriftDetectedKey: pushSecretDriftDetected,
+	}
 
 	gaugeVecMetrics = map[string]*prometheus.GaugeVec{
 		PushSecretReconcileDurationKey: pushSecretReconcileDuration,
 	}
 }
 
+func GetCounterVec(key string) *prometheus.CounterVec {
+	return counterVecMetrics[key]
+}
+
 func GetGaugeVec(key string) *prometheus.GaugeVec {
 	return gaugeVecMetrics[key]
 }
@@ -29,11 +29,15 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
@@ -49,6 +53,7 @@ const (
 	errSetSecretFailed       = "could not write remote ref %v to target secretstore %v: %v"
 	errFailedSetSecret       = "set secret failed: %v"
 	errConvert               = "could not apply conversion strategy to keys: %v"
+	errNoSelector            = "either selector.secret or selector.generatorRef must be set"
 	pushSecretFinalizer      = "pushsecret.externalsecrets.io/finalizer"
 )
 
@@ -59,18 +64,29 @@ type Reconciler struct {
 	recorder        record.EventRecorder
 	RequeueInterval time.Duration
 	ControllerClass string
+	// ClusterName is added to the ownership marker of remote secrets.
+	ClusterName string
 }
 
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.recorder = mgr.GetEventRecorderFor("pushsecret")
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&esapi.PushSecret{}).
+		For(&esapi.PushSecret{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+		)).
+		Watches(
+			&v1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findPushSecretsForSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+			builder.OnlyMetadata,
+		).
 		Complete(r)
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("pushsecret", req.NamespacedName)
+	ctx = audit.WithRequester(ctx, esapi.PushSecretKind, req.Namespace, req.Name)
 
 	resourceLabels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": req.Name, "namespace": req.Namespace})
 	start := time.Now()
@@ -98,6 +114,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	if ps.Spec.RefreshInterval != nil {
 		refreshInt = ps.Spec.RefreshInterval.Duration
 	}
+	if interval := driftCheckInterval(&ps); interval > 0 && (refreshInt <= 0 || interval < refreshInt) {
+		refreshInt = interval
+	}
 
 	p := client.MergeFrom(ps.DeepCopy())
 	defer func() {
@@ -140,7 +159,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	default:
 	}
 
-	secret, err := r.GetSecret(ctx, ps)
+	sources, err := r.resolveSources(ctx, &ps)
 	if err != nil {
 		r.markAsFailed(errFailedGetSecret, &ps, nil)
 
@@ -153,11 +172,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
-	if err := r.applyTemplate(ctx, &ps, secret); err != nil {
+	for _, src := range sources {
+		if err := r.applyTemplate(ctx, &ps, src.secret); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if err := setSelectedData(&ps, sources); err != nil {
+		r.markAsFailed(err.Error(), &ps, nil)
+
 		return ctrl.Result{}, err
 	}
 
-	syncedSecrets, err := r.PushSecretToProviders(ctx, secretStores, ps, secret, mgr)
+	state := newPushState(&ps)
+	syncedSecrets, err := r.PushSecretToProviders(ctx, secretStores, ps, sources, mgr, state)
+	ps.Status.Stores = state.storeStatuses()
 	if err != nil {
 		if errors.Is(err, locks.ErrConflict) {
 			log.Info("retry to acquire lock to update the secret later", "error", err)
@@ -167,6 +195,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		totalSecrets := mergeSecretState(syncedSecrets, ps.Status.SyncedPushSecrets)
 		msg := fmt.Sprintf(errFailedSetSecret, err)
 		r.markAsFailed(msg, &ps, totalSecrets)
+		if ps.Spec.Verification != nil {
+			ps.Status.SyncedHashes = state.drift.mergedHashes()
+		}
 
 		return ctrl.Result{}, err
 	}
@@ -182,6 +213,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	default:
 	}
 
+	ps.Status.SyncedSourceSecrets = syncedSourceSecrets(sources)
+	ps.Status.SyncedHashes = nil
+	if ps.Spec.Verification != nil {
+		ps.Status.SyncedHashes = state.drift.hashes
+	}
+	setDriftCondition(&ps, state.drift)
 	r.markAsDone(&ps, syncedSecrets)
 
 	return ctrl.Result{RequeueAfter: refreshInt}, nil
@@ -238,7 +275,7 @@ func (r *Reconciler) DeleteSecretFromProviders(ctx context.Context, ps *esapi.Pu
 		}
 		newData, ok := newMap[storeName]
 		if !ok {
-			err = r.DeleteAllSecretsFromStore(ctx, client, oldData)
+			err = r.DeleteAllSecretsFromStore(ctx, client, ps, oldData)
 			if err != nil {
 				return out, err
 			}
@@ -248,7 +285,7 @@ func (r *Reconciler) DeleteSecretFromProviders(ctx context.Context, ps *esapi.Pu
 		for oldEntry, oldRef := range oldData {
 			_, ok := newData[oldEntry]
 			if !ok {
-				err = r.DeleteSecretFromStore(ctx, client, oldRef)
+				err = r.DeleteSecretFromStore(ctx, client, ps, oldRef)
 				if err != nil {
 					return out, err
 				}
@@ -259,9 +296,9 @@ func (r *Reconciler) DeleteSecretFromProviders(ctx context.Context, ps *esapi.Pu
 	return out, nil
 }
 
-func (r *Reconciler) DeleteAllSecretsFromStore(ctx context.Context, client v1beta1.SecretsClient, data map[string]esapi.PushSecretData) error {
+func (r *Reconciler) DeleteAllSecretsFromStore(ctx context.Context, client v1beta1.SecretsClient, ps *esapi.PushSecret, data map[string]esapi.PushSecretData) error {
 	for _, v := range data {
-		err := r.DeleteSecretFromStore(ctx, client, v)
+		err := r.DeleteSecretFromStore(ctx, client, ps, v)
 		if err != nil {
 			return err
 		}
@@ -269,61 +306,115 @@ func (r *Reconciler) DeleteAllSecretsFromStore(ctx context.Context, client v1bet
 	return nil
 }
 
-func (r *Reconciler) DeleteSecretFromStore(ctx context.Context, client v1beta1.SecretsClient, data esapi.PushSecretData) error {
-	return client.DeleteSecret(ctx, data.Match.RemoteRef)
+func (r *Reconciler) DeleteSecretFromStore(ctx context.Context, client v1beta1.SecretsClient, ps *esapi.PushSecret, data esapi.PushSecretData) error {
+	return client.DeleteSecret(ctx, ownedRemoteRef{PushSecretRemoteRef: data.Match.RemoteRef, owner: r.remoteOwner(ps)})
 }
 
-func (r *Reconciler) PushSecretToProviders(ctx context.Context, stores map[esapi.PushSecretStoreRef]v1beta1.GenericStore, ps esapi.PushSecret, secret *v1.Secret, mgr *secretstore.Manager) (esapi.SyncedPushSecretsMap, error) {
+// PushSecretToProviders pushes the sources to every store. A failing store does not prevent
+// the push to the other stores, the errors of all stores are returned joined.
+func (r *Reconciler) PushSecretToProviders(ctx context.Context, stores map[esapi.PushSecretStoreRef]v1beta1.GenericStore, ps esapi.PushSecret, sources []pushSource, mgr *secretstore.Manager, state *pushState) (esapi.SyncedPushSecretsMap, error) {
 	out := make(esapi.SyncedPushSecretsMap)
+	var errs []error
 	for ref, store := range stores {
-		out, err := r.handlePushSecretDataForStore(ctx, ps, secret, out, mgr, store.GetName(), ref.Kind)
+		storeKey := fmt.Sprintf("%v/%v", ref.Kind, store.GetName())
+		_, err := r.handlePushSecretDataForStore(ctx, ps, sources, out, mgr, state, store.GetName(), ref.Kind)
+		state.storeDone(storeKey, err)
 		if err != nil {
-			return out, err
+			errs = append(errs, err)
 		}
 	}
-	return out, nil
+	return out, errors.Join(errs...)
 }
 
-func (r *Reconciler) handlePushSecretDataForStore(ctx context.Context, ps esapi.PushSecret, secret *v1.Secret, out esapi.SyncedPushSecretsMap, mgr *secretstore.Manager, storeName, refKind string) (esapi.SyncedPushSecretsMap, error) {
+func (r *Reconciler) handlePushSecretDataForStore(ctx context.Context, ps esapi.PushSecret, sources []pushSource, out esapi.SyncedPushSecretsMap, mgr *secretstore.Manager, state *pushState, storeName, refKind string) (esapi.SyncedPushSecretsMap, error) {
 	storeKey := fmt.Sprintf("%v/%v", refKind, storeName)
 	out[storeKey] = make(map[string]esapi.PushSecretData)
 	storeRef := v1beta1.SecretStoreRef{
 		Name: storeName,
 		Kind: refKind,
 	}
-	originalSecretData := secret.Data
 	secretClient, err := mgr.Get(ctx, storeRef, ps.GetNamespace(), nil)
 	if err != nil {
 		return out, fmt.Errorf("could not get secrets client for store %v: %w", storeName, err)
 	}
-	for _, data := range ps.Spec.Data {
+	for _, src := range sources {
+		if err := r.pushSourceToStore(ctx, ps, src, secretClient, out[storeKey], state, storeKey, storeName); err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+func (r *Reconciler) pushSourceToStore(ctx context.Context, ps esapi.PushSecret, src pushSource, secretClient v1beta1.SecretsClient, out map[string]esapi.PushSecretData, state *pushState, storeKey, storeName string) error {
+	secret := src.secret
+	originalSecretData := secret.Data
+	defer func() { secret.Data = originalSecretData }()
+	for _, data := range src.data {
 		secretData, err := utils.ReverseKeys(data.ConversionStrategy, originalSecretData)
 		if err != nil {
-			return nil, fmt.Errorf(errConvert, err)
+			err = fmt.Errorf(errConvert, err)
+			state.failedRef(storeKey, statusRef(data), err)
+			return err
 		}
 		secret.Data = secretData
-		key := data.GetSecretKey()
-		if !secretKeyExists(key, secret) {
-			return out, fmt.Errorf("secret key %v does not exist", key)
+		if err := r.pushDataToStore(ctx, ps, secret, data, secretClient, state, storeKey, storeName); err != nil {
+			state.failedRef(storeKey, statusRef(data), err)
+			return err
 		}
-		switch ps.Spec.UpdatePolicy {
-		case esapi.PushSecretUpdatePolicyIfNotExists:
-			exists, err := secretClient.SecretExists(ctx, data.Match.RemoteRef)
-			if err != nil {
-				return out, fmt.Errorf("could not verify if secret exists in store: %w", err)
-			} else if exists {
-				out[storeKey][statusRef(data)] = data
-				continue
-			}
-		case esapi.PushSecretUpdatePolicyReplace:
-		default:
+		out[statusRef(data)] = data
+	}
+	return nil
+}
+
+// pushDataToStore pushes a single PushSecretData to the store, unless the update policy
+// or the drift checks skip it, and records the outcome in the push state.
+func (r *Reconciler) pushDataToStore(ctx context.Context, ps esapi.PushSecret, secret *v1.Secret, data esapi.PushSecretData, secretClient v1beta1.SecretsClient, state *pushState, storeKey, storeName string) error {
+	key := data.GetSecretKey()
+	if !secretKeyExists(key, secret) {
+		return fmt.Errorf("secret key %v does not exist", key)
+	}
+	switch ps.Spec.UpdatePolicy {
+	case esapi.PushSecretUpdatePolicyIfNotExists:
+		exists, err := secretClient.SecretExists(ctx, data.Match.RemoteRef)
+		if err != nil {
+			return fmt.Errorf("could not verify if secret exists in store: %w", err)
+		} else if exists {
+			state.keepRef(storeKey, statusRef(data))
+			return nil
+		}
+	case esapi.PushSecretUpdatePolicyReplace, esapi.PushSecretUpdatePolicyAdopt:
+	default:
+	}
+	hash, err := sourceHash(secret, data)
+	if err != nil {
+		return err
+	}
+	if driftCheckInterval(&ps) > 0 {
+		push, err := r.checkDrift(ctx, &ps, secretClient, state.drift, data, storeKey, storeName, hash)
+		if err != nil {
+			return err
 		}
-		if err := secretClient.PushSecret(ctx, secret, data); err != nil {
-			return out, fmt.Errorf(errSetSecretFailed, key, storeName, err)
+		if !push {
+			state.keepRef(storeKey, statusRef(data))
+			return nil
 		}
-		out[storeKey][statusRef(data)] = data
 	}
-	return out, nil
+	ownedData := &ownedPushSecretData{
+		PushSecretData: data,
+		owner:          r.remoteOwner(&ps),
+		adopt:          ps.Spec.UpdatePolicy == esapi.PushSecretUpdatePolicyAdopt,
+	}
+	if err := secretClient.PushSecret(ctx, secret, ownedData); err != nil {
+		return fmt.Errorf(errSetSecretFailed, key, storeName, err)
+	}
+	if verifyAfterWrite(&ps) {
+		if err := verifyPushedValue(ctx, secretClient, data, storeName, hash); err != nil {
+			return err
+		}
+	}
+	state.drift.setHash(storeKey, statusRef(data), hash)
+	state.pushedRef(storeKey, statusRef(data), ownedData.version, hash)
+	return nil
 }
 
 func secretKeyExists(key string, secret *v1.Secret) bool {
@@ -331,7 +422,20 @@ func secretKeyExists(key string, secret *v1.Secret) bool {
 	return key == "" || ok
 }
 
+// resolveSecret returns the Secret to push, which is either read from the cluster
+// or produced by the generator referenced in the selector.
+func (r *Reconciler) resolveSecret(ctx context.Context, ps *esapi.PushSecret) (*v1.Secret, error) {
+	if ps.Spec.Selector.GeneratorRef != nil {
+		return r.getGeneratedSecret(ctx, ps)
+	}
+	ps.Status.GeneratorStateSecret = ""
+	return r.GetSecret(ctx, *ps)
+}
+
 func (r *Reconciler) GetSecret(ctx context.Context, ps esapi.PushSecret) (*v1.Secret, error) {
+	if ps.Spec.Selector.Secret == nil {
+		return nil, errors.New(errNoSelector)
+	}
 	secretName := types.NamespacedName{Name: ps.Spec.Selector.Secret.Name, Namespace: ps.Namespace}
 	secret := &v1.Secret{}
 	err := r.Client.Get(ctx, secretName, secret)
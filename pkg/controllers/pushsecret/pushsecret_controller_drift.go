@@ -0,0 +1,194 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errReadRemote   = "could not read remote ref %v from target secretstore %v: %w"
+	errVerifyFailed = "remote ref %v in target secretstore %v does not match the pushed value"
+	msgDrifted      = "remote ref %v in target secretstore %v drifted from the pushed value"
+	msgInSync       = "remote values match the pushed values"
+)
+
+// driftState tracks the hashes of the values pushed to the stores
+// and the remote values which drifted from them.
+type driftState struct {
+	previous map[string]map[string]string
+	hashes   map[string]map[string]string
+	drifted  []string
+}
+
+func newDriftState(ps *esapi.PushSecret) *driftState {
+	return &driftState{
+		previous: ps.Status.SyncedHashes,
+		hashes:   make(map[string]map[string]string),
+	}
+}
+
+func (s *driftState) previousHash(storeKey, ref string) (string, bool) {
+	hash, ok := s.previous[storeKey][ref]
+	return hash, ok
+}
+
+func (s *driftState) setHash(storeKey, ref, hash string) {
+	if _, ok := s.hashes[storeKey]; !ok {
+		s.hashes[storeKey] = make(map[string]string)
+	}
+	s.hashes[storeKey][ref] = hash
+}
+
+// mergedHashes returns the new hashes merged with the previous hashes
+// of the remote refs which were not pushed in this reconcile.
+func (s *driftState) mergedHashes() map[string]map[string]string {
+	out := make(map[string]map[string]string)
+	for storeKey, refs := range s.previous {
+		for ref, hash := range refs {
+			if _, ok := out[storeKey]; !ok {
+				out[storeKey] = make(map[string]string)
+			}
+			out[storeKey][ref] = hash
+		}
+	}
+	for storeKey, refs := range s.hashes {
+		for ref, hash := range refs {
+			if _, ok := out[storeKey]; !ok {
+				out[storeKey] = make(map[string]string)
+			}
+			out[storeKey][ref] = hash
+		}
+	}
+	return out
+}
+
+func verifyAfterWrite(ps *esapi.PushSecret) bool {
+	return ps.Spec.Verification != nil && ps.Spec.Verification.ReadAfterWrite
+}
+
+func driftCheckInterval(ps *esapi.PushSecret) time.Duration {
+	if ps.Spec.Verification == nil || ps.Spec.Verification.DriftCheckInterval == nil {
+		return 0
+	}
+	return ps.Spec.Verification.DriftCheckInterval.Duration
+}
+
+// sourceHash returns the hash of the value pushed for data.
+// The value is encoded with utils.PushSecretValue as in the push itself.
+func sourceHash(secret *v1.Secret, data esapi.PushSecretData) (string, error) {
+	value, err := utils.PushSecretValue(secret, data)
+	if err != nil {
+		return "", err
+	}
+	return utils.ObjectHash(value), nil
+}
+
+// remoteHash reads the value pushed for data back from the provider and returns its hash.
+// The whole Secret is read back as map if no secret key is set and encoded like the pushed value,
+// as providers return it formatted differently from the pushed value.
+func remoteHash(ctx context.Context, secretClient v1beta1.SecretsClient, data esapi.PushSecretData) (string, error) {
+	ref := v1beta1.ExternalSecretDataRemoteRef{
+		Key:      data.GetRemoteKey(),
+		Property: data.GetProperty(),
+	}
+	if data.GetSecretKey() == "" {
+		secretMap, err := secretClient.GetSecretMap(ctx, ref)
+		if err != nil {
+			return "", err
+		}
+		value, err := utils.SecretDataToJSON(secretMap)
+		if err != nil {
+			return "", err
+		}
+		return utils.ObjectHash(value), nil
+	}
+	value, err := secretClient.GetSecret(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	return utils.ObjectHash(value), nil
+}
+
+// verifyPushedValue reads the pushed value back from the provider and compares it with the hash of the pushed value.
+func verifyPushedValue(ctx context.Context, secretClient v1beta1.SecretsClient, data esapi.PushSecretData, storeName, hash string) error {
+	remote, err := remoteHash(ctx, secretClient, data)
+	if err != nil {
+		return fmt.Errorf(errReadRemote, statusRef(data), storeName, err)
+	}
+	if remote != hash {
+		return fmt.Errorf(errVerifyFailed, statusRef(data), storeName)
+	}
+	return nil
+}
+
+// checkDrift compares the remote value with the value last pushed, if the source value did not change since.
+// It returns true if the value has to be pushed.
+func (r *Reconciler) checkDrift(ctx context.Context, ps *esapi.PushSecret, secretClient v1beta1.SecretsClient, drift *driftState, data esapi.PushSecretData, storeKey, storeName, hash string) (bool, error) {
+	ref := statusRef(data)
+	previous, ok := drift.previousHash(storeKey, ref)
+	if !ok || previous != hash {
+		return true, nil
+	}
+	remote, err := remoteHash(ctx, secretClient, data)
+	if err != nil && !errors.Is(err, v1beta1.NoSecretError{}) {
+		return false, fmt.Errorf(errReadRemote, ref, storeName, err)
+	}
+	if err == nil && remote == hash {
+		drift.setHash(storeKey, ref, hash)
+		return false, nil
+	}
+
+	drift.drifted = append(drift.drifted, storeKey+"/"+ref)
+	r.recorder.Event(ps, v1.EventTypeWarning, esapi.ReasonDrifted, fmt.Sprintf(msgDrifted, ref, storeName))
+	resourceLabels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": ps.Name, "namespace": ps.Namespace})
+	psmetrics.GetCounterVec(psmetrics.PushSecretDriftDetectedKey).With(resourceLabels).Inc()
+
+	if ps.Spec.Verification.DriftPolicy == esapi.PushSecretDriftPolicyReport {
+		drift.setHash(storeKey, ref, previous)
+		return false, nil
+	}
+	return true, nil
+}
+
+// setDriftCondition reports the drift found in this reconcile through the Drifted condition.
+func setDriftCondition(ps *esapi.PushSecret, drift *driftState) {
+	if driftCheckInterval(ps) == 0 {
+		ps.Status.Conditions = filterOutCondition(ps.Status.Conditions, esapi.PushSecretDrifted)
+		return
+	}
+	cond := newPushSecretCondition(esapi.PushSecretDrifted, v1.ConditionFalse, esapi.ReasonInSync, msgInSync)
+	if len(drift.drifted) > 0 {
+		drifted := strings.Join(drift.drifted, ", ")
+		if ps.Spec.Verification.DriftPolicy == esapi.PushSecretDriftPolicyReport {
+			cond = newPushSecretCondition(esapi.PushSecretDrifted, v1.ConditionTrue, esapi.ReasonDrifted, "remote values drifted: "+drifted)
+		} else {
+			cond = newPushSecretCondition(esapi.PushSecretDrifted, v1.ConditionFalse, esapi.ReasonRepaired, "pushed drifted remote values again: "+drifted)
+		}
+	}
+	setPushSecretCondition(ps, *cond)
+}
@@ -0,0 +1,133 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	vault "github.com/hashicorp/vault/api"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	provider "github.com/external-secrets/external-secrets/pkg/provider/vault"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/util"
+)
+
+// newVaultClient returns a Vault client which keeps the written secrets in memory.
+func newVaultClient(t *testing.T, version esv1beta1.VaultKVStoreVersion) esv1beta1.SecretsClient {
+	t.Helper()
+	secrets := make(map[string]map[string]any)
+	logical := fake.Logical{
+		ReadWithDataWithContextFn: func(_ context.Context, path string, _ map[string][]string) (*vault.Secret, error) {
+			data, ok := secrets[path]
+			if !ok {
+				return nil, nil
+			}
+			return &vault.Secret{Data: data}, nil
+		},
+		WriteWithContextFn: func(_ context.Context, path string, data map[string]any) (*vault.Secret, error) {
+			secrets[path] = data
+			return nil, nil
+		},
+	}
+	newClient := func(config *vault.Config) (util.Client, error) {
+		c, err := fake.ClientWithLoginMock(config)
+		if err != nil {
+			return nil, err
+		}
+		c.(*util.VaultClient).LogicalField = logical
+		return c, nil
+	}
+	path := "secret"
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Vault: &esv1beta1.VaultProvider{
+					Server:  "https://vault.example.com",
+					Path:    &path,
+					Version: version,
+					Auth: esv1beta1.VaultAuth{
+						TokenSecretRef: &esmeta.SecretKeySelector{Name: "vault-token", Key: "token"},
+					},
+				},
+			},
+		},
+	}
+	kube := clientfake.NewClientBuilder().WithObjects(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("token")},
+	}).Build()
+	// the provider builds a Kubernetes clientset from the kubeconfig, no requests are sent to the cluster.
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", kubeconfig)
+	secretClient, err := (&provider.Provider{NewVaultClient: newClient}).NewClient(context.Background(), store, kube, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return secretClient
+}
+
+func TestVaultWholeSecretPushHasNoDrift(t *testing.T) {
+	for _, version := range []esv1beta1.VaultKVStoreVersion{esv1beta1.VaultKVStoreV1, esv1beta1.VaultKVStoreV2} {
+		t.Run(string(version), func(t *testing.T) {
+			ctx := context.Background()
+			secretClient := newVaultClient(t, version)
+			secret := &v1.Secret{Data: map[string][]byte{
+				"username": []byte("admin"),
+				"password": []byte(`p&ss<"word">`),
+			}}
+			data := esapi.PushSecretData{Match: esapi.PushSecretMatch{RemoteRef: esapi.PushSecretRemoteRef{RemoteKey: "db"}}}
+
+			if err := secretClient.PushSecret(ctx, secret, data); err != nil {
+				t.Fatal(err)
+			}
+			source, err := sourceHash(secret, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			remote, err := remoteHash(ctx, secretClient, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if source != remote {
+				t.Errorf("remote hash %s does not match source hash %s", remote, source)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+
+	// Loading registered generators.
+	_ "github.com/external-secrets/external-secrets/pkg/generator/register"
+)
+
+const (
+	errGetGenerator        = "could not get generator %s/%s: %w"
+	errGenerate            = "could not generate secret data: %w"
+	errInvalidGeneratedKey = "generator %s/%s returned invalid keys"
+	errGetGeneratorState   = "could not get generator state: %w"
+	errSetGeneratorState   = "could not store generator state: %w"
+	errGeneratorStateOwner = "generator state secret %s is not owned by PushSecret %s"
+
+	generatorStateSuffix = "-generator-state"
+)
+
+// getGeneratedSecret returns the data produced by the generator referenced in .spec.selector.generatorRef.
+// The generated data is persisted in a Secret owned by the PushSecret, so that the generator
+// only runs again if the generatorRef or the regenerate annotation changes.
+func (r *Reconciler) getGeneratedSecret(ctx context.Context, ps *esapi.PushSecret) (*v1.Secret, error) {
+	generatorRef := ps.Spec.Selector.GeneratorRef
+	stateName := ps.Name + generatorStateSuffix
+	state := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: stateName, Namespace: ps.Namespace}, state)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf(errGetGeneratorState, err)
+	}
+	if err == nil && !metav1.IsControlledBy(state, ps) {
+		return nil, fmt.Errorf(errGeneratorStateOwner, stateName, ps.Name)
+	}
+	ps.Status.GeneratorStateSecret = stateName
+
+	if err == nil && !shouldRegenerate(ps, state) {
+		return state, nil
+	}
+
+	data, err := r.generate(ctx, ps.Namespace, generatorRef)
+	if err != nil {
+		return nil, err
+	}
+
+	state = &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      stateName,
+			Namespace: ps.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, state, func() error {
+		if state.Labels == nil {
+			state.Labels = make(map[string]string)
+		}
+		state.Labels[esapi.LabelGeneratorState] = "true"
+		if state.Annotations == nil {
+			state.Annotations = make(map[string]string)
+		}
+		state.Annotations[esapi.AnnotationGeneratorRef] = generatorRefString(generatorRef)
+		state.Annotations[esapi.AnnotationRegenerate] = ps.Annotations[esapi.AnnotationRegenerate]
+		state.Type = v1.SecretTypeOpaque
+		state.Data = data
+		return controllerutil.SetControllerReference(ps, state, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errSetGeneratorState, err)
+	}
+	return state, nil
+}
+
+// generate runs the referenced generator and returns its output.
+func (r *Reconciler) generate(ctx context.Context, namespace string, generatorRef *esv1beta1.GeneratorRef) (map[string][]byte, error) {
+	gv, err := schema.ParseGroupVersion(generatorRef.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	// unstructured objects are read from the API server directly, so no informer is started for generators.
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gv.WithKind(generatorRef.Kind))
+	err = r.Get(ctx, types.NamespacedName{Name: generatorRef.Name, Namespace: namespace}, obj)
+	if err != nil {
+		return nil, fmt.Errorf(errGetGenerator, generatorRef.Kind, generatorRef.Name, err)
+	}
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	genDef := &apiextensions.JSON{Raw: raw}
+	gen, err := genv1alpha1.GetGenerator(genDef)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	data, err := gen.Generate(ctx, genDef, r.Client, namespace)
+	audit.Observe(ctx, audit.Record{
+		Operation: audit.OperationGenerate,
+		Generator: &audit.ObjectRef{Kind: generatorRef.Kind, Namespace: namespace, Name: generatorRef.Name},
+	}, start, err)
+	if err != nil {
+		return nil, fmt.Errorf(errGenerate, err)
+	}
+	if !utils.ValidateKeys(data) {
+		return nil, fmt.Errorf(errInvalidGeneratedKey, generatorRef.Kind, generatorRef.Name)
+	}
+	return data, nil
+}
+
+// shouldRegenerate returns true if the generator state is outdated.
+func shouldRegenerate(ps *esapi.PushSecret, state *v1.Secret) bool {
+	return state.Annotations[esapi.AnnotationGeneratorRef] != generatorRefString(ps.Spec.Selector.GeneratorRef) ||
+		state.Annotations[esapi.AnnotationRegenerate] != ps.Annotations[esapi.AnnotationRegenerate]
+}
+
+func generatorRefString(ref *esv1beta1.GeneratorRef) string {
+	return fmt.Sprintf("%s/%s/%s", ref.APIVersion, ref.Kind, ref.Name)
+}
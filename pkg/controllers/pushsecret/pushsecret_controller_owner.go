@@ -0,0 +1,65 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// ownedPushSecretData passes the identity of the PushSecret to the provider,
+// which stamps it on the remote secret as ownership marker.
+// It also records the version of the remote secret reported by the provider.
+type ownedPushSecretData struct {
+	esapi.PushSecretData
+	owner   string
+	adopt   bool
+	version string
+}
+
+func (d ownedPushSecretData) GetOwner() string {
+	return d.owner
+}
+
+func (d ownedPushSecretData) GetAdopt() bool {
+	return d.adopt
+}
+
+func (d *ownedPushSecretData) SetRemoteVersion(version string) {
+	d.version = version
+}
+
+// ownedRemoteRef passes the identity of the PushSecret to the provider,
+// so that remote secrets owned by someone else are not deleted.
+type ownedRemoteRef struct {
+	esapi.PushSecretRemoteRef
+	owner string
+}
+
+func (r ownedRemoteRef) GetOwner() string {
+	return r.owner
+}
+
+func (r ownedRemoteRef) GetAdopt() bool {
+	return false
+}
+
+// remoteOwner returns the identity of the PushSecret formatted as [<cluster>/]<namespace>/<name>.
+func (r *Reconciler) remoteOwner(ps *esapi.PushSecret) string {
+	owner := ps.Namespace + "/" + ps.Name
+	if r.ClusterName != "" {
+		owner = r.ClusterName + "/" + owner
+	}
+	return owner
+}
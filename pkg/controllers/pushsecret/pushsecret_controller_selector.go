@@ -0,0 +1,213 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	tpl "text/template"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/template/sprig"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errSelectorWithName     = "selector.secret.name and selector.secret.selector cannot be set at the same time"
+	errSelectorWithData     = "spec.data must not be set when secrets are selected by labels"
+	errListSecrets          = "could not list secrets: %w"
+	errParseKeyTemplate     = "could not parse remote key template: %w"
+	errRenderKeyTemplate    = "could not render remote key for key %s of secret %s: %w"
+	errRewriteSecretKeys    = "could not rewrite keys of secret %s: %w"
+	errDuplicateRemoteKey   = "remote key %s is used by secret %s and secret %s"
+	defaultRemoteKeyPattern = "{{ .namespace }}/{{ .name }}/{{ .key }}"
+)
+
+// keyTemplateFuncs are the functions available in the remote key template.
+// Functions reading the environment of the controller are not available.
+var keyTemplateFuncs = sprig.FuncMap()
+
+// pushSource is a Secret together with the data entries which are pushed from it.
+type pushSource struct {
+	secret *v1.Secret
+	data   []esapi.PushSecretData
+	// selected is true if the Secret was selected by labels,
+	// in that case data is derived from the keys of the Secret.
+	selected bool
+}
+
+// resolveSources returns the Secrets to push. Secrets selected by labels
+// do not have any data entries until setSelectedData is called.
+func (r *Reconciler) resolveSources(ctx context.Context, ps *esapi.PushSecret) ([]pushSource, error) {
+	selector := ps.Spec.Selector.Secret
+	if selector == nil || selector.Selector == nil {
+		secret, err := r.resolveSecret(ctx, ps)
+		if err != nil {
+			return nil, err
+		}
+		return []pushSource{{secret: secret, data: ps.Spec.Data}}, nil
+	}
+
+	if selector.Name != "" {
+		return nil, errors.New(errSelectorWithName)
+	}
+	if len(ps.Spec.Data) > 0 {
+		return nil, errors.New(errSelectorWithData)
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert labels: %w", err)
+	}
+	var secretList v1.SecretList
+	err = r.List(ctx, &secretList, client.InNamespace(ps.Namespace), client.MatchingLabelsSelector{Selector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf(errListSecrets, err)
+	}
+	ps.Status.GeneratorStateSecret = ""
+
+	sort.Slice(secretList.Items, func(i, j int) bool { return secretList.Items[i].Name < secretList.Items[j].Name })
+	sources := make([]pushSource, 0, len(secretList.Items))
+	for i := range secretList.Items {
+		sources = append(sources, pushSource{secret: &secretList.Items[i], selected: true})
+	}
+	return sources, nil
+}
+
+// setSelectedData rewrites the keys of every Secret selected by labels
+// and creates a data entry for each key with the remote key rendered from the template.
+func setSelectedData(ps *esapi.PushSecret, sources []pushSource) error {
+	selector := ps.Spec.Selector.Secret
+	if selector == nil || selector.Selector == nil {
+		return nil
+	}
+
+	pattern := selector.RemoteKeyTemplate
+	if pattern == "" {
+		pattern = defaultRemoteKeyPattern
+	}
+	keyTemplate, err := tpl.New("remoteKey").
+		Funcs(keyTemplateFuncs).
+		Option("missingkey=error").
+		Parse(pattern)
+	if err != nil {
+		return fmt.Errorf(errParseKeyTemplate, err)
+	}
+
+	remoteKeys := make(map[string]string)
+	for i := range sources {
+		src := &sources[i]
+		if !src.selected {
+			continue
+		}
+		secretData, err := utils.RewriteMap(selector.Rewrite, src.secret.Data)
+		if err != nil {
+			return fmt.Errorf(errRewriteSecretKeys, src.secret.Name, err)
+		}
+		src.secret.Data = secretData
+
+		keys := make([]string, 0, len(secretData))
+		for key := range secretData {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		src.data = make([]esapi.PushSecretData, 0, len(keys))
+		for _, key := range keys {
+			var remoteKey bytes.Buffer
+			err := keyTemplate.Execute(&remoteKey, map[string]string{
+				"namespace": src.secret.Namespace,
+				"name":      src.secret.Name,
+				"key":       key,
+			})
+			if err != nil {
+				return fmt.Errorf(errRenderKeyTemplate, key, src.secret.Name, err)
+			}
+			if owner, ok := remoteKeys[remoteKey.String()]; ok {
+				return fmt.Errorf(errDuplicateRemoteKey, remoteKey.String(), owner, src.secret.Name)
+			}
+			remoteKeys[remoteKey.String()] = src.secret.Name
+			src.data = append(src.data, esapi.PushSecretData{
+				Match: esapi.PushSecretMatch{
+					SecretKey: key,
+					RemoteRef: esapi.PushSecretRemoteRef{
+						RemoteKey: remoteKey.String(),
+					},
+				},
+				ConversionStrategy: esapi.PushSecretConversionNone,
+			})
+		}
+	}
+	return nil
+}
+
+// syncedSourceSecrets maps the remote refs of Secrets selected by labels to the name of the Secret.
+func syncedSourceSecrets(sources []pushSource) map[string]string {
+	var out map[string]string
+	for _, src := range sources {
+		if !src.selected {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string)
+		}
+		for _, data := range src.data {
+			out[statusRef(data)] = src.secret.Name
+		}
+	}
+	return out
+}
+
+// findPushSecretsForSecret returns the PushSecrets which push the given Secret,
+// either by name or by selecting it through its labels.
+func (r *Reconciler) findPushSecretsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	var pushSecrets esapi.PushSecretList
+	if err := r.List(ctx, &pushSecrets, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.Log.Error(err, "could not list PushSecrets")
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range pushSecrets.Items {
+		ps := &pushSecrets.Items[i]
+		if isSourceSecret(ps, secret) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(ps)})
+		}
+	}
+	return requests
+}
+
+func isSourceSecret(ps *esapi.PushSecret, secret client.Object) bool {
+	selector := ps.Spec.Selector.Secret
+	if selector == nil {
+		return false
+	}
+	if selector.Selector == nil {
+		return selector.Name == secret.GetName()
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector.Selector)
+	if err != nil {
+		return false
+	}
+	return labelSelector.Matches(labels.Set(secret.GetLabels()))
+}
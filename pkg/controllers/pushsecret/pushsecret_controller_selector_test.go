@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+func TestSetSelectedData(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "default template",
+			want: "default/db/password",
+		},
+		{
+			name:     "sprig functions",
+			template: "{{ .name | upper }}-{{ .key }}",
+			want:     "DB-password",
+		},
+		{
+			name:     "env is not available",
+			template: `{{ env "HOME" }}/{{ .key }}`,
+			wantErr:  true,
+		},
+		{
+			name:     "expandenv is not available",
+			template: `{{ expandenv "$HOME" }}/{{ .key }}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &esapi.PushSecret{
+				Spec: esapi.PushSecretSpec{
+					Selector: esapi.PushSecretSelector{
+						Secret: &esapi.PushSecretSecret{
+							Selector:          &metav1.LabelSelector{},
+							RemoteKeyTemplate: tt.template,
+						},
+					},
+				},
+			}
+			sources := []pushSource{{
+				secret: &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+					Data:       map[string][]byte{"password": []byte("secret")},
+				},
+				selected: true,
+			}}
+			err := setSelectedData(ps, sources)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setSelectedData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(sources[0].data) != 1 || sources[0].data[0].Match.RemoteRef.RemoteKey != tt.want {
+				t.Errorf("setSelectedData() data = %+v, want remote key %s", sources[0].data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+const msgStoreSynced = "pushed to store successfully"
+
+// pushState collects the outcome of pushing a PushSecret to its stores.
+type pushState struct {
+	drift    *driftState
+	previous map[string]esapi.PushSecretStoreStatus
+	stores   map[string]*esapi.PushSecretStoreStatus
+	refs     map[string]map[string]esapi.PushSecretRemoteRefStatus
+}
+
+func newPushState(ps *esapi.PushSecret) *pushState {
+	previous := make(map[string]esapi.PushSecretStoreStatus, len(ps.Status.Stores))
+	for _, store := range ps.Status.Stores {
+		previous[store.Store] = store
+	}
+	return &pushState{
+		drift:    newDriftState(ps),
+		previous: previous,
+		stores:   make(map[string]*esapi.PushSecretStoreStatus),
+		refs:     make(map[string]map[string]esapi.PushSecretRemoteRefStatus),
+	}
+}
+
+// previousRef returns the status of the remote ref after the previous reconcile.
+func (s *pushState) previousRef(storeKey, ref string) esapi.PushSecretRemoteRefStatus {
+	for _, r := range s.previous[storeKey].Refs {
+		if r.RemoteRef == ref {
+			return r
+		}
+	}
+	return esapi.PushSecretRemoteRefStatus{RemoteRef: ref}
+}
+
+func (s *pushState) setRef(storeKey string, ref esapi.PushSecretRemoteRefStatus) {
+	if _, ok := s.refs[storeKey]; !ok {
+		s.refs[storeKey] = make(map[string]esapi.PushSecretRemoteRefStatus)
+	}
+	s.refs[storeKey][ref.RemoteRef] = ref
+}
+
+// keepRef keeps the status of a remote ref which was not pushed in this reconcile.
+func (s *pushState) keepRef(storeKey, ref string) {
+	s.setRef(storeKey, s.previousRef(storeKey, ref))
+}
+
+// pushedRef records a successful push of a remote ref.
+// The previous version is kept if the provider did not report one. The push time is only
+// updated if the pushed value or version changed, so that the status does not change on every reconcile.
+func (s *pushState) pushedRef(storeKey, ref, version, hash string) {
+	status := s.previousRef(storeKey, ref)
+	if status.LastPushTime == nil || status.Hash != hash || (version != "" && status.Version != version) {
+		now := metav1.Now()
+		status.LastPushTime = &now
+	}
+	status.LastError = ""
+	status.Hash = hash
+	if version != "" {
+		status.Version = version
+	}
+	s.setRef(storeKey, status)
+}
+
+// failedRef records a failed push of a remote ref.
+func (s *pushState) failedRef(storeKey, ref string, err error) {
+	status := s.previousRef(storeKey, ref)
+	status.LastError = err.Error()
+	s.setRef(storeKey, status)
+}
+
+// storeDone sets the Ready condition of the store. If the push to the store failed,
+// the status of the remote refs which were not pushed is kept.
+func (s *pushState) storeDone(storeKey string, err error) {
+	previous := s.previous[storeKey]
+	cond := newPushSecretCondition(esapi.PushSecretReady, v1.ConditionTrue, esapi.ReasonSynced, msgStoreSynced)
+	if err != nil {
+		cond = newPushSecretCondition(esapi.PushSecretReady, v1.ConditionFalse, esapi.ReasonErrored, err.Error())
+		for _, ref := range previous.Refs {
+			if _, ok := s.refs[storeKey][ref.RemoteRef]; !ok {
+				s.setRef(storeKey, ref)
+			}
+		}
+	}
+	store := &esapi.PushSecretStoreStatus{
+		Store:      storeKey,
+		Conditions: previous.Conditions,
+	}
+	if current := getCondition(store.Conditions, cond.Type); current != nil && current.Status == cond.Status {
+		cond.LastTransitionTime = current.LastTransitionTime
+	}
+	store.Conditions = append(filterOutCondition(store.Conditions, cond.Type), *cond)
+	s.stores[storeKey] = store
+}
+
+// storeStatuses returns the status of the stores pushed to in this reconcile, sorted by store and remote ref.
+func (s *pushState) storeStatuses() []esapi.PushSecretStoreStatus {
+	out := make([]esapi.PushSecretStoreStatus, 0, len(s.stores))
+	for storeKey, store := range s.stores {
+		refs := make([]esapi.PushSecretRemoteRefStatus, 0, len(s.refs[storeKey]))
+		for _, ref := range s.refs[storeKey] {
+			refs = append(refs, ref)
+		}
+		sort.Slice(refs, func(i, j int) bool { return refs[i].RemoteRef < refs[j].RemoteRef })
+		store.Refs = refs
+		out = append(out, *store)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Store < out[j].Store })
+	return out
+}
+
+// getCondition returns the condition with the provided type.
+func getCondition(conditions []esapi.PushSecretStatusCondition, condType esapi.PushSecretConditionType) *esapi.PushSecretStatusCondition {
+	for i := range conditions {
+		if conditions[i].Type == condType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
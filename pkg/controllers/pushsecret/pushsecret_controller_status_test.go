@@ -0,0 +1,78 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+func TestPushedRef(t *testing.T) {
+	lastPush := metav1.NewTime(time.Now().Add(-time.Hour))
+	tests := []struct {
+		name    string
+		version string
+		hash    string
+		updated bool
+	}{
+		{
+			name:    "unchanged value and version",
+			version: "1",
+			hash:    "hash",
+		},
+		{
+			name: "unchanged value without version",
+			hash: "hash",
+		},
+		{
+			name:    "changed value",
+			version: "1",
+			hash:    "other",
+			updated: true,
+		},
+		{
+			name:    "changed version",
+			version: "2",
+			hash:    "hash",
+			updated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &esapi.PushSecret{Status: esapi.PushSecretStatus{Stores: []esapi.PushSecretStoreStatus{{
+				Store: "SecretStore/store",
+				Refs: []esapi.PushSecretRemoteRefStatus{{
+					RemoteRef:    "key",
+					LastPushTime: &lastPush,
+					Version:      "1",
+					Hash:         "hash",
+				}},
+			}}}}
+			state := newPushState(ps)
+			state.pushedRef("SecretStore/store", "key", tt.version, tt.hash)
+			got := state.refs["SecretStore/store"]["key"]
+			if updated := !got.LastPushTime.Equal(&lastPush); updated != tt.updated {
+				t.Errorf("pushedRef() updated LastPushTime = %v, want %v", updated, tt.updated)
+			}
+			if got.Hash != tt.hash {
+				t.Errorf("pushedRef() Hash = %s, want %s", got.Hash, tt.hash)
+			}
+		})
+	}
+}
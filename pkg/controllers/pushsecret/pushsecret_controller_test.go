@@ -29,6 +29,7 @@ import (
 
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	ctest "github.com/external-secrets/external-secrets/pkg/controllers/commontest"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
 	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
@@ -156,7 +157,7 @@ var _ = Describe("ExternalSecret controller", func() {
 						},
 					},
 					Selector: v1alpha1.PushSecretSelector{
-						Secret: v1alpha1.PushSecretSecret{
+						Secret: &v1alpha1.PushSecretSecret{
 							Name: SecretName,
 						},
 					},
@@ -220,6 +221,31 @@ var _ = Describe("ExternalSecret controller", func() {
 		}
 	}
 
+	// the pushed data is marked as owned by the PushSecret and adopts the remote secret.
+	syncWithAdopt := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		tc.pushsecret.Spec.UpdatePolicy = v1alpha1.PushSecretUpdatePolicyAdopt
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			Eventually(func() bool {
+				By("checking if Provider value got updated by the owner")
+				providerValue, ok := fakeProvider.SetSecretArgs[ps.Spec.Data[0].Match.RemoteRef.RemoteKey]
+				if !ok {
+					return false
+				}
+				owner, ok := providerValue.RemoteRef.(v1beta1.PushSecretOwner)
+				if !ok {
+					return false
+				}
+				return bytes.Equal(providerValue.Value, secret.Data[defaultKey]) &&
+					owner.GetOwner() == ps.Namespace+"/"+ps.Name &&
+					owner.GetAdopt()
+			}, time.Second*10, time.Second).Should(BeTrue())
+			return true
+		}
+	}
+
 	updateIfNotExists := func(tc *testCase) {
 		fakeProvider.SetSecretFn = func() error {
 			return nil
@@ -389,7 +415,7 @@ var _ = Describe("ExternalSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -453,7 +479,7 @@ var _ = Describe("ExternalSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -510,7 +536,7 @@ var _ = Describe("ExternalSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -656,7 +682,7 @@ var _ = Describe("ExternalSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -721,7 +747,7 @@ var _ = Describe("ExternalSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -822,7 +848,7 @@ var _ = Describe("ExternalSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -865,6 +891,155 @@ var _ = Describe("ExternalSecret controller", func() {
 			return bytes.Equal(secretValue, providerValue) && checkCondition(ps.Status, expected)
 		}
 	}
+	syncWithGenerator := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		Expect(k8sClient.Create(context.Background(), &genv1alpha1.Fake{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-generator",
+				Namespace: PushSecretNamespace,
+			},
+			Spec: genv1alpha1.FakeSpec{
+				Data: map[string]string{defaultKey: newVal},
+			},
+		})).To(Succeed())
+		tc.secret = nil
+		tc.pushsecret.Spec.Selector = v1alpha1.PushSecretSelector{
+			GeneratorRef: &v1beta1.GeneratorRef{
+				APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "Fake",
+				Name:       "test-generator",
+			},
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			providerValue, ok := fakeProvider.SetSecretArgs[ps.Spec.Data[0].Match.RemoteRef.RemoteKey]
+			if !ok || !bytes.Equal(providerValue.Value, []byte(newVal)) {
+				return false
+			}
+			state := &v1.Secret{}
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: ps.Status.GeneratorStateSecret, Namespace: ps.Namespace}, state)
+			if err != nil {
+				return false
+			}
+			expected := v1alpha1.PushSecretStatusCondition{
+				Type:    v1alpha1.PushSecretReady,
+				Status:  v1.ConditionTrue,
+				Reason:  v1alpha1.ReasonSynced,
+				Message: "PushSecret synced successfully",
+			}
+			return bytes.Equal(state.Data[defaultKey], []byte(newVal)) &&
+				metav1.IsControlledBy(state, ps) &&
+				checkCondition(ps.Status, expected)
+		}
+	}
+	createSelectedSecrets := func() {
+		for name, data := range map[string]map[string][]byte{
+			"selected-a": {defaultKey: []byte(defaultVal)},
+			"selected-b": {otherKey: []byte(otherVal)},
+		} {
+			Expect(k8sClient.Create(context.Background(), &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: PushSecretNamespace,
+					Labels:    map[string]string{"push": "true"},
+				},
+				Data: data,
+			})).To(Succeed())
+		}
+	}
+	selectSecretsByLabels := func(tc *testCase) {
+		tc.pushsecret.Spec.Selector = v1alpha1.PushSecretSelector{
+			Secret: &v1alpha1.PushSecretSecret{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"push": "true"},
+				},
+				Rewrite: []v1beta1.ExternalSecretRewrite{
+					{
+						Regexp: &v1beta1.ExternalSecretRewriteRegexp{
+							Source: "other-(.*)",
+							Target: "renamed-$1",
+						},
+					},
+				},
+			},
+		}
+		tc.pushsecret.Spec.Data = nil
+	}
+	syncSelectedSecrets := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		createSelectedSecrets()
+		selectSecretsByLabels(tc)
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			keyA := fmt.Sprintf("%s/selected-a/%s", PushSecretNamespace, defaultKey)
+			keyB := fmt.Sprintf("%s/selected-b/renamed-key", PushSecretNamespace)
+			valueA, okA := fakeProvider.SetSecretArgs[keyA]
+			valueB, okB := fakeProvider.SetSecretArgs[keyB]
+			_, okDefault := fakeProvider.SetSecretArgs[defaultPath]
+			return okA && okB && !okDefault &&
+				bytes.Equal(valueA.Value, []byte(defaultVal)) &&
+				bytes.Equal(valueB.Value, []byte(otherVal)) &&
+				ps.Status.SyncedSourceSecrets[keyA] == "selected-a" &&
+				ps.Status.SyncedSourceSecrets[keyB] == "selected-b"
+		}
+	}
+	deleteUnselectedSecrets := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		fakeProvider.DeleteSecretFn = func() error {
+			return nil
+		}
+		createSelectedSecrets()
+		selectSecretsByLabels(tc)
+		tc.pushsecret.Spec.DeletionPolicy = v1alpha1.PushSecretDeletionPolicyDelete
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			keyB := fmt.Sprintf("%s/selected-b/renamed-key", PushSecretNamespace)
+			if _, ok := ps.Status.SyncedPushSecrets[fmt.Sprintf(storePrefixTemplate, PushSecretStore)][keyB]; !ok {
+				return false
+			}
+			By("removing the label from a selected secret")
+			selected := &v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: "selected-b", Namespace: PushSecretNamespace}, selected)).To(Succeed())
+			selected.Labels = nil
+			Expect(k8sClient.Update(context.Background(), selected)).To(Succeed())
+			Eventually(func() bool {
+				updatedPS := &v1alpha1.PushSecret{}
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(ps), updatedPS)
+				if err != nil {
+					return false
+				}
+				_, ok := updatedPS.Status.SyncedPushSecrets[fmt.Sprintf(storePrefixTemplate, PushSecretStore)][keyB]
+				_, tracked := updatedPS.Status.SyncedSourceSecrets[keyB]
+				return !ok && !tracked
+			}, time.Second*10, time.Second).Should(BeTrue())
+			return true
+		}
+	}
+	failSelectorWithData := func(tc *testCase) {
+		selectSecretsByLabels(tc)
+		tc.pushsecret.Spec.Data = []v1alpha1.PushSecretData{
+			{
+				Match: v1alpha1.PushSecretMatch{
+					SecretKey: defaultKey,
+					RemoteRef: v1alpha1.PushSecretRemoteRef{
+						RemoteKey: defaultPath,
+					},
+				},
+			},
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			expected := v1alpha1.PushSecretStatusCondition{
+				Type:    v1alpha1.PushSecretReady,
+				Status:  v1.ConditionFalse,
+				Reason:  v1alpha1.ReasonErrored,
+				Message: "could not get source secret",
+			}
+			return checkCondition(ps.Status, expected)
+		}
+	}
 	// if target Secret name is not specified it should use the ExternalSecret name.
 	failNoSecret := func(tc *testCase) {
 		fakeProvider.SetSecretFn = func() error {
@@ -945,6 +1120,116 @@ var _ = Describe("ExternalSecret controller", func() {
 			return checkCondition(ps.Status, expected)
 		}
 	}
+	// the outcome of the push is reported for every store and remote ref.
+	syncStoreStatus := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			if len(ps.Status.Stores) != 1 {
+				return false
+			}
+			store := ps.Status.Stores[0]
+			expected := v1alpha1.PushSecretStatusCondition{
+				Type:    v1alpha1.PushSecretReady,
+				Status:  v1.ConditionTrue,
+				Reason:  v1alpha1.ReasonSynced,
+				Message: "pushed to store successfully",
+			}
+			return store.Store == fmt.Sprintf(storePrefixTemplate, PushSecretStore) &&
+				checkCondition(v1alpha1.PushSecretStatus{Conditions: store.Conditions}, expected) &&
+				len(store.Refs) == 1 &&
+				store.Refs[0].RemoteRef == defaultPath &&
+				store.Refs[0].LastPushTime != nil &&
+				store.Refs[0].LastError == ""
+		}
+	}
+	// the error of a failed push is reported for the store and remote ref.
+	failStoreStatus := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return fmt.Errorf("boom")
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			if len(ps.Status.Stores) != 1 {
+				return false
+			}
+			store := ps.Status.Stores[0]
+			msg := "could not write remote ref key to target secretstore test-store: boom"
+			expected := v1alpha1.PushSecretStatusCondition{
+				Type:    v1alpha1.PushSecretReady,
+				Status:  v1.ConditionFalse,
+				Reason:  v1alpha1.ReasonErrored,
+				Message: msg,
+			}
+			return checkCondition(v1alpha1.PushSecretStatus{Conditions: store.Conditions}, expected) &&
+				len(store.Refs) == 1 &&
+				store.Refs[0].LastPushTime == nil &&
+				store.Refs[0].LastError == msg
+		}
+	}
+	// the pushed value is read back and compared with the source value.
+	verifyFail := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		fakeProvider.WithGetSecret([]byte(otherVal), nil)
+		tc.pushsecret.Spec.Verification = &v1alpha1.PushSecretVerification{
+			ReadAfterWrite: true,
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			expected := v1alpha1.PushSecretStatusCondition{
+				Type:    v1alpha1.PushSecretReady,
+				Status:  v1.ConditionFalse,
+				Reason:  v1alpha1.ReasonErrored,
+				Message: "set secret failed: remote ref path/to/key in target secretstore test-store does not match the pushed value",
+			}
+			return checkCondition(ps.Status, expected)
+		}
+	}
+	// drifted remote values are reported but not pushed again if DriftPolicy=Report.
+	driftReport := func(tc *testCase) {
+		pushes := 0
+		fakeProvider.SetSecretFn = func() error {
+			pushes++
+			return nil
+		}
+		fakeProvider.WithGetSecret([]byte(otherVal), nil)
+		tc.pushsecret.Spec.Verification = &v1alpha1.PushSecretVerification{
+			DriftCheckInterval: &metav1.Duration{Duration: time.Second},
+			DriftPolicy:        v1alpha1.PushSecretDriftPolicyReport,
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			expected := v1alpha1.PushSecretStatusCondition{
+				Type:    v1alpha1.PushSecretDrifted,
+				Status:  v1.ConditionTrue,
+				Reason:  v1alpha1.ReasonDrifted,
+				Message: "remote values drifted: SecretStore/test-store/path/to/key",
+			}
+			return checkCondition(ps.Status, expected) && pushes == 1
+		}
+	}
+	// drifted remote values are pushed again if DriftPolicy=Repush.
+	driftRepush := func(tc *testCase) {
+		pushes := 0
+		fakeProvider.SetSecretFn = func() error {
+			pushes++
+			return nil
+		}
+		fakeProvider.WithGetSecret([]byte(otherVal), nil)
+		tc.pushsecret.Spec.Verification = &v1alpha1.PushSecretVerification{
+			DriftCheckInterval: &metav1.Duration{Duration: time.Second},
+			DriftPolicy:        v1alpha1.PushSecretDriftPolicyRepush,
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			expected := v1alpha1.PushSecretStatusCondition{
+				Type:    v1alpha1.PushSecretDrifted,
+				Status:  v1.ConditionFalse,
+				Reason:  v1alpha1.ReasonRepaired,
+				Message: "pushed drifted remote values again: SecretStore/test-store/path/to/key",
+			}
+			return checkCondition(ps.Status, expected) && pushes > 1
+		}
+	}
 	// if target Secret name is not specified it should use the ExternalSecret name.
 	newClientFail := func(tc *testCase) {
 		fakeProvider.NewFn = func(context.Context, v1beta1.GenericStore, client.Client, string) (v1beta1.SecretsClient, error) {
@@ -991,6 +1276,7 @@ var _ = Describe("ExternalSecret controller", func() {
 			// this must be optional so we can test faulty es configuration
 		},
 		Entry("should sync", syncSuccessfully),
+		Entry("should mark pushed secrets as owned and adopt them if UpdatePolicy=Adopt", syncWithAdopt),
 		Entry("should not update existing secret if UpdatePolicy=IfNotExists", updateIfNotExists),
 		Entry("should only update parts of secret that don't already exist if UpdatePolicy=IfNotExists", updateIfNotExistsPartialSecrets),
 		Entry("should update the PushSecret status correctly if UpdatePolicy=IfNotExists", updateIfNotExistsSyncStatus),
@@ -1004,9 +1290,18 @@ var _ = Describe("ExternalSecret controller", func() {
 		Entry("should sync to stores matching labels", syncMatchingLabels),
 		Entry("should sync with ClusterStore", syncWithClusterStore),
 		Entry("should sync with ClusterStore matching labels", syncWithClusterStoreMatchingLabels),
+		Entry("should sync with generatorRef", syncWithGenerator),
+		Entry("should sync secrets selected by labels", syncSelectedSecrets),
+		Entry("should delete remote keys of secrets which are no longer selected", deleteUnselectedSecrets),
+		Entry("should fail if secrets are selected by labels and data is set", failSelectorWithData),
 		Entry("should fail if Secret is not created", failNoSecret),
 		Entry("should fail if Secret Key does not exist", failNoSecretKey),
+		Entry("should report the status of every store and remote ref", syncStoreStatus),
 		Entry("should fail if SetSecret fails", setSecretFail),
+		Entry("should report the error of the failed store and remote ref", failStoreStatus),
+		Entry("should fail if the pushed value does not match the remote value", verifyFail),
+		Entry("should report drifted remote values if DriftPolicy=Report", driftReport),
+		Entry("should push drifted remote values again if DriftPolicy=Repush", driftRepush),
 		Entry("should fail if no valid SecretStore", failNoSecretStore),
 		Entry("should fail if no valid ClusterSecretStore", failNoClusterStore),
 		Entry("should fail if NewClient fails", newClientFail),
@@ -0,0 +1,133 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
+)
+
+// auditedClient records the calls to the provider in the audit trail.
+type auditedClient struct {
+	esv1beta1.SecretsClient
+	store *audit.ObjectRef
+}
+
+var _ esv1beta1.SecretsClient = &auditedClient{}
+
+// newAuditedClient wraps the client if the audit trail is enabled.
+func newAuditedClient(secretClient esv1beta1.SecretsClient, store esv1beta1.GenericStore) esv1beta1.SecretsClient {
+	if !audit.Enabled() {
+		return secretClient
+	}
+	return &auditedClient{
+		SecretsClient: secretClient,
+		store: &audit.ObjectRef{
+			Kind:      store.GetKind(),
+			Namespace: store.GetNamespace(),
+			Name:      store.GetName(),
+		},
+	}
+}
+
+func (c *auditedClient) remoteRefRecord(op audit.Operation, ref esv1beta1.ExternalSecretDataRemoteRef) audit.Record {
+	return audit.Record{
+		Operation: op,
+		Store:     c.store,
+		RemoteKey: ref.Key,
+		Property:  ref.Property,
+		Version:   ref.Version,
+	}
+}
+
+func (c *auditedClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	start := time.Now()
+	data, err := c.SecretsClient.GetSecret(ctx, ref)
+	audit.Observe(ctx, c.remoteRefRecord(audit.OperationGetSecret, ref), start, err)
+	return data, err
+}
+
+func (c *auditedClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	start := time.Now()
+	data, err := c.SecretsClient.GetSecretMap(ctx, ref)
+	audit.Observe(ctx, c.remoteRefRecord(audit.OperationGetSecretMap, ref), start, err)
+	return data, err
+}
+
+func (c *auditedClient) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	start := time.Now()
+	data, err := c.SecretsClient.GetAllSecrets(ctx, ref)
+	find := &audit.Find{Tags: ref.Tags}
+	if ref.Path != nil {
+		find.Path = *ref.Path
+	}
+	if ref.Name != nil {
+		find.Name = ref.Name.RegExp
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	audit.Observe(ctx, audit.Record{
+		Operation: audit.OperationGetAllSecrets,
+		Store:     c.store,
+		Find:      find,
+		Keys:      keys,
+	}, start, err)
+	return data, err
+}
+
+func (c *auditedClient) PushSecret(ctx context.Context, secret *v1.Secret, data esv1beta1.PushSecretData) error {
+	start := time.Now()
+	err := c.SecretsClient.PushSecret(ctx, secret, data)
+	audit.Observe(ctx, audit.Record{
+		Operation: audit.OperationPushSecret,
+		Store:     c.store,
+		RemoteKey: data.GetRemoteKey(),
+		Property:  data.GetProperty(),
+	}, start, err)
+	return err
+}
+
+func (c *auditedClient) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	start := time.Now()
+	err := c.SecretsClient.DeleteSecret(ctx, remoteRef)
+	audit.Observe(ctx, audit.Record{
+		Operation: audit.OperationDeleteSecret,
+		Store:     c.store,
+		RemoteKey: remoteRef.GetRemoteKey(),
+		Property:  remoteRef.GetProperty(),
+	}, start, err)
+	return err
+}
+
+func (c *auditedClient) SecretExists(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) (bool, error) {
+	start := time.Now()
+	exists, err := c.SecretsClient.SecretExists(ctx, remoteRef)
+	audit.Observe(ctx, audit.Record{
+		Operation: audit.OperationSecretExists,
+		Store:     c.store,
+		RemoteKey: remoteRef.GetRemoteKey(),
+		Property:  remoteRef.GetProperty(),
+	}, start, err)
+	return exists, err
+}
@@ -0,0 +1,89 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestAuditedClient(t *testing.T) {
+	store := &esv1beta1.ClusterSecretStore{ObjectMeta: metav1.ObjectMeta{Name: "vault"}}
+	cl := fake.New()
+	assert.Same(t, cl, newAuditedClient(cl, store), "client must not be wrapped if the audit trail is disabled")
+
+	var buf bytes.Buffer
+	audit.SetAuditor(audit.New(audit.Filter{SampleRate: 1}, audit.NewWriterSink(&buf)))
+	defer audit.SetAuditor(nil)
+
+	cl.WithGetSecret([]byte("s3cr3t"), nil).
+		WithGetAllSecrets(map[string][]byte{"db": []byte("s3cr3t"), "api": []byte("t0k3n")}, nil).
+		WithSetSecret(errors.New("access denied"))
+	audited := newAuditedClient(cl, store)
+	ctx := audit.WithRequester(context.Background(), esv1beta1.ExtSecretKind, "foo", "bar")
+
+	_, err := audited.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Property: "password"})
+	require.NoError(t, err)
+	_, err = audited.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: ptr.To("app")})
+	require.NoError(t, err)
+	err = audited.PushSecret(ctx, &corev1.Secret{Data: map[string][]byte{"password": []byte("s3cr3t")}}, esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{
+			SecretKey: "password",
+			RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "db"},
+		},
+	})
+	require.Error(t, err)
+
+	assert.NotContains(t, buf.String(), "s3cr3t")
+	assert.NotContains(t, buf.String(), "t0k3n")
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+	records := make([]audit.Record, len(lines))
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal(line, &records[i]))
+	}
+
+	storeRef := &audit.ObjectRef{Kind: esv1beta1.ClusterSecretStoreKind, Name: "vault"}
+	requester := &audit.ObjectRef{Kind: esv1beta1.ExtSecretKind, Namespace: "foo", Name: "bar"}
+	assert.Equal(t, audit.OperationGetSecret, records[0].Operation)
+	assert.Equal(t, storeRef, records[0].Store)
+	assert.Equal(t, requester, records[0].Requester)
+	assert.Equal(t, "db", records[0].RemoteKey)
+	assert.Equal(t, "password", records[0].Property)
+	assert.Equal(t, audit.ResultSuccess, records[0].Result)
+
+	assert.Equal(t, audit.OperationGetAllSecrets, records[1].Operation)
+	assert.Equal(t, &audit.Find{Path: "app"}, records[1].Find)
+	assert.Equal(t, []string{"api", "db"}, records[1].Keys)
+
+	assert.Equal(t, audit.OperationPushSecret, records[2].Operation)
+	assert.Equal(t, "db", records[2].RemoteKey)
+	assert.Equal(t, audit.ResultError, records[2].Result)
+	assert.Equal(t, "access denied", records[2].Error)
+}
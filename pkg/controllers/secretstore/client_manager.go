@@ -21,8 +21,6 @@ import (
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,6 +32,7 @@ const (
 	errGetClusterSecretStore = "could not get ClusterSecretStore %q, %w"
 	errGetSecretStore        = "could not get SecretStore %q, %w"
 	errSecretStoreNotReady   = "the desired SecretStore %s is not ready"
+	errSecretStoreDegraded   = "the desired SecretStore %s is degraded: %s"
 	errClusterStoreMismatch  = "using cluster store %q is not allowed from namespace %q: denied by spec.condition"
 )
 
@@ -73,7 +72,17 @@ func NewManager(ctrlClient client.Client, controllerClass string, enableFloodgat
 	}
 }
 
+// GetFromStore returns a provider client for the given store.
+// Calls to the client are recorded in the audit trail.
 func (m *Manager) GetFromStore(ctx context.Context, store esv1beta1.GenericStore, namespace string) (esv1beta1.SecretsClient, error) {
+	secretClient, err := m.getFromStore(ctx, store, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return newAuditedClient(secretClient, store), nil
+}
+
+func (m *Manager) getFromStore(ctx context.Context, store esv1beta1.GenericStore, namespace string) (esv1beta1.SecretsClient, error) {
 	storeProvider, err := esv1beta1.GetProvider(store)
 	if err != nil {
 		return nil, err
@@ -116,7 +125,7 @@ func (m *Manager) Get(ctx context.Context, storeRef esv1beta1.SecretStoreRef, na
 		return nil, fmt.Errorf("can not reference unmanaged store")
 	}
 	// when using ClusterSecretStore, validate the ClusterSecretStore namespace conditions
-	shouldProcess, err := m.shouldProcessSecret(store, namespace)
+	shouldProcess, ns, rules, err := m.storeAccess(store, namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +139,18 @@ func (m *Manager) Get(ctx context.Context, storeRef esv1beta1.SecretStoreRef, na
 			return nil, err
 		}
 	}
-	return m.GetFromStore(ctx, store, namespace)
+	secretClient, err := m.getFromStore(ctx, store, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		secretClient, err = newRestrictedClient(secretClient, ns.ObjectMeta, rules)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// access denied by the rules of a ClusterSecretStore is audited as well
+	return newAuditedClient(secretClient, store), nil
 }
 
 // returns a previously stored client from the cache if store and store-version match
@@ -208,46 +228,24 @@ func (m *Manager) Close(ctx context.Context) error {
 	return nil
 }
 
-func (m *Manager) shouldProcessSecret(store esv1beta1.GenericStore, ns string) (bool, error) {
+// storeAccess returns whether the namespace may use the store and the access
+// rules which restrict the remote keys the namespace may access.
+func (m *Manager) storeAccess(store esv1beta1.GenericStore, ns string) (bool, *v1.Namespace, []esv1beta1.ClusterSecretStoreAccess, error) {
 	if store.GetKind() != esv1beta1.ClusterSecretStoreKind {
-		return true, nil
+		return true, nil, nil, nil
 	}
 
 	if len(store.GetSpec().Conditions) == 0 {
-		return true, nil
+		return true, nil, nil, nil
 	}
 
 	namespace := v1.Namespace{}
 	if err := m.client.Get(context.Background(), client.ObjectKey{Name: ns}, &namespace); err != nil {
-		return false, fmt.Errorf("failed to get a namespace %q: %w", ns, err)
+		return false, nil, nil, fmt.Errorf("failed to get a namespace %q: %w", ns, err)
 	}
 
-	nsLabels := labels.Set(namespace.GetLabels())
-	for _, condition := range store.GetSpec().Conditions {
-		var labelSelectors []*metav1.LabelSelector
-		if condition.NamespaceSelector != nil {
-			labelSelectors = append(labelSelectors, condition.NamespaceSelector)
-		}
-		for _, n := range condition.Namespaces {
-			labelSelectors = append(labelSelectors, &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"kubernetes.io/metadata.name": n,
-				},
-			})
-		}
-
-		for _, ls := range labelSelectors {
-			selector, err := metav1.LabelSelectorAsSelector(ls)
-			if err != nil {
-				return false, fmt.Errorf("failed to convert label selector into selector %v: %w", ls, err)
-			}
-			if selector.Matches(nsLabels) {
-				return true, nil
-			}
-		}
-	}
-
-	return false, nil
+	allowed, rules, err := esv1beta1.NamespaceStoreAccess(store, &namespace)
+	return allowed, &namespace, rules, err
 }
 
 // assertStoreIsUsable assert that the store is ready to use.
@@ -256,6 +254,9 @@ func assertStoreIsUsable(store esv1beta1.GenericStore) error {
 		return nil
 	}
 	condition := GetSecretStoreCondition(store.GetStatus(), esv1beta1.SecretStoreReady)
+	if condition != nil && condition.Reason == esv1beta1.ReasonStoreDegraded {
+		return fmt.Errorf(errSecretStoreDegraded, store.GetName(), condition.Message)
+	}
 	if condition == nil || condition.Status != v1.ConditionTrue {
 		return fmt.Errorf(errSecretStoreNotReady, store.GetName())
 	}
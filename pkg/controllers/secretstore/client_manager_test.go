@@ -30,6 +30,7 @@ import (
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 )
 
@@ -310,6 +311,91 @@ func TestManagerGet(t *testing.T) {
 	}
 }
 
+func TestManagerGetRestricted(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+
+	fakeProvider := &WrapProvider{
+		newClientFunc: func(context.Context, esv1beta1.GenericStore, client.Client, string) (esv1beta1.SecretsClient, error) {
+			return &MockFakeClient{id: "1"}, nil
+		},
+	}
+	esv1beta1.ForceRegister(fakeProvider, &esv1beta1.SecretStoreProvider{
+		AWS: &esv1beta1.AWSProvider{},
+	})
+
+	store := &esv1beta1.ClusterSecretStore{
+		TypeMeta: metav1.TypeMeta{Kind: esv1beta1.ClusterSecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "shared",
+		},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				AWS: &esv1beta1.AWSProvider{},
+			},
+			Conditions: []esv1beta1.ClusterSecretStoreCondition{
+				{
+					Namespaces: []string{"foo"},
+					Access: &esv1beta1.ClusterSecretStoreAccess{
+						KeyPrefixes: []string{"{{ .namespace.name }}/"},
+					},
+				},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "foo",
+			Labels: map[string]string{"kubernetes.io/metadata.name": "foo"},
+		},
+	}
+	mgr := NewManager(fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(store, namespace).Build(), "", false)
+	defer mgr.Close(context.Background())
+
+	secretClient, err := mgr.Get(context.Background(), esv1beta1.SecretStoreRef{
+		Name: store.Name,
+		Kind: esv1beta1.ClusterSecretStoreKind,
+	}, "foo", nil)
+	require.NoError(t, err)
+
+	_, err = secretClient.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "foo/db"})
+	assert.NoError(t, err)
+	_, err = secretClient.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "bar/db"})
+	assert.EqualError(t, err, `access to remote key "bar/db" is not allowed from namespace "foo"`)
+	_, err = secretClient.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	assert.EqualError(t, err, `find in path "" is not allowed from namespace "foo"`)
+	err = secretClient.PushSecret(context.Background(), &corev1.Secret{}, esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "bar/db"}},
+	})
+	assert.EqualError(t, err, `access to remote key "bar/db" is not allowed from namespace "foo"`)
+}
+
+// findClient returns all of its secrets, regardless of the find path.
+type findClient struct {
+	MockFakeClient
+	secrets map[string][]byte
+}
+
+func (c *findClient) GetAllSecrets(_ context.Context, _ esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return c.secrets, nil
+}
+
+func TestRestrictedClientFiltersFoundSecrets(t *testing.T) {
+	secretClient, err := newRestrictedClient(&findClient{secrets: map[string][]byte{
+		"foo/db":  []byte("foo"),
+		"bar/db":  []byte("bar"),
+		"foo-api": []byte("foo-api"),
+	}}, metav1.ObjectMeta{Name: "foo"}, []esv1beta1.ClusterSecretStoreAccess{
+		{KeyPrefixes: []string{"{{ .namespace.name }}/"}, FindPaths: []string{"{{ .namespace.name }}"}},
+	})
+	require.NoError(t, err)
+	path := "foo/"
+	secrets, err := secretClient.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Path: &path})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"foo/db": []byte("foo")}, secrets)
+}
+
 type WrapProvider struct {
 	newClientFunc func(
 		context.Context,
@@ -20,10 +20,13 @@ import (
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
@@ -41,6 +44,7 @@ type ClusterStoreReconciler struct {
 	ControllerClass string
 	RequeueInterval time.Duration
 	recorder        record.EventRecorder
+	changed         changedStores
 }
 
 func (r *ClusterStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -56,20 +60,40 @@ func (r *ClusterStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	err := r.Get(ctx, req.NamespacedName, &css)
 	if apierrors.IsNotFound(err) {
 		cssmetrics.RemoveMetrics(req.Namespace, req.Name)
+		// drops the cached clients and credentials of the deleted store
+		invalidateCachedClients(&esapi.ClusterSecretStore{ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace}})
 		return ctrl.Result{}, nil
 	} else if err != nil {
 		log.Error(err, "unable to get ClusterSecretStore")
 		return ctrl.Result{}, err
 	}
 
-	return reconcile(ctx, req, &css, r.Client, log, r.ControllerClass, cssmetrics.GetGaugeVec, r.recorder, r.RequeueInterval)
+	return reconcile(ctx, req, &css, r.Client, log, r.ControllerClass, cssmetrics.GetGaugeVec, r.recorder, r.RequeueInterval, r.changed.pop(req.NamespacedName))
 }
 
 // SetupWithManager returns a new controller builder that will be started by the provided Manager.
 func (r *ClusterStoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.recorder = mgr.GetEventRecorderFor("cluster-secret-store")
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&esapi.ClusterSecretStore{}).
+	// Index the objects referenced by the provider to revalidate stores when credentials rotate
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &esapi.ClusterSecretStore{}, storeReferencesKey, indexStoreReferences); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&esapi.ClusterSecretStore{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	return watchStoreReferences(b, findStoresFunc(&r.changed, r.listStores)).
 		Complete(r)
 }
+
+func (r *ClusterStoreReconciler) listStores(ctx context.Context, opts ...client.ListOption) ([]esapi.GenericStore, error) {
+	var list esapi.ClusterSecretStoreList
+	if err := r.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	stores := make([]esapi.GenericStore, 0, len(list.Items))
+	for i := range list.Items {
+		stores = append(stores, &list.Items[i])
+	}
+	return stores, nil
+}
@@ -21,11 +21,13 @@ import (
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/audit"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/metrics"
 )
 
@@ -42,19 +44,30 @@ const (
 )
 
 func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl client.Client, log logr.Logger,
-	controllerClass string, gaugeVecGetter metrics.GaugeVevGetter, recorder record.EventRecorder, requeueInterval time.Duration) (ctrl.Result, error) {
+	controllerClass string, gaugeVecGetter metrics.GaugeVevGetter, recorder record.EventRecorder, requeueInterval time.Duration,
+	referencesChanged bool) (ctrl.Result, error) {
 	if !ShouldProcessStore(ss, controllerClass) {
 		log.V(1).Info("skip store")
 		return ctrl.Result{}, nil
 	}
 
+	// credentials referenced by the store changed, clients created with the previous ones must not be reused
+	if referencesChanged {
+		log.V(1).Info("referenced objects changed, dropping cached clients")
+		invalidateCachedClients(ss)
+	}
+
 	if ss.GetSpec().RefreshInterval != 0 {
 		requeueInterval = time.Second * time.Duration(ss.GetSpec().RefreshInterval)
 	}
 
-	// patch status when done processing
-	p := client.MergeFrom(ss.Copy())
+	// patch status when done processing, unless it did not change
+	previous := ss.Copy()
+	p := client.MergeFrom(previous)
 	defer func() {
+		if equality.Semantic.DeepEqual(previous.GetStatus(), ss.GetStatus()) {
+			return
+		}
 		err := cl.Status().Patch(ctx, ss, p)
 		if err != nil {
 			log.Error(err, errPatchStatus)
@@ -64,7 +77,11 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 	// validateStore modifies the store conditions
 	// we have to patch the status
 	log.V(1).Info("validating")
-	err := validateStore(ctx, req.Namespace, controllerClass, ss, cl, gaugeVecGetter, recorder)
+	// the health check reads the canary key on behalf of the store
+	ctx = audit.WithRequester(ctx, ss.GetKind(), ss.GetNamespace(), ss.GetName())
+	mgr := NewManager(cl, controllerClass, false)
+	defer mgr.Close(ctx)
+	storeClient, err := validateStore(ctx, req.Namespace, ss, mgr, gaugeVecGetter, recorder)
 	if err != nil {
 		log.Error(err, "unable to validate store")
 		return ctrl.Result{}, err
@@ -73,12 +90,25 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	capStatus := esapi.SecretStoreStatus{
-		Capabilities: storeProvider.Capabilities(),
-		Conditions:   ss.GetStatus().Conditions,
+	capStatus := ss.GetStatus()
+	capStatus.Capabilities = storeProvider.Capabilities()
+	capStatus.Features, err = esapi.GetStoreFeatures(ss)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 	ss.SetStatus(capStatus)
 
+	// a failed health check is not returned as error,
+	// the canary key is read again with the next health check.
+	healthy := checkStoreHealth(ctx, storeClient, ss, requeueInterval, referencesChanged, gaugeVecGetter, recorder)
+	requeueInterval = nextHealthCheck(ss, time.Now(), requeueInterval)
+	if !healthy {
+		log.V(1).Info("store is degraded", "error", ss.GetStatus().HealthCheck.LastError)
+		return ctrl.Result{
+			RequeueAfter: requeueInterval,
+		}, nil
+	}
+
 	recorder.Event(ss, v1.EventTypeNormal, esapi.ReasonStoreValid, msgStoreValidated)
 	cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionTrue, esapi.ReasonStoreValid, msgStoreValidated)
 	SetExternalSecretCondition(ss, *cond, gaugeVecGetter)
@@ -90,26 +120,25 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 
 // validateStore tries to construct a new client
 // if it fails sets a condition and writes events.
-func validateStore(ctx context.Context, namespace, controllerClass string, store esapi.GenericStore,
-	client client.Client, gaugeVecGetter metrics.GaugeVevGetter, recorder record.EventRecorder) error {
-	mgr := NewManager(client, controllerClass, false)
-	defer mgr.Close(ctx)
+// The client is returned so it can be used for the health check.
+func validateStore(ctx context.Context, namespace string, store esapi.GenericStore,
+	mgr *Manager, gaugeVecGetter metrics.GaugeVevGetter, recorder record.EventRecorder) (esapi.SecretsClient, error) {
 	cl, err := mgr.GetFromStore(ctx, store, namespace)
 	if err != nil {
 		cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, esapi.ReasonInvalidProviderConfig, errUnableCreateClient)
 		SetExternalSecretCondition(store, *cond, gaugeVecGetter)
 		recorder.Event(store, v1.EventTypeWarning, esapi.ReasonInvalidProviderConfig, err.Error())
-		return fmt.Errorf(errStoreClient, err)
+		return nil, fmt.Errorf(errStoreClient, err)
 	}
 	validationResult, err := cl.Validate()
 	if err != nil && validationResult != esapi.ValidationResultUnknown {
 		cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, esapi.ReasonValidationFailed, errUnableValidateStore)
 		SetExternalSecretCondition(store, *cond, gaugeVecGetter)
 		recorder.Event(store, v1.EventTypeWarning, esapi.ReasonValidationFailed, err.Error())
-		return fmt.Errorf(errValidationFailed, err)
+		return nil, fmt.Errorf(errValidationFailed, err)
 	}
 
-	return nil
+	return cl, nil
 }
 
 // ShouldProcessStore returns true if the store should be processed.
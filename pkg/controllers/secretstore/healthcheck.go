@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/metrics"
+)
+
+const (
+	errHealthCheckFailed = "could not read canary key %s: %v"
+)
+
+// healthCheckInterval returns the interval of the health check.
+// It falls back to the refresh interval of the store if no interval is set.
+func healthCheckInterval(store esapi.GenericStore, refreshInterval time.Duration) time.Duration {
+	if hc := store.GetSpec().HealthCheck; hc != nil && hc.Interval != nil && hc.Interval.Duration != 0 {
+		return hc.Interval.Duration
+	}
+	return refreshInterval
+}
+
+// healthCheckDue returns true if the canary key has to be read in this reconcile.
+func healthCheckDue(store esapi.GenericStore, now time.Time, refreshInterval time.Duration) bool {
+	status := store.GetStatus().HealthCheck
+	if status == nil || status.LastCheckTime == nil || status.ObservedGeneration != store.GetGeneration() {
+		return true
+	}
+	interval := healthCheckInterval(store, refreshInterval)
+	if interval == 0 {
+		return false
+	}
+	return !now.Before(status.LastCheckTime.Add(interval))
+}
+
+// nextHealthCheck returns the requeue interval capped at the time left until the next health check.
+func nextHealthCheck(store esapi.GenericStore, now time.Time, requeueInterval time.Duration) time.Duration {
+	status := store.GetStatus().HealthCheck
+	interval := healthCheckInterval(store, requeueInterval)
+	if store.GetSpec().HealthCheck == nil || interval == 0 || status == nil || status.LastCheckTime == nil {
+		return requeueInterval
+	}
+	next := status.LastCheckTime.Add(interval).Sub(now)
+	if next <= 0 {
+		return time.Second
+	}
+	if requeueInterval == 0 || next < requeueInterval {
+		return next
+	}
+	return requeueInterval
+}
+
+// checkHealth reads the canary key of the store if the health check is due or forced
+// and records the result in the store status.
+// It returns the error of the last read, even if the canary key was not read in this reconcile.
+func checkHealth(ctx context.Context, cl esapi.SecretsClient, store esapi.GenericStore, now time.Time, refreshInterval time.Duration, force bool) error {
+	hc := store.GetSpec().HealthCheck
+	status := store.GetStatus()
+	if hc == nil {
+		status.HealthCheck = nil
+		store.SetStatus(status)
+		return nil
+	}
+	if !force && !healthCheckDue(store, now, refreshInterval) {
+		if status.HealthCheck.LastError != "" {
+			return errors.New(status.HealthCheck.LastError)
+		}
+		return nil
+	}
+
+	start := time.Now()
+	_, err := cl.GetSecret(ctx, hc.RemoteRef)
+	result := &esapi.SecretStoreHealthCheckStatus{
+		LastCheckTime:      &metav1.Time{Time: now},
+		Latency:            &metav1.Duration{Duration: time.Since(start)},
+		ObservedGeneration: store.GetGeneration(),
+	}
+	if err != nil {
+		err = fmt.Errorf(errHealthCheckFailed, hc.RemoteRef.Key, err)
+		result.LastError = err.Error()
+		result.ConsecutiveFailures = 1
+		if status.HealthCheck != nil {
+			result.ConsecutiveFailures = status.HealthCheck.ConsecutiveFailures + 1
+		}
+	}
+	status.HealthCheck = result
+	store.SetStatus(status)
+	return err
+}
+
+// checkStoreHealth runs the health check of the store and sets the Ready condition to
+// Degraded if the canary key can not be read. It returns true if the store is healthy.
+func checkStoreHealth(ctx context.Context, cl esapi.SecretsClient, store esapi.GenericStore, refreshInterval time.Duration, force bool,
+	gaugeVecGetter metrics.GaugeVevGetter, recorder record.EventRecorder) bool {
+	previous := store.GetStatus().HealthCheck
+	err := checkHealth(ctx, cl, store, time.Now(), refreshInterval, force)
+	if err == nil {
+		return true
+	}
+	cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, esapi.ReasonStoreDegraded, err.Error())
+	SetExternalSecretCondition(store, *cond, gaugeVecGetter)
+	if current := store.GetStatus().HealthCheck; current != previous {
+		recorder.Event(store, v1.EventTypeWarning, esapi.ReasonStoreDegraded, err.Error())
+	}
+	return false
+}
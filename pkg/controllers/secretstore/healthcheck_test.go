@@ -0,0 +1,258 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func makeHealthCheckStore(interval time.Duration, status *esv1beta1.SecretStoreHealthCheckStatus) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "foo",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: esv1beta1.SecretStoreSpec{
+			HealthCheck: &esv1beta1.SecretStoreHealthCheck{
+				RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "canary"},
+				Interval:  &metav1.Duration{Duration: interval},
+			},
+		},
+		Status: esv1beta1.SecretStoreStatus{
+			HealthCheck: status,
+		},
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	now := time.Now()
+	recent := &metav1.Time{Time: now.Add(-time.Second)}
+	old := &metav1.Time{Time: now.Add(-time.Hour)}
+
+	tests := []struct {
+		name         string
+		store        *esv1beta1.SecretStore
+		getErr       error
+		force        bool
+		wantErr      bool
+		wantChecked  bool
+		wantFailures int32
+	}{
+		{
+			name:        "reads the canary key on the first check",
+			store:       makeHealthCheckStore(time.Minute, nil),
+			wantChecked: true,
+		},
+		{
+			name:         "records a failed read",
+			store:        makeHealthCheckStore(time.Minute, nil),
+			getErr:       errors.New("access denied"),
+			wantErr:      true,
+			wantChecked:  true,
+			wantFailures: 1,
+		},
+		{
+			name: "counts consecutive failures",
+			store: makeHealthCheckStore(time.Minute, &esv1beta1.SecretStoreHealthCheckStatus{
+				LastCheckTime:       old,
+				LastError:           "access denied",
+				ConsecutiveFailures: 2,
+				ObservedGeneration:  1,
+			}),
+			getErr:       errors.New("access denied"),
+			wantErr:      true,
+			wantChecked:  true,
+			wantFailures: 3,
+		},
+		{
+			name: "resets failures after a successful read",
+			store: makeHealthCheckStore(time.Minute, &esv1beta1.SecretStoreHealthCheckStatus{
+				LastCheckTime:       old,
+				LastError:           "access denied",
+				ConsecutiveFailures: 2,
+				ObservedGeneration:  1,
+			}),
+			wantChecked: true,
+		},
+		{
+			name: "keeps the previous error until the next check is due",
+			store: makeHealthCheckStore(time.Minute, &esv1beta1.SecretStoreHealthCheckStatus{
+				LastCheckTime:       recent,
+				LastError:           "access denied",
+				ConsecutiveFailures: 1,
+				ObservedGeneration:  1,
+			}),
+			wantErr:      true,
+			wantFailures: 1,
+		},
+		{
+			name: "reads the canary key when forced",
+			store: makeHealthCheckStore(time.Minute, &esv1beta1.SecretStoreHealthCheckStatus{
+				LastCheckTime:       recent,
+				LastError:           "access denied",
+				ConsecutiveFailures: 1,
+				ObservedGeneration:  1,
+			}),
+			force:       true,
+			wantChecked: true,
+		},
+		{
+			name: "reads the canary key again if the store changed",
+			store: makeHealthCheckStore(time.Minute, &esv1beta1.SecretStoreHealthCheckStatus{
+				LastCheckTime:      recent,
+				ObservedGeneration: 0,
+			}),
+			wantChecked: true,
+		},
+		{
+			name: "falls back to the refresh interval without interval",
+			store: makeHealthCheckStore(0, &esv1beta1.SecretStoreHealthCheckStatus{
+				LastCheckTime:      recent,
+				ObservedGeneration: 1,
+			}),
+		},
+		{
+			name: "reads the canary key without interval once the refresh interval passed",
+			store: makeHealthCheckStore(0, &esv1beta1.SecretStoreHealthCheckStatus{
+				LastCheckTime:      old,
+				ObservedGeneration: 1,
+			}),
+			wantChecked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.New().WithGetSecret([]byte("ok"), tt.getErr)
+			previous := tt.store.Status.HealthCheck
+			err := checkHealth(context.Background(), cl, tt.store, now, time.Hour, tt.force)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			status := tt.store.Status.HealthCheck
+			if !assert.NotNil(t, status) {
+				return
+			}
+			assert.Equal(t, tt.wantChecked, status != previous)
+			assert.Equal(t, tt.wantFailures, status.ConsecutiveFailures)
+			if tt.wantChecked {
+				assert.Equal(t, now, status.LastCheckTime.Time)
+				assert.NotNil(t, status.Latency)
+			}
+		})
+	}
+}
+
+func TestCheckHealthDisabled(t *testing.T) {
+	store := makeHealthCheckStore(time.Minute, &esv1beta1.SecretStoreHealthCheckStatus{LastError: "access denied"})
+	store.Spec.HealthCheck = nil
+	err := checkHealth(context.Background(), fake.New(), store, time.Now(), time.Hour, false)
+	assert.NoError(t, err)
+	assert.Nil(t, store.Status.HealthCheck)
+}
+
+func TestNextHealthCheck(t *testing.T) {
+	now := time.Now()
+	store := makeHealthCheckStore(time.Minute, &esv1beta1.SecretStoreHealthCheckStatus{
+		LastCheckTime: &metav1.Time{Time: now.Add(-20 * time.Second)},
+	})
+	assert.Equal(t, 40*time.Second, nextHealthCheck(store, now, time.Hour))
+	assert.Equal(t, 10*time.Second, nextHealthCheck(store, now, 10*time.Second))
+
+	store.Spec.HealthCheck.Interval = nil
+	assert.Equal(t, time.Hour-20*time.Second, nextHealthCheck(store, now, time.Hour))
+
+	store.Spec.HealthCheck = nil
+	assert.Equal(t, time.Hour, nextHealthCheck(store, now, time.Hour))
+}
+
+func TestReconcileUnchangedStoreDoesNotPatchStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+
+	esv1beta1.ForceRegister(&WrapProvider{
+		newClientFunc: func(context.Context, esv1beta1.GenericStore, client.Client, string) (esv1beta1.SecretsClient, error) {
+			return &MockFakeClient{id: "1"}, nil
+		},
+	}, &esv1beta1.SecretStoreProvider{
+		AWS: &esv1beta1.AWSProvider{},
+	})
+
+	store := makeHealthCheckStore(0, nil)
+	store.TypeMeta = metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind}
+	store.Spec.Provider = &esv1beta1.SecretStoreProvider{AWS: &esv1beta1.AWSProvider{}}
+	statusPatches := 0
+	kube := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(store).
+		WithStatusSubresource(store).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				statusPatches++
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test"}, ctrlmetrics.ConditionMetricLabelNames)
+	gaugeVecGetter := func(string) *prometheus.GaugeVec { return gauge }
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: store.Name, Namespace: store.Namespace}}
+
+	for i := 0; i < 2; i++ {
+		var ss esv1beta1.SecretStore
+		require.NoError(t, kube.Get(context.Background(), req.NamespacedName, &ss))
+		_, err := reconcile(context.Background(), req, &ss, kube, logr.Discard(), "", gaugeVecGetter, record.NewFakeRecorder(10), time.Hour, false)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, statusPatches, "only the first reconcile changes the status")
+}
+
+func TestAssertStoreIsUsableDegraded(t *testing.T) {
+	store := makeHealthCheckStore(time.Minute, nil)
+	store.Status.Conditions = []esv1beta1.SecretStoreStatusCondition{
+		{
+			Type:    esv1beta1.SecretStoreReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  esv1beta1.ReasonStoreDegraded,
+			Message: "could not read canary key canary: access denied",
+		},
+	}
+	err := assertStoreIsUsable(store)
+	assert.EqualError(t, err, "the desired SecretStore foo is degraded: could not read canary key canary: access denied")
+}
@@ -0,0 +1,205 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/cache"
+)
+
+// storeReferencesKey indexes stores by the Secrets, ServiceAccounts and ConfigMaps their provider references.
+const storeReferencesKey = "spec.provider.references"
+
+const (
+	referenceKindSecret         = "Secret"
+	referenceKindConfigMap      = "ConfigMap"
+	referenceKindServiceAccount = "ServiceAccount"
+)
+
+var (
+	secretKeySelectorType      = reflect.TypeOf(esmeta.SecretKeySelector{})
+	serviceAccountSelectorType = reflect.TypeOf(esmeta.ServiceAccountSelector{})
+	caProviderType             = reflect.TypeOf(esapi.CAProvider{})
+)
+
+// referencedObjects are the kinds of objects a store provider may reference.
+var referencedObjects = map[string]client.Object{
+	referenceKindSecret:         &v1.Secret{},
+	referenceKindConfigMap:      &v1.ConfigMap{},
+	referenceKindServiceAccount: &v1.ServiceAccount{},
+}
+
+func referenceKey(kind, namespace, name string) string {
+	return kind + "/" + types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+// storeReferences returns the index keys of the objects referenced by the provider of the store.
+// References of a ClusterSecretStore without namespace are resolved in the namespace
+// of the ExternalSecret and can not be indexed.
+func storeReferences(store esapi.GenericStore) []string {
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil {
+		return nil
+	}
+	refs := make(map[string]struct{})
+	walkReferences(reflect.ValueOf(spec.Provider), func(kind, name string, namespace *string) {
+		ns := store.GetNamespace()
+		if store.GetKind() == esapi.ClusterSecretStoreKind {
+			if namespace == nil {
+				return
+			}
+			ns = *namespace
+		}
+		refs[referenceKey(kind, ns, name)] = struct{}{}
+	})
+	keys := make([]string, 0, len(refs))
+	for key := range refs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// walkReferences calls fn for every Secret, ServiceAccount and ConfigMap selector found in v.
+func walkReferences(v reflect.Value, fn func(kind, name string, namespace *string)) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkReferences(v.Elem(), fn)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkReferences(v.Index(i), fn)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkReferences(iter.Value(), fn)
+		}
+	case reflect.Struct:
+		switch v.Type() {
+		case secretKeySelectorType:
+			if sel := v.Interface().(esmeta.SecretKeySelector); sel.Name != "" {
+				fn(referenceKindSecret, sel.Name, sel.Namespace)
+			}
+			return
+		case serviceAccountSelectorType:
+			if sel := v.Interface().(esmeta.ServiceAccountSelector); sel.Name != "" {
+				fn(referenceKindServiceAccount, sel.Name, sel.Namespace)
+			}
+			return
+		case caProviderType:
+			ca := v.Interface().(esapi.CAProvider)
+			kind := referenceKindConfigMap
+			if ca.Type == esapi.CAProviderTypeSecret {
+				kind = referenceKindSecret
+			}
+			if ca.Name != "" {
+				fn(kind, ca.Name, ca.Namespace)
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				walkReferences(v.Field(i), fn)
+			}
+		}
+	default:
+	}
+}
+
+// watchStoreReferences reconciles the stores returned by find
+// when an object referenced by their provider changes.
+func watchStoreReferences(b *builder.Builder, find func(kind string) handler.MapFunc) *builder.Builder {
+	for kind, obj := range referencedObjects {
+		b = b.Watches(
+			obj,
+			handler.EnqueueRequestsFromMapFunc(find(kind)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+			builder.OnlyMetadata,
+		)
+	}
+	return b
+}
+
+// changedStores tracks the stores whose referenced objects changed since their last reconcile.
+type changedStores struct {
+	stores sync.Map
+}
+
+// requests marks the stores as changed and returns the reconcile requests for them.
+func (c *changedStores) requests(stores []esapi.GenericStore) []ctrl.Request {
+	requests := make([]ctrl.Request, 0, len(stores))
+	for _, store := range stores {
+		key := types.NamespacedName{Namespace: store.GetNamespace(), Name: store.GetName()}
+		c.stores.Store(key, struct{}{})
+		requests = append(requests, ctrl.Request{NamespacedName: key})
+	}
+	return requests
+}
+
+// pop returns true if the referenced objects of the store changed and resets the mark.
+func (c *changedStores) pop(key types.NamespacedName) bool {
+	_, ok := c.stores.LoadAndDelete(key)
+	return ok
+}
+
+// invalidateCachedClients drops the provider clients cached for the store,
+// e.g. because the credentials they were created with rotated.
+func invalidateCachedClients(store esapi.GenericStore) {
+	cache.Invalidate(func(key cache.Key) bool {
+		if key.Kind != store.GetKind() || key.Name != store.GetName() {
+			return false
+		}
+		// clients of a ClusterSecretStore may be cached per namespace
+		return store.GetKind() == esapi.ClusterSecretStoreKind || key.Namespace == store.GetNamespace()
+	})
+}
+
+// findStoresFunc returns a MapFunc which lists the stores referencing an object of the given kind.
+func findStoresFunc(changed *changedStores, list func(ctx context.Context, opts ...client.ListOption) ([]esapi.GenericStore, error)) func(kind string) handler.MapFunc {
+	return func(kind string) handler.MapFunc {
+		return func(ctx context.Context, obj client.Object) []ctrl.Request {
+			stores, err := list(ctx, client.MatchingFields{storeReferencesKey: referenceKey(kind, obj.GetNamespace(), obj.GetName())})
+			if err != nil {
+				return []ctrl.Request{}
+			}
+			return changed.requests(stores)
+		}
+	}
+}
+
+// indexStoreReferences is the IndexerFunc of storeReferencesKey.
+func indexStoreReferences(obj client.Object) []string {
+	store, ok := obj.(esapi.GenericStore)
+	if !ok {
+		return nil
+	}
+	return storeReferences(store)
+}
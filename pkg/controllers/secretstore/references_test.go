@@ -0,0 +1,139 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/cache"
+)
+
+func TestStoreReferences(t *testing.T) {
+	vaultProvider := &esv1beta1.SecretStoreProvider{
+		Vault: &esv1beta1.VaultProvider{
+			CAProvider: &esv1beta1.CAProvider{
+				Type: esv1beta1.CAProviderTypeConfigMap,
+				Name: "vault-ca",
+				Key:  "ca.crt",
+			},
+			Auth: esv1beta1.VaultAuth{
+				TokenSecretRef: &esmeta.SecretKeySelector{Name: "vault-token", Key: "token"},
+				Kubernetes: &esv1beta1.VaultKubernetesAuth{
+					ServiceAccountRef: &esmeta.ServiceAccountSelector{Name: "vault-sa", Namespace: ptr.To("auth")},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name  string
+		store esv1beta1.GenericStore
+		want  []string
+	}{
+		{
+			name: "secret store references in its namespace",
+			store: &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "foo"},
+				Spec:       esv1beta1.SecretStoreSpec{Provider: vaultProvider},
+			},
+			want: []string{
+				"ConfigMap/foo/vault-ca",
+				"Secret/foo/vault-token",
+				"ServiceAccount/foo/vault-sa",
+			},
+		},
+		{
+			name: "cluster secret store skips references without namespace",
+			store: &esv1beta1.ClusterSecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault"},
+				Spec:       esv1beta1.SecretStoreSpec{Provider: vaultProvider},
+			},
+			want: []string{
+				"ServiceAccount/auth/vault-sa",
+			},
+		},
+		{
+			name: "aws secret ref",
+			store: &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "foo"},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						AWS: &esv1beta1.AWSProvider{
+							Auth: esv1beta1.AWSAuth{
+								SecretRef: &esv1beta1.AWSAuthSecretRef{
+									AccessKeyID:     esmeta.SecretKeySelector{Name: "aws-creds", Key: "id"},
+									SecretAccessKey: esmeta.SecretKeySelector{Name: "aws-creds", Key: "secret"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []string{
+				"Secret/foo/aws-creds",
+			},
+		},
+		{
+			name: "no references",
+			store: &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "foo"},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+				},
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, storeReferences(tt.store))
+		})
+	}
+}
+
+func TestChangedStores(t *testing.T) {
+	var changed changedStores
+	store := &esv1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "foo"}}
+	requests := changed.requests([]esv1beta1.GenericStore{store})
+	key := types.NamespacedName{Name: "vault", Namespace: "foo"}
+
+	assert.Len(t, requests, 1)
+	assert.Equal(t, key, requests[0].NamespacedName)
+	assert.True(t, changed.pop(key))
+	assert.False(t, changed.pop(key))
+}
+
+func TestInvalidateCachedClients(t *testing.T) {
+	c := cache.Must[string](10, nil)
+	c.Add("1", cache.Key{Name: "vault", Namespace: "foo", Kind: esv1beta1.SecretStoreKind}, "foo")
+	c.Add("1", cache.Key{Name: "vault", Namespace: "bar", Kind: esv1beta1.SecretStoreKind}, "bar")
+	c.Add("1", cache.Key{Name: "vault", Namespace: "foo", Kind: esv1beta1.ClusterSecretStoreKind}, "cluster-foo")
+	c.Add("1", cache.Key{Name: "vault", Namespace: "bar", Kind: esv1beta1.ClusterSecretStoreKind}, "cluster-bar")
+
+	invalidateCachedClients(&esv1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "foo"}})
+	assert.False(t, c.Contains(cache.Key{Name: "vault", Namespace: "foo", Kind: esv1beta1.SecretStoreKind}))
+	assert.True(t, c.Contains(cache.Key{Name: "vault", Namespace: "bar", Kind: esv1beta1.SecretStoreKind}))
+	assert.True(t, c.Contains(cache.Key{Name: "vault", Namespace: "foo", Kind: esv1beta1.ClusterSecretStoreKind}))
+
+	invalidateCachedClients(&esv1beta1.ClusterSecretStore{ObjectMeta: metav1.ObjectMeta{Name: "vault"}})
+	assert.False(t, c.Contains(cache.Key{Name: "vault", Namespace: "foo", Kind: esv1beta1.ClusterSecretStoreKind}))
+	assert.False(t, c.Contains(cache.Key{Name: "vault", Namespace: "bar", Kind: esv1beta1.ClusterSecretStoreKind}))
+}
@@ -0,0 +1,98 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// restrictedClient enforces the access rules of a ClusterSecretStore
+// for the namespace the client was requested for.
+type restrictedClient struct {
+	esv1beta1.SecretsClient
+	access *esv1beta1.KeyAccess
+}
+
+var _ esv1beta1.SecretsClient = &restrictedClient{}
+
+// newRestrictedClient renders the access rules for the namespace once for the lifetime of the client.
+func newRestrictedClient(secretClient esv1beta1.SecretsClient, namespace metav1.ObjectMeta, rules []esv1beta1.ClusterSecretStoreAccess) (*restrictedClient, error) {
+	access, err := esv1beta1.NewKeyAccess(rules, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &restrictedClient{
+		SecretsClient: secretClient,
+		access:        access,
+	}, nil
+}
+
+func (c *restrictedClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if err := c.access.CheckKey(ref.Key); err != nil {
+		return nil, err
+	}
+	return c.SecretsClient.GetSecret(ctx, ref)
+}
+
+func (c *restrictedClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if err := c.access.CheckKey(ref.Key); err != nil {
+		return nil, err
+	}
+	return c.SecretsClient.GetSecretMap(ctx, ref)
+}
+
+// GetAllSecrets only returns the secrets whose keys are allowed,
+// as not every provider restricts the secrets it finds to ref.Path.
+func (c *restrictedClient) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if err := c.access.CheckFind(ref); err != nil {
+		return nil, err
+	}
+	secrets, err := c.SecretsClient.GetAllSecrets(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	for key := range secrets {
+		if c.access.CheckKey(key) != nil {
+			delete(secrets, key)
+		}
+	}
+	return secrets, nil
+}
+
+func (c *restrictedClient) PushSecret(ctx context.Context, secret *v1.Secret, data esv1beta1.PushSecretData) error {
+	if err := c.access.CheckKey(data.GetRemoteKey()); err != nil {
+		return err
+	}
+	return c.SecretsClient.PushSecret(ctx, secret, data)
+}
+
+func (c *restrictedClient) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	if err := c.access.CheckKey(remoteRef.GetRemoteKey()); err != nil {
+		return err
+	}
+	return c.SecretsClient.DeleteSecret(ctx, remoteRef)
+}
+
+func (c *restrictedClient) SecretExists(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) (bool, error) {
+	if err := c.access.CheckKey(remoteRef.GetRemoteKey()); err != nil {
+		return false, err
+	}
+	return c.SecretsClient.SecretExists(ctx, remoteRef)
+}
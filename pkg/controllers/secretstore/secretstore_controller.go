@@ -20,11 +20,14 @@ import (
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
@@ -42,6 +45,7 @@ type StoreReconciler struct {
 	recorder        record.EventRecorder
 	RequeueInterval time.Duration
 	ControllerClass string
+	changed         changedStores
 }
 
 func (r *StoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -57,21 +61,41 @@ func (r *StoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	err := r.Get(ctx, req.NamespacedName, &ss)
 	if apierrors.IsNotFound(err) {
 		ssmetrics.RemoveMetrics(req.Namespace, req.Name)
+		// drops the cached clients and credentials of the deleted store
+		invalidateCachedClients(&esapi.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace}})
 		return ctrl.Result{}, nil
 	} else if err != nil {
 		log.Error(err, "unable to get SecretStore")
 		return ctrl.Result{}, err
 	}
 
-	return reconcile(ctx, req, &ss, r.Client, log, r.ControllerClass, ssmetrics.GetGaugeVec, r.recorder, r.RequeueInterval)
+	return reconcile(ctx, req, &ss, r.Client, log, r.ControllerClass, ssmetrics.GetGaugeVec, r.recorder, r.RequeueInterval, r.changed.pop(req.NamespacedName))
 }
 
 // SetupWithManager returns a new controller builder that will be started by the provided Manager.
 func (r *StoreReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	r.recorder = mgr.GetEventRecorderFor("secret-store")
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Index the objects referenced by the provider to revalidate stores when credentials rotate
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &esapi.SecretStore{}, storeReferencesKey, indexStoreReferences); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
-		For(&esapi.SecretStore{}).
+		For(&esapi.SecretStore{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	return watchStoreReferences(b, findStoresFunc(&r.changed, r.listStores)).
 		Complete(r)
 }
+
+func (r *StoreReconciler) listStores(ctx context.Context, opts ...client.ListOption) ([]esapi.GenericStore, error) {
+	var list esapi.SecretStoreList
+	if err := r.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	stores := make([]esapi.GenericStore, 0, len(list.Items))
+	for i := range list.Items {
+		stores = append(stores, &list.Items[i])
+	}
+	return stores, nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	vault "github.com/hashicorp/vault/api"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -29,24 +30,66 @@ import (
 
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	provider "github.com/external-secrets/external-secrets/pkg/provider/vault"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/util"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 type Generator struct{}
 
+var _ genv1alpha1.LeasingGenerator = &Generator{}
+
 const (
 	errNoSpec      = "no config spec provided"
 	errParseSpec   = "unable to parse spec: %w"
 	errVaultClient = "unable to setup Vault client: %w"
 	errGetSecret   = "unable to get dynamic secret: %w"
+	errRenewLease  = "unable to renew lease %s: %w"
+	errRevokeLease = "unable to revoke lease %s: %w"
+
+	pathRenewLease  = "sys/leases/renew"
+	pathRevokeLease = "sys/leases/revoke"
 )
 
 func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
-	c := &provider.Provider{NewVaultClient: provider.NewVaultClient}
+	data, _, err := g.GenerateWithLease(ctx, jsonSpec, kube, namespace)
+	return data, err
+}
 
-	// controller-runtime/client does not support TokenRequest or other subresource APIs
-	// so we need to construct our own client and use it to fetch tokens
-	// (for Kubernetes service account token auth)
+// GenerateWithLease returns the dynamic secret and the lease of it, if Vault issued one.
+func (g *Generator) GenerateWithLease(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, *genv1alpha1.Lease, error) {
+	corev1, err := newCoreV1Client()
+	if err != nil {
+		return nil, nil, err
+	}
+	return g.generate(ctx, newProvider(), jsonSpec, kube, corev1, namespace)
+}
+
+// RenewLease renews the lease of a dynamic secret.
+func (g *Generator) RenewLease(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace, id string) (*genv1alpha1.Lease, error) {
+	corev1, err := newCoreV1Client()
+	if err != nil {
+		return nil, err
+	}
+	return g.renewLease(ctx, newProvider(), jsonSpec, kube, corev1, namespace, id)
+}
+
+// RevokeLease revokes the lease of a dynamic secret.
+func (g *Generator) RevokeLease(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace, id string) error {
+	corev1, err := newCoreV1Client()
+	if err != nil {
+		return err
+	}
+	return g.revokeLease(ctx, newProvider(), jsonSpec, kube, corev1, namespace, id)
+}
+
+func newProvider() *provider.Provider {
+	return &provider.Provider{NewVaultClient: provider.NewVaultClient}
+}
+
+// controller-runtime/client does not support TokenRequest or other subresource APIs
+// so we need to construct our own client and use it to fetch tokens
+// (for Kubernetes service account token auth).
+func newCoreV1Client() (typedcorev1.CoreV1Interface, error) {
 	restCfg, err := ctrlcfg.GetConfig()
 	if err != nil {
 		return nil, err
@@ -55,24 +98,33 @@ func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON,
 	if err != nil {
 		return nil, err
 	}
-
-	return g.generate(ctx, c, jsonSpec, kube, clientset.CoreV1(), namespace)
+	return clientset.CoreV1(), nil
 }
 
-func (g *Generator) generate(ctx context.Context, c *provider.Provider, jsonSpec *apiextensions.JSON, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace string) (map[string][]byte, error) {
+// newClient returns a client logged in to Vault and a function which revokes the token of the login.
+func newClient(ctx context.Context, c *provider.Provider, jsonSpec *apiextensions.JSON, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace string) (util.Client, func(context.Context) error, *genv1alpha1.VaultDynamicSecret, error) {
 	if jsonSpec == nil {
-		return nil, fmt.Errorf(errNoSpec)
+		return nil, nil, nil, fmt.Errorf(errNoSpec)
 	}
 	res, err := parseSpec(jsonSpec.Raw)
 	if err != nil {
-		return nil, fmt.Errorf(errParseSpec, err)
+		return nil, nil, nil, fmt.Errorf(errParseSpec, err)
 	}
 	if res == nil || res.Spec.Provider == nil {
-		return nil, fmt.Errorf("no Vault provider config in spec")
+		return nil, nil, nil, fmt.Errorf("no Vault provider config in spec")
+	}
+	cl, revoke, err := c.NewGeneratorClient(ctx, kube, corev1, res.Spec.Provider, namespace)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(errVaultClient, err)
 	}
-	cl, err := c.NewGeneratorClient(ctx, kube, corev1, res.Spec.Provider, namespace)
+	return cl, revoke, res, nil
+}
+
+func (g *Generator) generate(ctx context.Context, c *provider.Provider, jsonSpec *apiextensions.JSON, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace string) (map[string][]byte, *genv1alpha1.Lease, error) {
+	// the token is not revoked, Vault would revoke the lease of the generated secret along with it
+	cl, _, res, err := newClient(ctx, c, jsonSpec, kube, corev1, namespace)
 	if err != nil {
-		return nil, fmt.Errorf(errVaultClient, err)
+		return nil, nil, err
 	}
 
 	var result *vault.Secret
@@ -87,16 +139,16 @@ func (g *Generator) generate(ctx context.Context, c *provider.Provider, jsonSpec
 		if res.Spec.Parameters != nil {
 			err = json.Unmarshal(res.Spec.Parameters.Raw, &params)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 		result, err = cl.Logical().WriteWithContext(ctx, res.Spec.Path, params)
 	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if result == nil {
-		return nil, fmt.Errorf(errGetSecret, fmt.Errorf("empty response from Vault"))
+		return nil, nil, fmt.Errorf(errGetSecret, fmt.Errorf("empty response from Vault"))
 	}
 
 	data := make(map[string]any)
@@ -104,11 +156,11 @@ func (g *Generator) generate(ctx context.Context, c *provider.Provider, jsonSpec
 	if res.Spec.ResultType == genv1alpha1.VaultDynamicSecretResultTypeAuth {
 		authJSON, err := json.Marshal(result.Auth)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		err = json.Unmarshal(authJSON, &data)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	} else {
 		data = result.Data
@@ -117,10 +169,54 @@ func (g *Generator) generate(ctx context.Context, c *provider.Provider, jsonSpec
 	for k := range data {
 		response[k], err = utils.GetByteValueFromMap(data, k)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
-	return response, nil
+	return response, leaseOf(result), nil
+}
+
+func (g *Generator) renewLease(ctx context.Context, c *provider.Provider, jsonSpec *apiextensions.JSON, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace, id string) (*genv1alpha1.Lease, error) {
+	cl, revoke, _, err := newClient(ctx, c, jsonSpec, kube, corev1, namespace)
+	if err != nil {
+		return nil, err
+	}
+	// the token of the login did not create the lease and is not used afterwards,
+	// if it can not be revoked it expires with its TTL
+	defer func() { _ = revoke(ctx) }()
+	// https://developer.hashicorp.com/vault/api-docs/system/leases#renew-lease
+	result, err := cl.Logical().WriteWithContext(ctx, pathRenewLease, map[string]any{"lease_id": id})
+	if err != nil {
+		return nil, fmt.Errorf(errRenewLease, id, err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf(errRenewLease, id, fmt.Errorf("empty response from Vault"))
+	}
+	return leaseOf(result), nil
+}
+
+func (g *Generator) revokeLease(ctx context.Context, c *provider.Provider, jsonSpec *apiextensions.JSON, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace, id string) error {
+	cl, revoke, _, err := newClient(ctx, c, jsonSpec, kube, corev1, namespace)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = revoke(ctx) }()
+	// https://developer.hashicorp.com/vault/api-docs/system/leases#revoke-lease
+	if _, err := cl.Logical().WriteWithContext(ctx, pathRevokeLease, map[string]any{"lease_id": id}); err != nil {
+		return fmt.Errorf(errRevokeLease, id, err)
+	}
+	return nil
+}
+
+// leaseOf returns the lease of the secret, tokens returned with the auth result type are not leased.
+func leaseOf(secret *vault.Secret) *genv1alpha1.Lease {
+	if secret.LeaseID == "" {
+		return nil
+	}
+	return &genv1alpha1.Lease{
+		ID:        secret.LeaseID,
+		Duration:  time.Duration(secret.LeaseDuration) * time.Second,
+		Renewable: secret.Renewable,
+	}
 }
 
 func parseSpec(data []byte) (*genv1alpha1.VaultDynamicSecret, error) {
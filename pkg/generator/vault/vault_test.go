@@ -19,8 +19,12 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	vault "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,9 +32,11 @@ import (
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	utilfake "github.com/external-secrets/external-secrets/pkg/provider/util/fake"
 	provider "github.com/external-secrets/external-secrets/pkg/provider/vault"
 	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/util"
 )
 
 type args struct {
@@ -168,7 +174,7 @@ spec:
 		t.Run(name, func(t *testing.T) {
 			c := &provider.Provider{NewVaultClient: fake.ClientWithLoginMock}
 			gen := &Generator{}
-			val, err := gen.generate(context.Background(), c, tc.args.jsonSpec, tc.args.kube, tc.args.corev1, "testing")
+			val, _, err := gen.generate(context.Background(), c, tc.args.jsonSpec, tc.args.kube, tc.args.corev1, "testing")
 			if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
 				t.Errorf("\n%s\nvault.GetSecret(...): -want error, +got error:\n%s", tc.reason, diff)
 			}
@@ -178,3 +184,78 @@ spec:
 		})
 	}
 }
+
+func TestVaultDynamicSecretLease(t *testing.T) {
+	writes := map[string]map[string]any{}
+	logical := fake.Logical{
+		ReadWithDataWithContextFn: func(ctx context.Context, path string, data map[string][]string) (*vault.Secret, error) {
+			return &vault.Secret{
+				LeaseID:       "database/creds/app/abc",
+				LeaseDuration: 3600,
+				Renewable:     true,
+				Data:          map[string]any{"username": "app-abc", "password": "s3cr3t"},
+			}, nil
+		},
+		WriteWithContextFn: func(ctx context.Context, path string, data map[string]any) (*vault.Secret, error) {
+			writes[path] = data
+			if path == pathRevokeLease {
+				return nil, nil
+			}
+			return &vault.Secret{LeaseID: "database/creds/app/abc", LeaseDuration: 600}, nil
+		},
+	}
+	revoked := 0
+	token := fake.Token{
+		LookupSelfWithContextFn: func(ctx context.Context) (*vault.Secret, error) {
+			return &vault.Secret{Data: map[string]any{"type": "service"}}, nil
+		},
+		RevokeSelfWithContextFn: func(ctx context.Context, token string) error {
+			revoked++
+			return nil
+		},
+	}
+	c := &provider.Provider{NewVaultClient: func(config *vault.Config) (util.Client, error) {
+		cl, err := fake.ClientWithLoginMock(config)
+		if err != nil {
+			return nil, err
+		}
+		cl.(*util.VaultClient).LogicalField = logical
+		cl.(*util.VaultClient).AuthTokenField = token
+		cl.(*util.VaultClient).TokenFunc = func() string { return "login-token" }
+		cl.(*util.VaultClient).ClearTokenFunc = func() {}
+		return cl, nil
+	}}
+	jsonSpec := &apiextensions.JSON{Raw: []byte(`apiVersion: generators.external-secrets.io/v1alpha1
+kind: VaultDynamicSecret
+spec:
+  provider:
+    auth:
+      kubernetes:
+        role: test
+        serviceAccountRef:
+          name: "testing"
+  path: "database/creds/app"`)}
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "testing", Namespace: "testing"},
+	}).Build()
+	corev1Client := utilfake.NewCreateTokenMock().WithToken("ok")
+	gen := &Generator{}
+
+	val, lease, err := gen.generate(context.Background(), c, jsonSpec, kube, corev1Client, "testing")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"username": []byte("app-abc"), "password": []byte("s3cr3t")}, val)
+	assert.Equal(t, &genv1alpha1.Lease{ID: "database/creds/app/abc", Duration: time.Hour, Renewable: true}, lease)
+	// the token which created the lease is kept, the lease would be revoked along with it
+	assert.Equal(t, 0, revoked)
+
+	// the lease reached its max TTL, it is no longer renewable
+	lease, err = gen.renewLease(context.Background(), c, jsonSpec, kube, corev1Client, "testing", "database/creds/app/abc")
+	require.NoError(t, err)
+	assert.Equal(t, &genv1alpha1.Lease{ID: "database/creds/app/abc", Duration: 10 * time.Minute}, lease)
+	assert.Equal(t, map[string]any{"lease_id": "database/creds/app/abc"}, writes[pathRenewLease])
+	assert.Equal(t, 1, revoked)
+
+	require.NoError(t, gen.revokeLease(context.Background(), c, jsonSpec, kube, corev1Client, "testing", "database/creds/app/abc"))
+	assert.Equal(t, map[string]any{"lease_id": "database/creds/app/abc"}, writes[pathRevokeLease])
+	assert.Equal(t, 2, revoked)
+}
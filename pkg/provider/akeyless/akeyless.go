@@ -95,6 +95,16 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadOnly
 }
 
+// Features returns the optional features the provider supports.
+func (p *Provider) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{
+		FindByName: true,
+		FindByTags: true,
+		FindByPath: true,
+		Versions:   true,
+	}
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	// controller-runtime/client does not support TokenRequest or other subresource APIs
@@ -132,6 +132,13 @@ func (kms *KeyManagementService) Capabilities() esv1beta1.SecretStoreCapabilitie
 	return esv1beta1.SecretStoreReadOnly
 }
 
+// Features returns the optional features the provider supports.
+func (kms *KeyManagementService) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{
+		Versions: true,
+	}
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (kms *KeyManagementService) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	storeSpec := store.GetSpec()
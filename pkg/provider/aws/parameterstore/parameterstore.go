@@ -133,8 +133,25 @@ func (pm *ParameterStore) DeleteSecret(ctx context.Context, remoteRef esv1beta1.
 	return nil
 }
 
-func (pm *ParameterStore) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
-	return false, fmt.Errorf("not implemented")
+// SecretExists checks if the parameter exists.
+// If a property is set, the parameter value has to be a JSON object containing the property.
+func (pm *ParameterStore) SecretExists(ctx context.Context, ref esv1beta1.PushSecretRemoteRef) (bool, error) {
+	out, err := pm.getParameterValue(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: ref.GetRemoteKey()})
+	metrics.ObserveAPICall(constants.ProviderAWSPS, constants.CallAWSPSGetParameter, err)
+	var awsError awserr.Error
+	if errors.As(err, &awsError) && awsError.Code() == ssm.ErrCodeParameterNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, util.SanitizeErr(err)
+	}
+	if ref.GetProperty() == "" {
+		return true, nil
+	}
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return false, nil
+	}
+	return getPropertyValue(*out.Parameter.Value, ref.GetProperty()).Exists(), nil
 }
 
 func (pm *ParameterStore) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
@@ -426,21 +443,25 @@ func (pm *ParameterStore) GetSecret(ctx context.Context, ref esv1beta1.ExternalS
 		}
 		return nil, fmt.Errorf("invalid secret received. parameter value is nil for key: %s", ref.Key)
 	}
-	idx := strings.Index(ref.Property, ".")
-	if idx > -1 {
-		refProperty := strings.ReplaceAll(ref.Property, ".", "\\.")
-		val := gjson.Get(*out.Parameter.Value, refProperty)
-		if val.Exists() {
-			return []byte(val.String()), nil
-		}
-	}
-	val := gjson.Get(*out.Parameter.Value, ref.Property)
+	val := getPropertyValue(*out.Parameter.Value, ref.Property)
 	if !val.Exists() {
 		return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
 	}
 	return []byte(val.String()), nil
 }
 
+// getPropertyValue returns the property of a JSON parameter value.
+// Properties containing dots are looked up as literal key first.
+func getPropertyValue(value, property string) gjson.Result {
+	if strings.Contains(property, ".") {
+		val := gjson.Get(value, strings.ReplaceAll(property, ".", "\\."))
+		if val.Exists() {
+			return val
+		}
+	}
+	return gjson.Get(value, property)
+}
+
 func (pm *ParameterStore) getParameterTags(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (*ssm.GetParameterOutput, error) {
 	param := ssm.GetParameterOutput{
 		Parameter: &ssm.Parameter{
@@ -261,6 +261,78 @@ func TestDeleteSecret(t *testing.T) {
 		})
 	}
 }
+func TestSecretExists(t *testing.T) {
+	parameterValue := `{"foo":"bar","nested.key":"value"}`
+	tests := map[string]struct {
+		ref                fake.PushSecretData
+		getParameterOutput *ssm.GetParameterOutput
+		getParameterError  error
+		want               bool
+		wantErr            error
+	}{
+		"parameter exists": {
+			ref: fake.PushSecretData{RemoteKey: "fake-key"},
+			getParameterOutput: &ssm.GetParameterOutput{
+				Parameter: &ssm.Parameter{Value: &parameterValue},
+			},
+			want: true,
+		},
+		"parameter does not exist": {
+			ref:               fake.PushSecretData{RemoteKey: "fake-key"},
+			getParameterError: awserr.New(ssm.ErrCodeParameterNotFound, "not here, sorry dude", nil),
+			want:              false,
+		},
+		"property exists": {
+			ref: fake.PushSecretData{RemoteKey: "fake-key", Property: "foo"},
+			getParameterOutput: &ssm.GetParameterOutput{
+				Parameter: &ssm.Parameter{Value: &parameterValue},
+			},
+			want: true,
+		},
+		"property with dot exists": {
+			ref: fake.PushSecretData{RemoteKey: "fake-key", Property: "nested.key"},
+			getParameterOutput: &ssm.GetParameterOutput{
+				Parameter: &ssm.Parameter{Value: &parameterValue},
+			},
+			want: true,
+		},
+		"property does not exist": {
+			ref: fake.PushSecretData{RemoteKey: "fake-key", Property: "baz"},
+			getParameterOutput: &ssm.GetParameterOutput{
+				Parameter: &ssm.Parameter{Value: &parameterValue},
+			},
+			want: false,
+		},
+		"no permissions to get parameter": {
+			ref:               fake.PushSecretData{RemoteKey: "fake-key"},
+			getParameterError: errors.New("no permissions"),
+			wantErr:           errors.New("no permissions"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := fakeps.Client{}
+			client.GetParameterWithContextFn = fakeps.NewGetParameterWithContextFn(tc.getParameterOutput, tc.getParameterError)
+			ps := ParameterStore{
+				client: &client,
+			}
+			got, err := ps.SecretExists(context.TODO(), tc.ref)
+			if tc.wantErr != nil {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr.Error()) {
+					t.Errorf("SecretExists() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SecretExists() unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("SecretExists() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestPushSecret(t *testing.T) {
 	invalidParameters := errors.New(ssm.ErrCodeInvalidParameters)
 	alreadyExistsError := errors.New(ssm.ErrCodeAlreadyExistsException)
@@ -55,6 +55,30 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// Features returns the optional features of the AWS service the store uses.
+func (p *Provider) Features(store esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	features := esv1beta1.SecretStoreFeatures{
+		FindByName:    true,
+		FindByTags:    true,
+		FindByPath:    true,
+		Versions:      true,
+		MetadataFetch: true,
+		DeleteSecret:  true,
+		SecretExists:  true,
+	}
+	prov, err := util.GetAWSProvider(store)
+	if err != nil {
+		return features
+	}
+	switch prov.Service {
+	case esv1beta1.AWSServiceSecretsManager:
+		features.PushMetadata = true
+	case esv1beta1.AWSServiceParameterStore:
+		features.PushWholeSecret = true
+	}
+	return features
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	return newClient(ctx, store, kube, namespace, awsauth.DefaultSTSProvider)
@@ -540,3 +540,25 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestFeatures(t *testing.T) {
+	p := &Provider{}
+	makeStore := func(service esv1beta1.AWSServiceType) esv1beta1.GenericStore {
+		return &esv1beta1.SecretStore{
+			Spec: esv1beta1.SecretStoreSpec{
+				Provider: &esv1beta1.SecretStoreProvider{
+					AWS: &esv1beta1.AWSProvider{Service: service},
+				},
+			},
+		}
+	}
+	sm := p.Features(makeStore(esv1beta1.AWSServiceSecretsManager))
+	assert.True(t, sm.PushMetadata)
+	assert.False(t, sm.PushWholeSecret)
+	assert.True(t, sm.FindByTags)
+
+	ps := p.Features(makeStore(esv1beta1.AWSServiceParameterStore))
+	assert.False(t, ps.PushMetadata)
+	assert.True(t, ps.PushWholeSecret)
+	assert.True(t, ps.FindByTags)
+}
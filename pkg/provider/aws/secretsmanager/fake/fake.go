@@ -34,6 +34,7 @@ type Client struct {
 	PutSecretValueWithContextFn PutSecretValueWithContextFn
 	DescribeSecretWithContextFn DescribeSecretWithContextFn
 	DeleteSecretWithContextFn   DeleteSecretWithContextFn
+	TagResourceWithContextFn    TagResourceWithContextFn
 	ListSecretsFn               ListSecretsFn
 }
 
@@ -42,6 +43,7 @@ type GetSecretValueWithContextFn func(aws.Context, *awssm.GetSecretValueInput, .
 type PutSecretValueWithContextFn func(aws.Context, *awssm.PutSecretValueInput, ...request.Option) (*awssm.PutSecretValueOutput, error)
 type DescribeSecretWithContextFn func(aws.Context, *awssm.DescribeSecretInput, ...request.Option) (*awssm.DescribeSecretOutput, error)
 type DeleteSecretWithContextFn func(ctx aws.Context, input *awssm.DeleteSecretInput, opts ...request.Option) (*awssm.DeleteSecretOutput, error)
+type TagResourceWithContextFn func(aws.Context, *awssm.TagResourceInput, ...request.Option) (*awssm.TagResourceOutput, error)
 type ListSecretsFn func(ctx aws.Context, input *awssm.ListSecretsInput, opts ...request.Option) (*awssm.ListSecretsOutput, error)
 
 func (sm Client) CreateSecretWithContext(ctx aws.Context, input *awssm.CreateSecretInput, options ...request.Option) (*awssm.CreateSecretOutput, error) {
@@ -144,6 +146,19 @@ func NewDescribeSecretWithContextFn(output *awssm.DescribeSecretOutput, err erro
 	}
 }
 
+func (sm Client) TagResourceWithContext(ctx aws.Context, input *awssm.TagResourceInput, options ...request.Option) (*awssm.TagResourceOutput, error) {
+	if sm.TagResourceWithContextFn == nil {
+		return &awssm.TagResourceOutput{}, nil
+	}
+	return sm.TagResourceWithContextFn(ctx, input, options...)
+}
+
+func NewTagResourceWithContextFn(output *awssm.TagResourceOutput, err error) TagResourceWithContextFn {
+	return func(aws.Context, *awssm.TagResourceInput, ...request.Option) (*awssm.TagResourceOutput, error) {
+		return output, err
+	}
+}
+
 // NewClient init a new fake client.
 func NewClient() *Client {
 	return &Client{
@@ -72,6 +72,7 @@ type SMInterface interface {
 	PutSecretValueWithContext(aws.Context, *awssm.PutSecretValueInput, ...request.Option) (*awssm.PutSecretValueOutput, error)
 	DescribeSecretWithContext(aws.Context, *awssm.DescribeSecretInput, ...request.Option) (*awssm.DescribeSecretOutput, error)
 	DeleteSecretWithContext(ctx aws.Context, input *awssm.DeleteSecretInput, opts ...request.Option) (*awssm.DeleteSecretOutput, error)
+	TagResourceWithContext(aws.Context, *awssm.TagResourceInput, ...request.Option) (*awssm.TagResourceOutput, error)
 }
 
 const (
@@ -191,7 +192,7 @@ func (sm *SecretsManager) DeleteSecret(ctx context.Context, remoteRef esv1beta1.
 	if err != nil {
 		return err
 	}
-	if !isManagedByESO(data) {
+	if !isManagedByESO(data) || !utils.IsRemoteOwner(remoteRef, getTagValue(data, utils.RemoteOwnerKey)) {
 		return nil
 	}
 	deleteInput := &awssm.DeleteSecretInput{
@@ -313,14 +314,34 @@ func bumpVersionNumber(id *string) (*string, error) {
 }
 
 func isManagedByESO(data *awssm.DescribeSecretOutput) bool {
-	managedBy := managedBy
-	externalSecrets := externalSecrets
+	return getTagValue(data, managedBy) == externalSecrets
+}
+
+func getTagValue(data *awssm.DescribeSecretOutput, key string) string {
 	for _, tag := range data.Tags {
-		if *tag.Key == managedBy && *tag.Value == externalSecrets {
-			return true
+		if tag.Key != nil && tag.Value != nil && *tag.Key == key {
+			return *tag.Value
 		}
 	}
-	return false
+	return ""
+}
+
+// newManagedTags returns the tags which mark a secret as managed by external-secrets
+// and, if the PushSecretData carries an owner, as owned by the PushSecret.
+func newManagedTags(psd esv1beta1.PushSecretData) []*awssm.Tag {
+	tags := []*awssm.Tag{
+		{
+			Key:   utilpointer.To(managedBy),
+			Value: utilpointer.To(externalSecrets),
+		},
+	}
+	if owner, _ := utils.PushSecretOwner(psd); owner != "" {
+		tags = append(tags, &awssm.Tag{
+			Key:   utilpointer.To(utils.RemoteOwnerKey),
+			Value: utilpointer.To(owner),
+		})
+	}
+	return tags
 }
 
 // GetAllSecrets syncs multiple secrets from aws provider into a single Kubernetes Secret.
@@ -559,23 +580,51 @@ func (sm *SecretsManager) createSecretWithContext(ctx context.Context, secretNam
 	}
 
 	input := &awssm.CreateSecretInput{
-		Name:         &secretName,
-		SecretBinary: value,
-		Tags: []*awssm.Tag{
-			{
-				Key:   utilpointer.To(managedBy),
-				Value: utilpointer.To(externalSecrets),
-			},
-		},
+		Name:               &secretName,
+		SecretBinary:       value,
+		Tags:               newManagedTags(psd),
 		ClientRequestToken: utilpointer.To(initialVersion),
 	}
 	if secretPushFormat == SecretPushFormatString {
 		input.SetSecretBinary(nil).SetSecretString(string(value))
 	}
 
-	_, err = sm.client.CreateSecretWithContext(ctx, input)
+	out, err := sm.client.CreateSecretWithContext(ctx, input)
 	metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMCreateSecret, err)
+	if err != nil {
+		return err
+	}
+	utils.SetRemoteVersion(psd, aws.StringValue(out.VersionId))
+
+	return nil
+}
 
+// claimSecret verifies that the secret may be updated by the PushSecret
+// and stamps the managed-by and owner tags on it if they are missing.
+func (sm *SecretsManager) claimSecret(ctx context.Context, data *awssm.DescribeSecretOutput, psd esv1beta1.PushSecretData) error {
+	owner, _ := utils.PushSecretOwner(psd)
+	managed := isManagedByESO(data)
+	if !managed {
+		adoptUnmanaged, err := utils.AdoptUnmanaged(psd)
+		if err != nil {
+			return fmt.Errorf("failed to parse metadata: %w", err)
+		}
+		if !adoptUnmanaged {
+			return fmt.Errorf("secret not managed by external-secrets")
+		}
+	}
+	currentOwner := getTagValue(data, utils.RemoteOwnerKey)
+	if err := utils.CheckRemoteOwner(psd, psd.GetRemoteKey(), currentOwner); err != nil {
+		return err
+	}
+	if managed && (owner == "" || currentOwner == owner) {
+		return nil
+	}
+	_, err := sm.client.TagResourceWithContext(ctx, &awssm.TagResourceInput{
+		SecretId: data.ARN,
+		Tags:     newManagedTags(psd),
+	})
+	metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMTagResource, err)
 	return err
 }
 
@@ -585,10 +634,11 @@ func (sm *SecretsManager) putSecretValueWithContext(ctx context.Context, secretI
 	if err != nil {
 		return err
 	}
-	if !isManagedByESO(data) {
-		return fmt.Errorf("secret not managed by external-secrets")
+	if err := sm.claimSecret(ctx, data, psd); err != nil {
+		return err
 	}
 	if awsSecret != nil && bytes.Equal(awsSecret.SecretBinary, value) || utils.CompareStringAndByteSlices(awsSecret.SecretString, value) {
+		utils.SetRemoteVersion(psd, aws.StringValue(awsSecret.VersionId))
 		return nil
 	}
 
@@ -609,8 +659,12 @@ func (sm *SecretsManager) putSecretValueWithContext(ctx context.Context, secretI
 		input.SetSecretBinary(nil).SetSecretString(string(value))
 	}
 
-	_, err = sm.client.PutSecretValueWithContext(ctx, input)
+	out, err := sm.client.PutSecretValueWithContext(ctx, input)
 	metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMPutSecretValue, err)
+	if err != nil {
+		return err
+	}
+	utils.SetRemoteVersion(psd, aws.StringValue(out.VersionId))
 
-	return err
+	return nil
 }
@@ -40,6 +40,7 @@ import (
 	fakesm "github.com/external-secrets/external-secrets/pkg/provider/aws/secretsmanager/fake"
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/util"
 	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 type secretsManagerTestCase struct {
@@ -469,6 +470,21 @@ func TestSetSecret(t *testing.T) {
 		Raw: []byte(`{"secretPushFormat": "string"}`),
 	}}
 	pushSecretDataWithProperty := fake.PushSecretData{SecretKey: secretKey, RemoteKey: "fake-key", Property: "other-fake-property"}
+	pushSecretDataWithOwner := fake.PushSecretData{SecretKey: secretKey, RemoteKey: "fake-key", Owner: "cluster/ns/ps"}
+	pushSecretDataWithAdopt := fake.PushSecretData{SecretKey: secretKey, RemoteKey: "fake-key", Owner: "cluster/ns/ps", Adopt: true}
+	pushSecretDataWithAdoptUnmanaged := fake.PushSecretData{SecretKey: secretKey, RemoteKey: "fake-key", Owner: "cluster/ns/ps", Adopt: true, Metadata: &apiextensionsv1.JSON{
+		Raw: []byte(`{"adoptUnmanaged": true}`),
+	}}
+
+	ownerKey := utils.RemoteOwnerKey
+	otherOwner := "other/ns/ps"
+	tagSecretOutputOtherOwner := &awssm.DescribeSecretOutput{
+		ARN: &arn,
+		Tags: []*awssm.Tag{
+			{Key: &managedBy, Value: &externalSecrets},
+			{Key: &ownerKey, Value: &otherOwner},
+		},
+	}
 
 	type args struct {
 		store          *esv1beta1.AWSProvider
@@ -772,6 +788,81 @@ func TestSetSecret(t *testing.T) {
 				err: fmt.Errorf("secret not managed by external-secrets"),
 			},
 		},
+		"SetSecretDoesNotOverwriteSecretOfOtherOwner": {
+			reason: "secret owned by another PushSecret must not be overwritten",
+			args: args{
+				store: makeValidSecretStore().Spec.Provider.AWS,
+				client: fakesm.Client{
+					GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(secretValueOutput, nil),
+					DescribeSecretWithContextFn: fakesm.NewDescribeSecretWithContextFn(tagSecretOutputOtherOwner, nil),
+				},
+				pushSecretData: pushSecretDataWithOwner,
+			},
+			want: want{
+				err: fmt.Errorf("remote secret fake-key is owned by other/ns/ps"),
+			},
+		},
+		"SetSecretAdoptsSecretOfOtherOwner": {
+			reason: "secret owned by another PushSecret can be adopted",
+			args: args{
+				store: makeValidSecretStore().Spec.Provider.AWS,
+				client: fakesm.Client{
+					GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(secretValueOutput, nil),
+					PutSecretValueWithContextFn: fakesm.NewPutSecretValueWithContextFn(putSecretOutput, nil),
+					DescribeSecretWithContextFn: fakesm.NewDescribeSecretWithContextFn(tagSecretOutputOtherOwner, nil),
+					TagResourceWithContextFn:    fakesm.NewTagResourceWithContextFn(&awssm.TagResourceOutput{}, nil),
+				},
+				pushSecretData: pushSecretDataWithAdopt,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SetSecretDoesNotAdoptUntaggedSecret": {
+			reason: "secret not managed by external-secrets is not adopted without opt-in",
+			args: args{
+				store: makeValidSecretStore().Spec.Provider.AWS,
+				client: fakesm.Client{
+					GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(secretValueOutput, nil),
+					DescribeSecretWithContextFn: fakesm.NewDescribeSecretWithContextFn(tagSecretOutputFaulty, nil),
+				},
+				pushSecretData: pushSecretDataWithAdopt,
+			},
+			want: want{
+				err: fmt.Errorf("secret not managed by external-secrets"),
+			},
+		},
+		"SetSecretAdoptsUntaggedSecret": {
+			reason: "secret not managed by external-secrets can be adopted with opt-in",
+			args: args{
+				store: makeValidSecretStore().Spec.Provider.AWS,
+				client: fakesm.Client{
+					GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(secretValueOutput, nil),
+					PutSecretValueWithContextFn: fakesm.NewPutSecretValueWithContextFn(putSecretOutput, nil),
+					DescribeSecretWithContextFn: fakesm.NewDescribeSecretWithContextFn(tagSecretOutputFaulty, nil),
+					TagResourceWithContextFn:    fakesm.NewTagResourceWithContextFn(&awssm.TagResourceOutput{}, nil),
+				},
+				pushSecretData: pushSecretDataWithAdoptUnmanaged,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SetSecretFailsIfTaggingFails": {
+			reason: "the ownership marker must be stamped before the secret is updated",
+			args: args{
+				store: makeValidSecretStore().Spec.Provider.AWS,
+				client: fakesm.Client{
+					GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(secretValueOutput, nil),
+					DescribeSecretWithContextFn: fakesm.NewDescribeSecretWithContextFn(tagSecretOutput, nil),
+					TagResourceWithContextFn:    fakesm.NewTagResourceWithContextFn(nil, noPermission),
+				},
+				pushSecretData: pushSecretDataWithOwner,
+			},
+			want: want{
+				err: noPermission,
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -796,6 +887,67 @@ func TestSetSecret(t *testing.T) {
 	}
 }
 
+func TestSetSecretReportsVersion(t *testing.T) {
+	secretKey := "fake-secret-key"
+	fakeSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			secretKey: []byte("fake-value"),
+		},
+	}
+	arn := "arn:aws:secretsmanager:us-east-1:702902267788:secret:foo-bar5-Robbgh"
+	managedBy := managedBy
+	externalSecrets := externalSecrets
+	currentVersion := "00000000-0000-0000-0000-000000000002"
+	newVersion := "00000000-0000-0000-0000-000000000003"
+	describeOutput := &awssm.DescribeSecretOutput{
+		ARN:  &arn,
+		Tags: []*awssm.Tag{{Key: &managedBy, Value: &externalSecrets}},
+	}
+
+	tests := map[string]struct {
+		client fakesm.Client
+		want   string
+	}{
+		"CreatedSecret": {
+			client: fakesm.Client{
+				GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(nil, &awssm.ResourceNotFoundException{}),
+				CreateSecretWithContextFn:   fakesm.NewCreateSecretWithContextFn(&awssm.CreateSecretOutput{ARN: &arn, VersionId: &newVersion}, nil),
+			},
+			want: newVersion,
+		},
+		"UpdatedSecret": {
+			client: fakesm.Client{
+				GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(&awssm.GetSecretValueOutput{ARN: &arn, VersionId: &currentVersion}, nil),
+				DescribeSecretWithContextFn: fakesm.NewDescribeSecretWithContextFn(describeOutput, nil),
+				PutSecretValueWithContextFn: fakesm.NewPutSecretValueWithContextFn(&awssm.PutSecretValueOutput{ARN: &arn, VersionId: &newVersion}, nil),
+			},
+			want: newVersion,
+		},
+		"UnchangedSecret": {
+			client: fakesm.Client{
+				GetSecretValueWithContextFn: fakesm.NewGetSecretValueWithContextFn(&awssm.GetSecretValueOutput{ARN: &arn, VersionId: &currentVersion, SecretBinary: []byte("fake-value")}, nil),
+				DescribeSecretWithContextFn: fakesm.NewDescribeSecretWithContextFn(describeOutput, nil),
+			},
+			want: currentVersion,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sm := SecretsManager{
+				client: &tc.client,
+			}
+			var version string
+			data := fake.PushSecretData{SecretKey: secretKey, RemoteKey: "fake-key", Version: &version}
+			if err := sm.PushSecret(context.Background(), fakeSecret, data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != tc.want {
+				t.Errorf("unexpected version: got %q, want %q", version, tc.want)
+			}
+		})
+	}
+}
+
 func TestDeleteSecret(t *testing.T) {
 	fakeClient := fakesm.Client{}
 	managed := managedBy
@@ -804,6 +956,12 @@ func TestDeleteSecret(t *testing.T) {
 		Key:   &managed,
 		Value: &manager,
 	}
+	ownerKey := utils.RemoteOwnerKey
+	otherOwner := "other/ns/ps"
+	otherOwnerTag := awssm.Tag{
+		Key:   &ownerKey,
+		Value: &otherOwner,
+	}
 	type args struct {
 		client               fakesm.Client
 		config               esv1beta1.SecretsManager
@@ -884,6 +1042,22 @@ func TestDeleteSecret(t *testing.T) {
 			},
 			reason: "",
 		},
+		"Owned by other PushSecret": {
+			args: args{
+				client:          fakeClient,
+				config:          esv1beta1.SecretsManager{},
+				getSecretOutput: &awssm.GetSecretValueOutput{},
+				describeSecretOutput: &awssm.DescribeSecretOutput{
+					Tags: []*awssm.Tag{&secretTag, &otherOwnerTag},
+				},
+				deleteSecretOutput: nil,
+				deleteSecretErr:    errors.New("secret must not be deleted"),
+			},
+			want: want{
+				err: nil,
+			},
+			reason: "secrets owned by another PushSecret are not deleted",
+		},
 		"Invalid Recovery Window": {
 			args: args{
 
@@ -992,7 +1166,7 @@ func TestDeleteSecret(t *testing.T) {
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			ref := fake.PushSecretData{RemoteKey: "fake-key"}
+			ref := fake.PushSecretData{RemoteKey: "fake-key", Owner: "cluster/ns/ps"}
 			sm := SecretsManager{
 				client: &tc.args.client,
 				config: &tc.args.config,
@@ -134,6 +134,19 @@ func (a *Azure) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// Features returns the optional features the provider supports.
+func (a *Azure) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{
+		FindByName:      true,
+		FindByTags:      true,
+		Versions:        true,
+		MetadataFetch:   true,
+		PushWholeSecret: true,
+		DeleteSecret:    true,
+		SecretExists:    true,
+	}
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (a *Azure) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	return newClient(ctx, store, kube, namespace)
@@ -531,12 +544,14 @@ func (a *Azure) setKeyVaultKey(ctx context.Context, secretName string, value []b
 
 // PushSecret stores secrets into a Key vault instance.
 func (a *Azure) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
-	if data.GetSecretKey() == "" {
-		return fmt.Errorf("pushing the whole secret is not yet implemented")
-	}
-
 	objectType, secretName := getObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: data.GetRemoteKey()})
-	value := secret.Data[data.GetSecretKey()]
+	if data.GetSecretKey() == "" && objectType != defaultObjType {
+		return fmt.Errorf("pushing the whole secret is only supported for objects of type %v", defaultObjType)
+	}
+	value, err := utils.PushSecretValue(secret, data)
+	if err != nil {
+		return err
+	}
 	switch objectType {
 	case defaultObjType:
 		return a.setKeyVaultSecret(ctx, secretName, value)
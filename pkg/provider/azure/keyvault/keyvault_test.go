@@ -475,6 +475,25 @@ func TestAzureKeyVaultPushSecret(t *testing.T) {
 		smtc.setErr = autorest.DetailedError{StatusCode: 403, Method: "POST", Message: "Forbidden"}
 		smtc.expectError = "could not set secret example-1: #POST: Forbidden: StatusCode=403"
 	}
+	wholeSecretNoChange := func(smtc *secretManagerTestCase) {
+		smtc.setValue = []byte(goodSecret)
+		smtc.pushData = testingfake.PushSecretData{
+			RemoteKey: secretName,
+		}
+		smtc.secretOutput = keyvault.SecretBundle{
+			Tags: map[string]*string{
+				"managed-by": pointer.To("external-secrets"),
+			},
+			Value: pointer.To(`{"fakeSecretKey":"old"}`),
+		}
+	}
+	wholeSecretKeyNotSupported := func(smtc *secretManagerTestCase) {
+		smtc.setValue = goodKey
+		smtc.pushData = testingfake.PushSecretData{
+			RemoteKey: keyName,
+		}
+		smtc.expectError = "pushing the whole secret is only supported for objects of type secret"
+	}
 	keySuccess := func(smtc *secretManagerTestCase) {
 		smtc.setValue = goodKey
 		smtc.pushData = testingfake.PushSecretData{
@@ -772,6 +791,8 @@ func TestAzureKeyVaultPushSecret(t *testing.T) {
 		makeValidSecretManagerTestCaseCustom(failedNotParseableError),
 		makeValidSecretManagerTestCaseCustom(failedSetSecret),
 		makeValidSecretManagerTestCaseCustom(typeNotSupported),
+		makeValidSecretManagerTestCaseCustom(wholeSecretNoChange),
+		makeValidSecretManagerTestCaseCustom(wholeSecretKeyNotSupported),
 	}
 
 	sm := Azure{
@@ -346,3 +346,8 @@ func (providerchef *Providerchef) SecretExists(_ context.Context, _ v1beta1.Push
 func (providerchef *Providerchef) Capabilities() v1beta1.SecretStoreCapabilities {
 	return v1beta1.SecretStoreReadOnly
 }
+
+// Features returns the optional features the provider supports.
+func (providerchef *Providerchef) Features(_ v1beta1.GenericStore) v1beta1.SecretStoreFeatures {
+	return v1beta1.SecretStoreFeatures{}
+}
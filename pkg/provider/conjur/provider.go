@@ -51,6 +51,14 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadOnly
 }
 
+// Features returns the optional features the provider supports.
+func (p *Provider) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{
+		FindByName: true,
+		FindByTags: true,
+	}
+}
+
 func newConjurProvider(_ context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string, corev1 typedcorev1.CoreV1Interface, clientAPI SecretsClientFactory) (esv1beta1.SecretsClient, error) {
 	return &Client{
 		StoreKind: store.GetObjectKind().GroupVersionKind().Kind,
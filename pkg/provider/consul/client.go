@@ -0,0 +1,397 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	corev1 "k8s.io/api/core/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errFindByTags       = "find by tags is not supported"
+	errPropertyNotFound = "property %s not found in key %s"
+	errNotAnObject      = "key %s is not a JSON object"
+	errPropertyNotObj   = "property %s of key %s is not a JSON object"
+	errConflict         = "key %s was modified concurrently %d times, giving up"
+	errGetKey           = "unable to get key %s: %w"
+	errListKeys         = "unable to list keys with prefix %q: %w"
+	errWriteKey         = "unable to write key %s: %w"
+	errDeleteKey        = "unable to delete key %s: %w"
+	errLogout           = "unable to log out: %w"
+	errUnresolved       = "credentials are not resolved"
+	errNotManaged       = "key %s is not managed by external-secrets"
+	errOwnedByOther     = "key %s is owned by another PushSecret"
+
+	// casAttempts is how often a write is retried when the key was modified concurrently.
+	casAttempts = 5
+
+	// managedFlags marks keys written by external-secrets in the upper 16 bits of the flags of the key,
+	// the lower 48 bits hold a hash of the owner, zero if the key has none.
+	managedFlags uint64 = 0x6573 << 48
+	ownerMask    uint64 = 1<<48 - 1
+)
+
+// Client reads and writes keys of the Consul KV store.
+type Client struct {
+	kube      kclient.Client
+	store     *esv1beta1.ConsulProvider
+	storeKind string
+	// namespace of the ExternalSecret referencing the store.
+	namespace string
+	// api is nil for a ClusterSecretStore with referent credentials without an ExternalSecret.
+	api *consulapi.Client
+	// loggedIn is true if the ACL token was created by a login and is destroyed on Close.
+	loggedIn bool
+}
+
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	pair, err := c.get(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return pair.Value, nil
+	}
+	val := gjson.GetBytes(pair.Value, ref.Property)
+	if !val.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	return resultBytes(val), nil
+}
+
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	pair, err := c.get(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	val := gjson.ParseBytes(pair.Value)
+	if ref.Property != "" {
+		val = val.Get(ref.Property)
+		if !val.Exists() {
+			return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+		}
+	}
+	if !val.IsObject() {
+		if ref.Property != "" {
+			return nil, fmt.Errorf(errPropertyNotObj, ref.Property, ref.Key)
+		}
+		return nil, fmt.Errorf(errNotAnObject, ref.Key)
+	}
+	secretMap := make(map[string][]byte)
+	val.ForEach(func(key, value gjson.Result) bool {
+		secretMap[key.String()] = resultBytes(value)
+		return true
+	})
+	return secretMap, nil
+}
+
+// GetAllSecrets returns the keys starting with find.path whose name matches find.name.
+// Folders, keys ending with a slash without a value, are skipped.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Tags != nil {
+		return nil, errors.New(errFindByTags)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+	prefix := ""
+	if ref.Path != nil {
+		prefix = strings.TrimPrefix(*ref.Path, "/")
+	}
+	if c.api == nil {
+		return nil, errors.New(errUnresolved)
+	}
+	pairs, _, err := c.api.KV().List(prefix, queryOptions(ctx))
+	if err != nil {
+		return nil, fmt.Errorf(errListKeys, prefix, err)
+	}
+	secretMap := make(map[string][]byte)
+	for _, pair := range pairs {
+		if strings.HasSuffix(pair.Key, "/") && len(pair.Value) == 0 {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(pair.Key) {
+			continue
+		}
+		secretMap[pair.Key] = pair.Value
+	}
+	return secretMap, nil
+}
+
+// get returns the key or a NoSecretError.
+func (c *Client) get(ctx context.Context, key string) (*consulapi.KVPair, error) {
+	if c.api == nil {
+		return nil, errors.New(errUnresolved)
+	}
+	key = strings.TrimPrefix(key, "/")
+	pair, _, err := c.api.KV().Get(key, queryOptions(ctx))
+	if err != nil {
+		return nil, fmt.Errorf(errGetKey, key, err)
+	}
+	if pair == nil {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return pair, nil
+}
+
+// resultBytes returns strings as they are and other values as JSON.
+func resultBytes(val gjson.Result) []byte {
+	if val.Type == gjson.String {
+		return []byte(val.Str)
+	}
+	return []byte(val.Raw)
+}
+
+// PushSecret writes the value to the key, or to the property of the JSON object stored in the key.
+// Existing keys are only written if they are managed by external-secrets,
+// or if adoptUnmanaged is set in the metadata of the PushSecret.
+func (c *Client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	value, err := utils.PushSecretValue(secret, data)
+	if err != nil {
+		return err
+	}
+	property := data.GetProperty()
+	return c.update(ctx, data.GetRemoteKey(), func(current *consulapi.KVPair) ([]byte, uint64, operation, error) {
+		flags, err := pushFlags(current, data)
+		if err != nil {
+			return nil, 0, opNone, err
+		}
+		if property == "" {
+			if current != nil && current.Flags == flags && bytes.Equal(current.Value, value) {
+				return nil, 0, opNone, nil
+			}
+			return value, flags, opPut, nil
+		}
+		base := []byte("{}")
+		if current != nil && len(current.Value) > 0 {
+			base = current.Value
+			if !gjson.ParseBytes(base).IsObject() {
+				return nil, 0, opNone, fmt.Errorf(errNotAnObject, data.GetRemoteKey())
+			}
+		}
+		if val := gjson.GetBytes(base, property); current != nil && current.Flags == flags &&
+			val.Exists() && val.Type == gjson.String && val.Str == string(value) {
+			return nil, 0, opNone, nil
+		}
+		updated, err := sjson.SetBytes(base, property, string(value))
+		if err != nil {
+			return nil, 0, opNone, err
+		}
+		return updated, flags, opPut, nil
+	})
+}
+
+// pushFlags returns the flags of a key written by the PushSecret. Keys which are not managed
+// by external-secrets are only adopted with adoptUnmanaged, keys owned by another PushSecret
+// with the Adopt update policy. Pushes without owner keep the owner of the key.
+func pushFlags(current *consulapi.KVPair, data esv1beta1.PushSecretData) (uint64, error) {
+	owner, adopt := utils.PushSecretOwner(data)
+	if current == nil {
+		return managedFlags | ownerHash(owner), nil
+	}
+	if !isManaged(current.Flags) {
+		adoptUnmanaged, err := utils.AdoptUnmanaged(data)
+		if err != nil {
+			return 0, err
+		}
+		if !adoptUnmanaged {
+			return 0, fmt.Errorf(errNotManaged, data.GetRemoteKey())
+		}
+		return managedFlags | ownerHash(owner), nil
+	}
+	if !adopt && !isRemoteOwner(data, current.Flags) {
+		return 0, fmt.Errorf(errOwnedByOther, data.GetRemoteKey())
+	}
+	if owner == "" {
+		return current.Flags, nil
+	}
+	return managedFlags | ownerHash(owner), nil
+}
+
+// DeleteSecret removes the key, or the property of the JSON object stored in the key.
+// The key is removed once its last property is deleted. Keys which are not managed
+// by external-secrets, or owned by another PushSecret, are kept.
+func (c *Client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	property := remoteRef.GetProperty()
+	return c.update(ctx, remoteRef.GetRemoteKey(), func(current *consulapi.KVPair) ([]byte, uint64, operation, error) {
+		if current == nil || !isManaged(current.Flags) || !isRemoteOwner(remoteRef, current.Flags) {
+			return nil, 0, opNone, nil
+		}
+		if property == "" {
+			return nil, 0, opDelete, nil
+		}
+		if !gjson.GetBytes(current.Value, property).Exists() {
+			return nil, 0, opNone, nil
+		}
+		updated, err := sjson.DeleteBytes(current.Value, property)
+		if err != nil {
+			return nil, 0, opNone, err
+		}
+		if len(gjson.ParseBytes(updated).Map()) == 0 {
+			return nil, 0, opDelete, nil
+		}
+		return updated, current.Flags, opPut, nil
+	})
+}
+
+func isManaged(flags uint64) bool {
+	return flags&^ownerMask == managedFlags
+}
+
+// isRemoteOwner returns true if the key has no owner or is owned by the owner of the ref.
+func isRemoteOwner(ref any, flags uint64) bool {
+	owner, _ := utils.PushSecretOwner(ref)
+	current := flags & ownerMask
+	return current == 0 || current == ownerHash(owner)
+}
+
+// ownerHash returns the hash of the owner stored in the flags of a key, zero without owner.
+func ownerHash(owner string) uint64 {
+	if owner == "" {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(owner))
+	if sum := h.Sum64() & ownerMask; sum != 0 {
+		return sum
+	}
+	return 1
+}
+
+func (c *Client) SecretExists(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) (bool, error) {
+	pair, err := c.get(ctx, remoteRef.GetRemoteKey())
+	if errors.Is(err, esv1beta1.NoSecretError{}) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if remoteRef.GetProperty() == "" {
+		return true, nil
+	}
+	return gjson.GetBytes(pair.Value, remoteRef.GetProperty()).Exists(), nil
+}
+
+type operation int
+
+const (
+	opNone operation = iota
+	opPut
+	opDelete
+)
+
+// update applies the change computed from the current value of the key with a check-and-set,
+// so that concurrent writes are not lost. The change is computed again if the key was modified in the meantime.
+func (c *Client) update(ctx context.Context, key string, change func(current *consulapi.KVPair) ([]byte, uint64, operation, error)) error {
+	if c.api == nil {
+		return errors.New(errUnresolved)
+	}
+	key = strings.TrimPrefix(key, "/")
+	for i := 0; i < casAttempts; i++ {
+		current, _, err := c.api.KV().Get(key, queryOptions(ctx))
+		if err != nil {
+			return fmt.Errorf(errGetKey, key, err)
+		}
+		value, flags, op, err := change(current)
+		if err != nil {
+			return err
+		}
+		var index uint64
+		if current != nil {
+			index = current.ModifyIndex
+		}
+		var ok bool
+		switch op {
+		case opNone:
+			return nil
+		case opPut:
+			ok, _, err = c.api.KV().CAS(&consulapi.KVPair{Key: key, Value: value, Flags: flags, ModifyIndex: index}, writeOptions(ctx))
+			if err != nil {
+				return fmt.Errorf(errWriteKey, key, err)
+			}
+		case opDelete:
+			ok, _, err = c.api.KV().DeleteCAS(&consulapi.KVPair{Key: key, ModifyIndex: index}, writeOptions(ctx))
+			if err != nil {
+				return fmt.Errorf(errDeleteKey, key, err)
+			}
+		}
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf(errConflict, key, casAttempts)
+}
+
+// Validate checks the ACL token, or that the cluster is reachable without token.
+// The credentials of a ClusterSecretStore with referent references are only known for an ExternalSecret.
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	if c.api == nil {
+		return esv1beta1.ValidationResultUnknown, nil
+	}
+	q := queryOptions(context.Background())
+	if c.store.Auth == nil {
+		if _, err := c.api.Status().LeaderWithQueryOptions(q); err != nil {
+			return esv1beta1.ValidationResultError, err
+		}
+		return esv1beta1.ValidationResultReady, nil
+	}
+	_, _, err := c.api.ACL().TokenReadSelf(q)
+	// a cluster without ACLs replies 401 ACL support disabled, any token is accepted
+	var statusErr consulapi.StatusError
+	if err != nil && !(errors.As(err, &statusErr) && statusErr.Code == http.StatusUnauthorized) {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+// Close destroys the ACL token created by a login.
+func (c *Client) Close(ctx context.Context) error {
+	if !c.loggedIn {
+		return nil
+	}
+	c.loggedIn = false
+	if _, err := c.api.ACL().Logout(writeOptions(ctx)); err != nil {
+		return fmt.Errorf(errLogout, err)
+	}
+	return nil
+}
+
+func queryOptions(ctx context.Context) *consulapi.QueryOptions {
+	return (&consulapi.QueryOptions{}).WithContext(ctx)
+}
+
+func writeOptions(ctx context.Context) *consulapi.WriteOptions {
+	return (&consulapi.WriteOptions{}).WithContext(ctx)
+}
@@ -0,0 +1,287 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+	utilfake "github.com/external-secrets/external-secrets/pkg/provider/util/fake"
+)
+
+const (
+	testToken = "s3cr3t-token"
+	testJWT   = "service-account-jwt"
+)
+
+var tokenAuth = &esv1beta1.ConsulAuth{TokenSecretRef: &esmeta.SecretKeySelector{Name: "consul", Key: "token"}}
+
+func newTestClient(t *testing.T, provider *Provider, spec *esv1beta1.ConsulProvider) esv1beta1.SecretsClient {
+	t.Helper()
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "consul", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte(testToken), "wrong": []byte("wrong")},
+	}).Build()
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "consul", Namespace: "default"},
+		Spec:       esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Consul: spec}},
+	}
+	c, err := provider.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	return c
+}
+
+func TestClientGetSecret(t *testing.T) {
+	fake, address := newFakeConsul(t, map[string]string{
+		"apps/payments/":        "",
+		"apps/payments/db":      `{"username":"admin","password":"s3cr3t","port":5432}`,
+		"apps/payments/api-key": "k3y",
+		"apps/billing/token":    "t0k3n",
+	})
+	spec := &esv1beta1.ConsulProvider{Address: address, Datacenter: "dc2", Namespace: "team-a", Partition: "apps", Auth: tokenAuth}
+	c := newTestClient(t, &Provider{}, spec)
+	ctx := context.Background()
+
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+	assert.Equal(t, map[string]string{"dc": "dc2", "ns": "team-a", "partition": "apps"}, fake.query)
+
+	data, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/payments/api-key"})
+	require.NoError(t, err)
+	assert.Equal(t, "k3y", string(data))
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "/apps/payments/db", Property: "password"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(data))
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/payments/db", Property: "port"})
+	require.NoError(t, err)
+	assert.Equal(t, "5432", string(data))
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/payments/missing"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/payments/db", Property: "host"})
+	assert.EqualError(t, err, "property host not found in key apps/payments/db")
+
+	secretMap, err := c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/payments/db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"username": []byte("admin"), "password": []byte("s3cr3t"), "port": []byte("5432")}, secretMap)
+	_, err = c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/payments/api-key"})
+	assert.EqualError(t, err, "key apps/payments/api-key is not a JSON object")
+	_, err = c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/payments/db", Property: "port"})
+	assert.EqualError(t, err, "property port of key apps/payments/db is not a JSON object")
+
+	// folders are skipped
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: ptr.To("apps/payments")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"apps/payments/db":      []byte(`{"username":"admin","password":"s3cr3t","port":5432}`),
+		"apps/payments/api-key": []byte("k3y"),
+	}, secretMap)
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "token$"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"apps/billing/token": []byte("t0k3n")}, secretMap)
+	_, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "payments"}})
+	assert.EqualError(t, err, errFindByTags)
+
+	// wrong token
+	c = newTestClient(t, &Provider{}, &esv1beta1.ConsulProvider{
+		Address: address,
+		Auth:    &esv1beta1.ConsulAuth{TokenSecretRef: &esmeta.SecretKeySelector{Name: "consul", Key: "wrong"}},
+	})
+	result, err = c.Validate()
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+	assert.EqualError(t, err, "Unexpected response code: 403 (ACL not found)")
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/payments/api-key"})
+	assert.EqualError(t, err, "unable to get key apps/payments/api-key: Unexpected response code: 403 (ACL not found)")
+}
+
+func TestClientPushSecret(t *testing.T) {
+	fake, address := newFakeConsul(t, map[string]string{
+		"apps/payments/config": `{"region":"eu-west-1"}`,
+		"apps/payments/plain":  "not json",
+	})
+	c := newTestClient(t, &Provider{}, &esv1beta1.ConsulProvider{Address: address, Auth: tokenAuth})
+	ctx := context.Background()
+	source := &corev1.Secret{Data: map[string][]byte{"username": []byte("admin"), "password": []byte("s3cr3t")}}
+
+	// the whole secret is written as JSON
+	require.NoError(t, c.PushSecret(ctx, source, pushSecretData("", "apps/payments/db", "")))
+	value, _ := fake.value("apps/payments/db")
+	assert.JSONEq(t, `{"username":"admin","password":"s3cr3t"}`, value)
+	exists, err := c.SecretExists(ctx, pushSecretData("", "apps/payments/db", "password"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// a secret key is written to the key, or to a property of the JSON object
+	require.NoError(t, c.PushSecret(ctx, source, pushSecretData("password", "apps/payments/password", "")))
+	value, _ = fake.value("apps/payments/password")
+	assert.Equal(t, "s3cr3t", value)
+	// existing keys are only written with adoptUnmanaged
+	err = c.PushSecret(ctx, source, pushSecretData("password", "apps/payments/config", "db.password"))
+	assert.EqualError(t, err, "key apps/payments/config is not managed by external-secrets")
+	adoptUnmanaged := &apiextensionsv1.JSON{Raw: []byte(`{"adoptUnmanaged": true}`)}
+	adopt := testingfake.PushSecretData{SecretKey: "password", RemoteKey: "apps/payments/config", Property: "db.password", Adopt: true, Metadata: adoptUnmanaged}
+	require.NoError(t, c.PushSecret(ctx, source, adopt))
+	value, _ = fake.value("apps/payments/config")
+	assert.JSONEq(t, `{"region":"eu-west-1","db":{"password":"s3cr3t"}}`, value)
+	adopt.RemoteKey, adopt.Property = "apps/payments/plain", "password"
+	err = c.PushSecret(ctx, source, adopt)
+	assert.EqualError(t, err, "key apps/payments/plain is not a JSON object")
+
+	// keys owned by another PushSecret are neither written nor deleted
+	owned := testingfake.PushSecretData{SecretKey: "password", RemoteKey: "apps/payments/owned", Owner: "default/owner"}
+	require.NoError(t, c.PushSecret(ctx, source, owned))
+	other := testingfake.PushSecretData{SecretKey: "username", RemoteKey: "apps/payments/owned", Owner: "default/other"}
+	err = c.PushSecret(ctx, source, other)
+	assert.EqualError(t, err, "key apps/payments/owned is owned by another PushSecret")
+	require.NoError(t, c.DeleteSecret(ctx, other))
+	value, _ = fake.value("apps/payments/owned")
+	assert.Equal(t, "s3cr3t", value)
+	other.Adopt = true
+	require.NoError(t, c.PushSecret(ctx, source, other))
+	value, _ = fake.value("apps/payments/owned")
+	assert.Equal(t, "admin", value)
+	require.NoError(t, c.DeleteSecret(ctx, other))
+	_, ok := fake.value("apps/payments/owned")
+	assert.False(t, ok)
+
+	// unmanaged keys are not deleted
+	require.NoError(t, c.DeleteSecret(ctx, pushSecretData("", "apps/payments/plain", "")))
+	_, ok = fake.value("apps/payments/plain")
+	assert.True(t, ok)
+
+	// unchanged values are not written
+	index := fake.kv["apps/payments/config"].ModifyIndex
+	require.NoError(t, c.PushSecret(ctx, source, pushSecretData("password", "apps/payments/config", "db.password")))
+	assert.Equal(t, index, fake.kv["apps/payments/config"].ModifyIndex)
+
+	// a concurrent write is not lost, the change is applied to the new value
+	writes := 0
+	flags := fake.kv["apps/payments/config"].Flags
+	fake.beforeWrite = func(f *fakeConsul) {
+		if writes++; writes == 1 {
+			f.set("apps/payments/config", []byte(`{"region":"us-east-1","db":{"password":"s3cr3t"}}`))
+			f.kv["apps/payments/config"].Flags = flags
+		}
+	}
+	require.NoError(t, c.PushSecret(ctx, source, pushSecretData("username", "apps/payments/config", "db.username")))
+	value, _ = fake.value("apps/payments/config")
+	assert.JSONEq(t, `{"region":"us-east-1","db":{"password":"s3cr3t","username":"admin"}}`, value)
+	assert.Equal(t, 2, writes)
+
+	// a key modified on every attempt gives up
+	fake.beforeWrite = func(f *fakeConsul) {
+		f.set("apps/payments/config", []byte(`{}`))
+		f.kv["apps/payments/config"].Flags = flags
+	}
+	err = c.PushSecret(ctx, source, pushSecretData("username", "apps/payments/config", "user"))
+	assert.EqualError(t, err, "key apps/payments/config was modified concurrently 5 times, giving up")
+	fake.beforeWrite = nil
+
+	// deleting the last property deletes the key
+	require.NoError(t, c.DeleteSecret(ctx, pushSecretData("", "apps/payments/db", "password")))
+	value, _ = fake.value("apps/payments/db")
+	assert.JSONEq(t, `{"username":"admin"}`, value)
+	require.NoError(t, c.DeleteSecret(ctx, pushSecretData("", "apps/payments/db", "username")))
+	_, ok = fake.value("apps/payments/db")
+	assert.False(t, ok)
+	require.NoError(t, c.DeleteSecret(ctx, pushSecretData("", "apps/payments/password", "")))
+	exists, err = c.SecretExists(ctx, pushSecretData("", "apps/payments/password", ""))
+	require.NoError(t, err)
+	assert.False(t, exists)
+	// deleting a missing key succeeds
+	require.NoError(t, c.DeleteSecret(ctx, pushSecretData("", "apps/payments/password", "")))
+}
+
+func TestKubernetesAuth(t *testing.T) {
+	fake, address := newFakeConsul(t, map[string]string{"apps/token": "t0k3n"})
+	ctx := context.Background()
+	spec := &esv1beta1.ConsulProvider{Address: address, Auth: &esv1beta1.ConsulAuth{Kubernetes: &esv1beta1.ConsulKubernetesAuth{
+		AuthMethod:        "kubernetes",
+		ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "external-secrets"},
+	}}}
+
+	c := newTestClient(t, &Provider{corev1: utilfake.NewCreateTokenMock().WithToken(testJWT)}, spec)
+	data, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/token"})
+	require.NoError(t, err)
+	assert.Equal(t, "t0k3n", string(data))
+	assert.Len(t, fake.tokens, 2)
+
+	// the token created by the login is destroyed
+	require.NoError(t, c.Close(ctx))
+	assert.Len(t, fake.tokens, 1)
+
+	kube := clientfake.NewClientBuilder().Build()
+	store := &esv1beta1.SecretStore{Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Consul: spec}}}
+	_, err = (&Provider{corev1: utilfake.NewCreateTokenMock().WithToken("invalid")}).NewClient(ctx, store, kube, "default")
+	assert.EqualError(t, err, `unable to log in with auth method "kubernetes": Unexpected response code: 403 (Permission denied)`)
+	_, err = (&Provider{corev1: utilfake.NewCreateTokenMock().WithError(errors.New("forbidden"))}).NewClient(ctx, store, kube, "default")
+	assert.EqualError(t, err, `cannot request Kubernetes service account token for service account "external-secrets": forbidden`)
+}
+
+func TestEnvironmentToken(t *testing.T) {
+	_, address := newFakeConsul(t, map[string]string{"apps/token": "t0k3n"})
+	ctx := context.Background()
+	kube := clientfake.NewClientBuilder().Build()
+	store := &esv1beta1.SecretStore{Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Consul: &esv1beta1.ConsulProvider{Address: address}}}}
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(testToken), 0o600))
+
+	// stores without auth do not get the token of the operator
+	for env, value := range map[string]string{"CONSUL_HTTP_TOKEN": testToken, "CONSUL_HTTP_TOKEN_FILE": tokenFile} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+			_, err := (&Provider{}).NewClient(ctx, store, kube, "default")
+			assert.EqualError(t, err, "the "+env+" environment variable of the operator is set, the operator only uses the token of spec.provider.consul.auth")
+
+			c := newTestClient(t, &Provider{}, &esv1beta1.ConsulProvider{Address: address, Auth: tokenAuth})
+			data, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/token"})
+			require.NoError(t, err)
+			assert.Equal(t, "t0k3n", string(data))
+
+			c = newTestClient(t, &Provider{corev1: utilfake.NewCreateTokenMock().WithToken(testJWT)}, &esv1beta1.ConsulProvider{Address: address, Auth: &esv1beta1.ConsulAuth{Kubernetes: &esv1beta1.ConsulKubernetesAuth{
+				AuthMethod:        "kubernetes",
+				ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "external-secrets"},
+			}}})
+			_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "apps/token"})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func pushSecretData(secretKey, remoteKey, property string) esv1alpha1.PushSecretData {
+	return esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{
+			SecretKey: secretKey,
+			RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: remoteKey, Property: property},
+		},
+	}
+}
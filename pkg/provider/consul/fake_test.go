@@ -0,0 +1,162 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+// fakeConsul is an httptest stand-in for the KV store and the ACL login of a Consul agent.
+type fakeConsul struct {
+	mu     sync.Mutex
+	index  uint64
+	kv     map[string]*consulapi.KVPair
+	tokens map[string]bool
+	// query is the query of the last request.
+	query map[string]string
+	// beforeWrite runs before a check-and-set, to simulate a concurrent write.
+	beforeWrite func(f *fakeConsul)
+	logins      int
+}
+
+func newFakeConsul(t *testing.T, kv map[string]string) (*fakeConsul, string) {
+	t.Helper()
+	f := &fakeConsul{kv: map[string]*consulapi.KVPair{}, tokens: map[string]bool{testToken: true}}
+	for k, v := range kv {
+		f.set(k, []byte(v))
+	}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server.URL
+}
+
+func (f *fakeConsul) set(key string, value []byte) {
+	f.index++
+	if strings.HasSuffix(key, "/") {
+		value = nil
+	}
+	f.kv[key] = &consulapi.KVPair{Key: key, Value: value, ModifyIndex: f.index}
+}
+
+func (f *fakeConsul) value(key string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pair, ok := f.kv[key]
+	if !ok {
+		return "", false
+	}
+	return string(pair.Value), true
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.query = map[string]string{}
+	for _, param := range []string{"dc", "ns", "partition"} {
+		f.query[param] = r.URL.Query().Get(param)
+	}
+
+	if r.Method == http.MethodPost && r.URL.Path == "/v1/acl/login" {
+		var login struct {
+			AuthMethod  string
+			BearerToken string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&login); err != nil || login.AuthMethod != "kubernetes" || login.BearerToken != testJWT {
+			http.Error(w, "Permission denied", http.StatusForbidden)
+			return
+		}
+		f.logins++
+		token := "login-" + strconv.Itoa(f.logins)
+		f.tokens[token] = true
+		writeJSON(w, map[string]string{"SecretID": token})
+		return
+	}
+	token := r.Header.Get("X-Consul-Token")
+	if !f.tokens[token] {
+		http.Error(w, "ACL not found", http.StatusForbidden)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/acl/logout":
+		delete(f.tokens, token)
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/acl/token/self":
+		writeJSON(w, map[string]string{"SecretID": token})
+	case strings.HasPrefix(r.URL.Path, "/v1/kv/"):
+		f.serveKV(w, r, strings.TrimPrefix(r.URL.Path, "/v1/kv/"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeConsul) serveKV(w http.ResponseWriter, r *http.Request, key string) {
+	query := r.URL.Query()
+	switch r.Method {
+	case http.MethodGet:
+		var pairs []*consulapi.KVPair
+		if query.Has("recurse") {
+			for k, pair := range f.kv {
+				if strings.HasPrefix(k, key) {
+					pairs = append(pairs, pair)
+				}
+			}
+			sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+		} else if pair, ok := f.kv[key]; ok {
+			pairs = append(pairs, pair)
+		}
+		if len(pairs) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, pairs)
+	case http.MethodPut, http.MethodDelete:
+		if f.beforeWrite != nil {
+			f.beforeWrite(f)
+		}
+		var index uint64
+		if pair, ok := f.kv[key]; ok {
+			index = pair.ModifyIndex
+		}
+		if cas := query.Get("cas"); cas != "" && cas != strconv.FormatUint(index, 10) {
+			writeJSON(w, false)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.kv, key)
+		} else {
+			body, _ := io.ReadAll(r.Body)
+			f.set(key, body)
+			f.kv[key].Flags, _ = strconv.ParseUint(query.Get("flags"), 10, 64)
+		}
+		writeJSON(w, true)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,310 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-cleanhttp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errMissingProvider   = "missing: spec.provider.consul"
+	errMissingAddress    = "missing: spec.provider.consul.address"
+	errInvalidAddress    = "invalid spec.provider.consul.address: %w"
+	errCABundleScheme    = "invalid spec.provider.consul.caBundle: only used with an https address"
+	errInvalidAuth       = "invalid spec.provider.consul.auth: exactly one of tokenSecretRef or kubernetes must be set"
+	errInvalidToken      = "invalid spec.provider.consul.auth.tokenSecretRef: %w"
+	errMissingAuthMethod = "missing: spec.provider.consul.auth.kubernetes.authMethod"
+	errInvalidSA         = "invalid spec.provider.consul.auth.kubernetes.serviceAccountRef: %w"
+	errGetKubeSATokenReq = "cannot request Kubernetes service account token for service account %q: %w"
+	errLogin             = "unable to log in with auth method %q: %w"
+	errMissingSAName     = "service account name is required"
+	errInvalidCABundle   = "invalid spec.provider.consul.caBundle: no certificates found"
+	errEnvToken          = "the %s environment variable of the operator is set, the operator only uses the token of spec.provider.consul.auth"
+
+	// serviceAccountLifespan is the lifetime of the service account token used to log in, in seconds.
+	serviceAccountLifespan = 600
+	// requestTimeout bounds each request to the HTTP API.
+	requestTimeout = 30 * time.Second
+)
+
+// https://github.com/external-secrets/external-secrets/issues/644
+var _ esv1beta1.SecretsClient = &Client{}
+var _ esv1beta1.Provider = &Provider{}
+
+// Provider reads and writes keys of the Consul KV store.
+type Provider struct {
+	// corev1 requests service account tokens, the in-cluster client is used if nil.
+	corev1 typedcorev1.CoreV1Interface
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Consul: &esv1beta1.ConsulProvider{},
+	})
+}
+
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadWrite
+}
+
+// Features returns the optional features the provider supports.
+func (p *Provider) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{
+		FindByName:      true,
+		FindByPath:      true,
+		PushWholeSecret: true,
+		DeleteSecret:    true,
+		SecretExists:    true,
+	}
+}
+
+// NewClient resolves the ACL token of the store, logging in with the Kubernetes auth method if configured.
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	spec, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		kube:      kube,
+		store:     spec,
+		storeKind: store.GetKind(),
+		namespace: namespace,
+	}
+
+	// allow SecretStore controller validation to pass
+	// when using referent namespace.
+	if c.storeKind == esv1beta1.ClusterSecretStoreKind && c.namespace == "" && isReferentSpec(spec) {
+		return c, nil
+	}
+
+	if spec.Auth == nil {
+		if err := checkEnvToken(); err != nil {
+			return nil, err
+		}
+	}
+	var token string
+	if auth := spec.Auth; auth != nil {
+		switch {
+		case auth.TokenSecretRef != nil:
+			token, err = resolvers.SecretKeyRef(ctx, kube, c.storeKind, namespace, auth.TokenSecretRef)
+			if err != nil {
+				return nil, err
+			}
+		case auth.Kubernetes != nil:
+			jwt, err := p.serviceAccountToken(ctx, c.storeKind, namespace, auth.Kubernetes.ServiceAccountRef)
+			if err != nil {
+				return nil, err
+			}
+			token, err = login(ctx, spec, auth.Kubernetes.AuthMethod, jwt)
+			if err != nil {
+				return nil, fmt.Errorf(errLogin, auth.Kubernetes.AuthMethod, err)
+			}
+			c.loggedIn = true
+		}
+	}
+	c.api, err = newAPIClient(spec, token)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// checkEnvToken returns an error if the token of the operator is set in its environment.
+// The client library falls back to that token if the token is empty, which would grant
+// stores without auth the access of the operator.
+func checkEnvToken() error {
+	for _, env := range []string{consulapi.HTTPTokenEnvName, consulapi.HTTPTokenFileEnvName} {
+		if os.Getenv(env) != "" {
+			return fmt.Errorf(errEnvToken, env)
+		}
+	}
+	return nil
+}
+
+// newAPIClient returns a client of the HTTP API authenticated with the ACL token.
+// The datacenter, namespace and partition of the store apply to all requests.
+func newAPIClient(spec *esv1beta1.ConsulProvider, token string) (*consulapi.Client, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+	if len(spec.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(spec.CABundle) {
+			return nil, errors.New(errInvalidCABundle)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	httpClient, err := consulapi.NewHttpClient(transport, consulapi.TLSConfig{})
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Timeout = requestTimeout
+	return consulapi.NewClient(&consulapi.Config{
+		Address:    spec.Address,
+		Datacenter: spec.Datacenter,
+		Namespace:  spec.Namespace,
+		Partition:  spec.Partition,
+		Token:      token,
+		HttpClient: httpClient,
+	})
+}
+
+// login exchanges the bearer token for an ACL token with the auth method.
+func login(ctx context.Context, spec *esv1beta1.ConsulProvider, authMethod, bearerToken string) (string, error) {
+	api, err := newAPIClient(spec, "")
+	if err != nil {
+		return "", err
+	}
+	token, _, err := api.ACL().Login(&consulapi.ACLLoginParams{AuthMethod: authMethod, BearerToken: bearerToken}, writeOptions(ctx))
+	if err != nil {
+		return "", err
+	}
+	if token.SecretID == "" {
+		return "", errors.New("login returned no token")
+	}
+	return token.SecretID, nil
+}
+
+// serviceAccountToken requests a short-lived token of the service account with the TokenRequest API.
+func (p *Provider) serviceAccountToken(ctx context.Context, storeKind, namespace string, ref esmeta.ServiceAccountSelector) (string, error) {
+	corev1 := p.corev1
+	if corev1 == nil {
+		// controller-runtime/client does not support TokenRequest or other subresource APIs
+		// so we need to construct our own client and use it to create a TokenRequest
+		restCfg, err := ctrlcfg.GetConfig()
+		if err != nil {
+			return "", err
+		}
+		clientset, err := kubernetes.NewForConfig(restCfg)
+		if err != nil {
+			return "", err
+		}
+		corev1 = clientset.CoreV1()
+	}
+	if storeKind == esv1beta1.ClusterSecretStoreKind && ref.Namespace != nil {
+		namespace = *ref.Namespace
+	}
+	expirationSeconds := int64(serviceAccountLifespan)
+	tokenRequest := &authenticationv1.TokenRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         ref.Audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	resp, err := corev1.ServiceAccounts(namespace).CreateToken(ctx, ref.Name, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf(errGetKubeSATokenReq, ref.Name, err)
+	}
+	return resp.Status.Token, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	spec, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Address == "" {
+		return nil, errors.New(errMissingAddress)
+	}
+	u, err := url.Parse(spec.Address)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidAddress, err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf(errInvalidAddress, errors.New("an http or https URL is required"))
+	}
+	if len(spec.CABundle) > 0 && u.Scheme != "https" {
+		return nil, errors.New(errCABundleScheme)
+	}
+
+	auth := spec.Auth
+	if auth == nil {
+		return nil, nil
+	}
+	if (auth.TokenSecretRef == nil) == (auth.Kubernetes == nil) {
+		return nil, errors.New(errInvalidAuth)
+	}
+	if auth.TokenSecretRef != nil {
+		if err := validateSecretKeySelector(store, *auth.TokenSecretRef); err != nil {
+			return nil, fmt.Errorf(errInvalidToken, err)
+		}
+	}
+	if k8s := auth.Kubernetes; k8s != nil {
+		if k8s.AuthMethod == "" {
+			return nil, errors.New(errMissingAuthMethod)
+		}
+		if k8s.ServiceAccountRef.Name == "" {
+			return nil, fmt.Errorf(errInvalidSA, errors.New(errMissingSAName))
+		}
+		if err := utils.ValidateReferentServiceAccountSelector(store, k8s.ServiceAccountRef); err != nil {
+			return nil, fmt.Errorf(errInvalidSA, err)
+		}
+	}
+	return nil, nil
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.ConsulProvider, error) {
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Consul == nil {
+		return nil, errors.New(errMissingProvider)
+	}
+	return storeSpec.Provider.Consul, nil
+}
+
+func validateSecretKeySelector(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if ref.Name == "" || ref.Key == "" {
+		return errors.New("secret name and key are required")
+	}
+	return utils.ValidateReferentSecretSelector(store, ref)
+}
+
+// isReferentSpec returns true if the token or service account of a ClusterSecretStore
+// is resolved in the namespace of the ExternalSecret.
+func isReferentSpec(spec *esv1beta1.ConsulProvider) bool {
+	if spec.Auth == nil {
+		return false
+	}
+	if ref := spec.Auth.TokenSecretRef; ref != nil && ref.Namespace == nil {
+		return true
+	}
+	if k8s := spec.Auth.Kubernetes; k8s != nil && k8s.ServiceAccountRef.Namespace == nil {
+		return true
+	}
+	return false
+}
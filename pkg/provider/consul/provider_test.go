@@ -0,0 +1,142 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestValidateStore(t *testing.T) {
+	kubernetesAuth := &esv1beta1.ConsulAuth{Kubernetes: &esv1beta1.ConsulKubernetesAuth{
+		AuthMethod:        "kubernetes",
+		ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "external-secrets"},
+	}}
+	tests := []struct {
+		name    string
+		kind    string
+		spec    *esv1beta1.ConsulProvider
+		wantErr string
+	}{
+		{
+			name: "valid token auth",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.ConsulProvider{Address: "https://consul.example.com:8501", CABundle: []byte("ca"), Auth: tokenAuth},
+		},
+		{
+			name: "valid kubernetes auth",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.ConsulProvider{Address: "http://consul.consul:8500", Namespace: "team-a", Partition: "apps", Auth: kubernetesAuth},
+		},
+		{
+			name: "anonymous",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.ConsulProvider{Address: "http://consul.consul:8500"},
+		},
+		{
+			name:    "missing provider",
+			kind:    esv1beta1.SecretStoreKind,
+			wantErr: errMissingProvider,
+		},
+		{
+			name:    "missing address",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.ConsulProvider{Auth: tokenAuth},
+			wantErr: errMissingAddress,
+		},
+		{
+			name:    "invalid address",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.ConsulProvider{Address: "consul.consul:8500"},
+			wantErr: "invalid spec.provider.consul.address: an http or https URL is required",
+		},
+		{
+			name:    "caBundle with http",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.ConsulProvider{Address: "http://consul.consul:8500", CABundle: []byte("ca")},
+			wantErr: errCABundleScheme,
+		},
+		{
+			name:    "empty auth",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.ConsulProvider{Address: "http://consul.consul:8500", Auth: &esv1beta1.ConsulAuth{}},
+			wantErr: errInvalidAuth,
+		},
+		{
+			name: "token and kubernetes auth",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.ConsulProvider{Address: "http://consul.consul:8500", Auth: &esv1beta1.ConsulAuth{
+				TokenSecretRef: tokenAuth.TokenSecretRef,
+				Kubernetes:     kubernetesAuth.Kubernetes,
+			}},
+			wantErr: errInvalidAuth,
+		},
+		{
+			name: "namespace with SecretStore",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.ConsulProvider{Address: "http://consul.consul:8500", Auth: &esv1beta1.ConsulAuth{
+				TokenSecretRef: &esmeta.SecretKeySelector{Name: "consul", Key: "token", Namespace: ptr.To("other")},
+			}},
+			wantErr: "invalid spec.provider.consul.auth.tokenSecretRef: namespace not allowed with namespaced SecretStore",
+		},
+		{
+			name: "namespace with ClusterSecretStore",
+			kind: esv1beta1.ClusterSecretStoreKind,
+			spec: &esv1beta1.ConsulProvider{Address: "http://consul.consul:8500", Auth: &esv1beta1.ConsulAuth{Kubernetes: &esv1beta1.ConsulKubernetesAuth{
+				AuthMethod:        "kubernetes",
+				ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "external-secrets", Namespace: ptr.To("external-secrets")},
+			}}},
+		},
+		{
+			name: "missing auth method",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.ConsulProvider{Address: "http://consul.consul:8500", Auth: &esv1beta1.ConsulAuth{Kubernetes: &esv1beta1.ConsulKubernetesAuth{
+				ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "external-secrets"},
+			}}},
+			wantErr: errMissingAuthMethod,
+		},
+		{
+			name: "missing service account",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.ConsulProvider{Address: "http://consul.consul:8500", Auth: &esv1beta1.ConsulAuth{Kubernetes: &esv1beta1.ConsulKubernetesAuth{
+				AuthMethod: "kubernetes",
+			}}},
+			wantErr: "invalid spec.provider.consul.auth.kubernetes.serviceAccountRef: service account name is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{Kind: tt.kind},
+				Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Consul: tt.spec}},
+			}
+			_, err := (&Provider{}).ValidateStore(store)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -17,7 +17,9 @@ package doppler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -118,8 +120,23 @@ func (c *Client) DeleteSecret(_ context.Context, ref esv1beta1.PushSecretRemoteR
 	return nil
 }
 
-func (c *Client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
-	return false, fmt.Errorf("not implemented")
+func (c *Client) SecretExists(_ context.Context, ref esv1beta1.PushSecretRemoteRef) (bool, error) {
+	request := dClient.SecretRequest{
+		Name:    ref.GetRemoteKey(),
+		Project: c.project,
+		Config:  c.config,
+	}
+
+	_, err := c.doppler.GetSecret(request)
+	var apiErr *dClient.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf(errGetSecret, ref.GetRemoteKey(), err)
+	}
+
+	return true, nil
 }
 
 func (c *Client) PushSecret(_ context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
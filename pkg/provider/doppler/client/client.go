@@ -52,6 +52,8 @@ type APIError struct {
 	Err     error
 	Message string
 	Data    string
+	// StatusCode is the HTTP status code of a failed API request.
+	StatusCode int
 }
 
 type apiResponse struct {
@@ -163,7 +165,7 @@ func (c *DopplerClient) GetSecret(request SecretRequest) (*SecretResponse, error
 	}
 
 	if data.Value.Computed == nil {
-		return nil, &APIError{Message: fmt.Sprintf("secret '%s' not found", request.Name)}
+		return nil, &APIError{Message: fmt.Sprintf("secret '%s' not found", request.Name), StatusCode: http.StatusNotFound}
 	}
 
 	return &SecretResponse{Name: data.Name, Value: *data.Value.Computed}, nil
@@ -325,11 +327,11 @@ func (c *DopplerClient) performRequest(path, method string, headers headers, par
 			var errResponse apiErrorResponse
 			err := json.Unmarshal(bodyResponse, &errResponse)
 			if err != nil {
-				return response, &APIError{Err: err, Message: "unable to unmarshal error JSON payload"}
+				return response, &APIError{Err: err, Message: "unable to unmarshal error JSON payload", StatusCode: r.StatusCode}
 			}
-			return response, &APIError{Err: nil, Message: strings.Join(errResponse.Messages, "\n")}
+			return response, &APIError{Err: nil, Message: strings.Join(errResponse.Messages, "\n"), StatusCode: r.StatusCode}
 		}
-		return nil, &APIError{Err: fmt.Errorf("%d status code; %d bytes", r.StatusCode, len(bodyResponse)), Message: "unable to load response"}
+		return nil, &APIError{Err: fmt.Errorf("%d status code; %d bytes", r.StatusCode, len(bodyResponse)), Message: "unable to load response", StatusCode: r.StatusCode}
 	}
 
 	if success && err != nil {
@@ -17,6 +17,7 @@ package doppler
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -289,6 +290,49 @@ func ErrorContains(out error, want string) bool {
 	return strings.Contains(out.Error(), want)
 }
 
+func TestSecretExists(t *testing.T) {
+	secretExists := func(pstc *dopplerTestCase) {
+		pstc.label = "secret exists"
+		pstc.request = client.SecretRequest{Name: validRemoteKey}
+	}
+
+	secretMissing := func(pstc *dopplerTestCase) {
+		pstc.label = "secret does not exist"
+		pstc.request = client.SecretRequest{Name: validRemoteKey}
+		pstc.response = nil
+		pstc.apiErr = &client.APIError{Message: "secret not found", StatusCode: http.StatusNotFound}
+	}
+
+	setClientError := func(pstc *dopplerTestCase) {
+		pstc.label = "invalid client error"
+		pstc.request = client.SecretRequest{Name: validRemoteKey}
+		pstc.response = nil
+		pstc.apiErr = &client.APIError{Message: "forbidden", StatusCode: http.StatusForbidden}
+		pstc.expectError = missingSecretErr
+	}
+
+	testCases := []struct {
+		tc     *dopplerTestCase
+		exists bool
+	}{
+		{makeValidDopplerTestCaseCustom(secretExists), true},
+		{makeValidDopplerTestCaseCustom(secretMissing), false},
+		{makeValidDopplerTestCaseCustom(setClientError), false},
+	}
+
+	c := Client{}
+	for k, tc := range testCases {
+		c.doppler = tc.tc.fakeClient
+		exists, err := c.SecretExists(context.Background(), makeValidPushRemoteRef())
+		if !ErrorContains(err, tc.tc.expectError) {
+			t.Errorf("[%d] unexpected error: %v, expected: '%s'", k, err, tc.tc.expectError)
+		}
+		if exists != tc.exists {
+			t.Errorf("[%d] %s: unexpected result: %v, expected: %v", k, tc.tc.label, exists, tc.exists)
+		}
+	}
+}
+
 func TestDeleteSecret(t *testing.T) {
 	deleteSecret := func(pstc *updateSecretCase) {
 		pstc.label = "delete secret"
@@ -112,6 +112,9 @@ func (c *Client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecre
 	if manager, ok := gcpSecret.Labels[managedByKey]; !ok || manager != managedByValue {
 		return nil
 	}
+	if !utils.IsRemoteOwner(remoteRef, gcpSecret.Annotations[utils.RemoteOwnerKey]) {
+		return nil
+	}
 
 	deleteSecretVersionReq := &secretmanagerpb.DeleteSecretRequest{
 		Name: fmt.Sprintf("projects/%s/secrets/%s", c.store.ProjectID, remoteRef.GetRemoteKey()),
@@ -122,6 +125,15 @@ func (c *Client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecre
 	return err
 }
 
+// newOwnerAnnotations returns the annotations which mark a new secret as owned by the PushSecret.
+func newOwnerAnnotations(pushSecretData esv1beta1.PushSecretData) map[string]string {
+	owner, _ := utils.PushSecretOwner(pushSecretData)
+	if owner == "" {
+		return nil
+	}
+	return map[string]string{utils.RemoteOwnerKey: owner}
+}
+
 func parseError(err error) error {
 	var gerr *apierror.APIError
 	if errors.As(err, &gerr) && gerr.GRPCStatus().Code() == codes.NotFound {
@@ -130,8 +142,28 @@ func parseError(err error) error {
 	return err
 }
 
-func (c *Client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
-	return false, fmt.Errorf("not implemented")
+// SecretExists checks if the latest version of the secret exists.
+// If a property is set, the secret value has to be a JSON object containing the property.
+func (c *Client) SecretExists(ctx context.Context, ref esv1beta1.PushSecretRemoteRef) (bool, error) {
+	if utils.IsNil(c.smClient) || c.store.ProjectID == "" {
+		return false, fmt.Errorf(errUninitalizedGCPProvider)
+	}
+
+	result, err := c.smClient.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", c.store.ProjectID, ref.GetRemoteKey(), defaultVersion),
+	})
+	metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMAccessSecretVersion, err)
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if ref.GetProperty() == "" {
+		return true, nil
+	}
+	return getDataByProperty(result.Payload.GetData(), ref.GetProperty()).Exists(), nil
 }
 
 // PushSecret pushes a kubernetes secret key into gcp provider Secret.
@@ -159,6 +191,7 @@ func (c *Client) PushSecret(ctx context.Context, secret *corev1.Secret, pushSecr
 				Labels: map[string]string{
 					managedByKey: managedByValue,
 				},
+				Annotations: newOwnerAnnotations(pushSecretData),
 				Replication: &secretmanagerpb.Replication{
 					Replication: &secretmanagerpb.Replication_Automatic_{
 						Automatic: &secretmanagerpb.Replication_Automatic{},
@@ -216,6 +249,7 @@ func (c *Client) PushSecret(ctx context.Context, secret *corev1.Secret, pushSecr
 	}
 
 	if gcpVersion != nil && gcpVersion.Payload != nil && !builder.needUpdate(gcpVersion.Payload.Data) {
+		utils.SetRemoteVersion(pushSecretData, gcpVersion.Name)
 		return nil
 	}
 
@@ -236,9 +270,13 @@ func (c *Client) PushSecret(ctx context.Context, secret *corev1.Secret, pushSecr
 		},
 	}
 
-	_, err = c.smClient.AddSecretVersion(ctx, addSecretVersionReq)
+	version, err := c.smClient.AddSecretVersion(ctx, addSecretVersionReq)
 	metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMAddSecretVersion, err)
-	return err
+	if err != nil {
+		return err
+	}
+	utils.SetRemoteVersion(pushSecretData, version.GetName())
+	return nil
 }
 
 // GetAllSecrets syncs multiple secrets from gcp provider into a single Kubernetes Secret.
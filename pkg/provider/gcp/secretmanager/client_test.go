@@ -447,6 +447,24 @@ func TestDeleteSecret(t *testing.T) {
 				},
 			},
 		},
+		"Owned by other PushSecret": {
+			args: args{
+				client: fakeClient,
+				getSecretOutput: fakesm.SecretMockReturn{
+					Secret: &secretmanagerpb.Secret{
+						Name: "projects/foo/secret/bar",
+						Labels: map[string]string{
+							"managed-by": "external-secrets",
+						},
+						Annotations: map[string]string{
+							"owned-by": "cluster/ns/other",
+						},
+					},
+					Err: nil,
+				},
+				deleteSecretErr: errors.New("secret of other PushSecret deleted"),
+			},
+		},
 		"Secret Not Found": {
 			args: args{
 				client: fakeClient,
@@ -483,7 +501,7 @@ func TestDeleteSecret(t *testing.T) {
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			ref := testingfake.PushSecretData{RemoteKey: "fake-key"}
+			ref := testingfake.PushSecretData{RemoteKey: "fake-key", Owner: "cluster/ns/ps"}
 			client := Client{
 				smClient: &tc.args.client,
 				store: &esv1beta1.GCPSMProvider{
@@ -508,6 +526,86 @@ func TestDeleteSecret(t *testing.T) {
 	}
 }
 
+func TestSecretExists(t *testing.T) {
+	fErr := status.Error(codes.NotFound, "failed")
+	notFoundError, _ := apierror.FromError(fErr)
+	pErr := status.Error(codes.PermissionDenied, "failed")
+	permissionDeniedError, _ := apierror.FromError(pErr)
+	tests := map[string]struct {
+		ref           testingfake.PushSecretData
+		accessVersion fakesm.AccessSecretVersionMockReturn
+		want          bool
+		wantErr       error
+	}{
+		"secret exists": {
+			ref: testingfake.PushSecretData{RemoteKey: "fake-key"},
+			accessVersion: fakesm.AccessSecretVersionMockReturn{
+				Res: &secretmanagerpb.AccessSecretVersionResponse{
+					Payload: &secretmanagerpb.SecretPayload{Data: []byte("value")},
+				},
+			},
+			want: true,
+		},
+		"secret does not exist": {
+			ref: testingfake.PushSecretData{RemoteKey: "fake-key"},
+			accessVersion: fakesm.AccessSecretVersionMockReturn{
+				Err: notFoundError,
+			},
+			want: false,
+		},
+		"property exists": {
+			ref: testingfake.PushSecretData{RemoteKey: "fake-key", Property: "foo"},
+			accessVersion: fakesm.AccessSecretVersionMockReturn{
+				Res: &secretmanagerpb.AccessSecretVersionResponse{
+					Payload: &secretmanagerpb.SecretPayload{Data: []byte(`{"foo":"bar"}`)},
+				},
+			},
+			want: true,
+		},
+		"property does not exist": {
+			ref: testingfake.PushSecretData{RemoteKey: "fake-key", Property: "baz"},
+			accessVersion: fakesm.AccessSecretVersionMockReturn{
+				Res: &secretmanagerpb.AccessSecretVersionResponse{
+					Payload: &secretmanagerpb.SecretPayload{Data: []byte(`{"foo":"bar"}`)},
+				},
+			},
+			want: false,
+		},
+		"error accessing secret": {
+			ref: testingfake.PushSecretData{RemoteKey: "fake-key"},
+			accessVersion: fakesm.AccessSecretVersionMockReturn{
+				Err: permissionDeniedError,
+			},
+			wantErr: errors.New("failed"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			smClient := fakesm.MockSMClient{}
+			smClient.NewAccessSecretVersionFn(tc.accessVersion)
+			client := Client{
+				smClient: &smClient,
+				store: &esv1beta1.GCPSMProvider{
+					ProjectID: "foo",
+				},
+			}
+			got, err := client.SecretExists(context.Background(), tc.ref)
+			if tc.wantErr != nil {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr.Error()) {
+					t.Errorf("SecretExists() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SecretExists() unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("SecretExists() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestPushSecret(t *testing.T) {
 	secretKey := "secret-key"
 	remoteKey := "/baz"
@@ -543,6 +641,22 @@ func TestPushSecret(t *testing.T) {
 		},
 	}
 
+	otherOwnerSecret := secretmanagerpb.Secret{
+		Name: "projects/default/secrets/baz",
+		Replication: &secretmanagerpb.Replication{
+			Replication: &secretmanagerpb.Replication_Automatic_{
+				Automatic: &secretmanagerpb.Replication_Automatic{},
+			},
+		},
+		Labels: map[string]string{
+			"managed-by": "external-secrets",
+		},
+		Annotations: map[string]string{
+			"owned-by": "ns/other",
+		},
+	}
+	ownerError := fmt.Errorf("remote secret %v is owned by ns/other", remoteKey)
+
 	smtc := secretManagerTestCase{
 		mockClient:     &fakesm.MockSMClient{},
 		apiInput:       makeValidAPIInput(),
@@ -583,16 +697,43 @@ func TestPushSecret(t *testing.T) {
 		AccessSecretVersionMockReturn fakesm.AccessSecretVersionMockReturn
 		AddSecretVersionMockReturn    fakesm.AddSecretVersionMockReturn
 		CreateSecretMockReturn        fakesm.SecretMockReturn
+		Owner                         string
+		Adopt                         bool
 	}
 
 	type want struct {
-		err error
+		err     error
+		version string
 	}
 	tests := []struct {
 		desc string
 		args args
 		want want
 	}{
+		{
+			desc: "reports the version of the pushed secret",
+			args: args{
+				mock:                          smtc.mockClient,
+				GetSecretMockReturn:           fakesm.SecretMockReturn{Secret: &secret, Err: nil},
+				AccessSecretVersionMockReturn: fakesm.AccessSecretVersionMockReturn{Res: &res, Err: nil},
+				AddSecretVersionMockReturn: fakesm.AddSecretVersionMockReturn{SecretVersion: &secretmanagerpb.SecretVersion{
+					Name: "projects/default/secrets/baz/versions/2",
+				}, Err: nil}},
+			want: want{
+				version: "projects/default/secrets/baz/versions/2",
+			},
+		},
+		{
+			desc: "reports the version of an unchanged secret",
+			args: args{
+				mock:                          smtc.mockClient,
+				AccessSecretVersionMockReturn: fakesm.AccessSecretVersionMockReturn{Res: &res2, Err: nil},
+				GetSecretMockReturn:           fakesm.SecretMockReturn{Secret: &secret, Err: nil},
+			},
+			want: want{
+				version: "projects/default/secrets/baz",
+			},
+		},
 		{
 			desc: "SetSecret successfully pushes a secret",
 			args: args{
@@ -678,6 +819,60 @@ func TestPushSecret(t *testing.T) {
 				err: labelError,
 			},
 		},
+		{
+			desc: "secret not pushed if owned by another PushSecret",
+			args: args{
+				mock:                smtc.mockClient,
+				GetSecretMockReturn: fakesm.SecretMockReturn{Secret: &otherOwnerSecret, Err: nil},
+				Owner:               "ns/ps",
+			},
+			want: want{
+				err: ownerError,
+			},
+		},
+		{
+			desc: "secret of another PushSecret is adopted",
+			args: args{
+				mock:                          smtc.mockClient,
+				GetSecretMockReturn:           fakesm.SecretMockReturn{Secret: &otherOwnerSecret, Err: nil},
+				UpdateSecretReturn:            fakesm.SecretMockReturn{Secret: &secret, Err: nil},
+				AccessSecretVersionMockReturn: fakesm.AccessSecretVersionMockReturn{Res: &res, Err: nil},
+				AddSecretVersionMockReturn:    fakesm.AddSecretVersionMockReturn{SecretVersion: &secretVersion, Err: nil},
+				Owner:                         "ns/ps",
+				Adopt:                         true,
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			desc: "secret not managed-by external-secrets is not adopted without opt-in",
+			args: args{
+				mock:                smtc.mockClient,
+				GetSecretMockReturn: fakesm.SecretMockReturn{Secret: &wrongLabelSecret, Err: nil},
+				Owner:               "ns/ps",
+				Adopt:               true,
+			},
+			want: want{
+				err: labelError,
+			},
+		},
+		{
+			desc: "secret not managed-by external-secrets is adopted with opt-in",
+			args: args{
+				mock:                          smtc.mockClient,
+				GetSecretMockReturn:           fakesm.SecretMockReturn{Secret: &wrongLabelSecret, Err: nil},
+				UpdateSecretReturn:            fakesm.SecretMockReturn{Secret: &secret, Err: nil},
+				AccessSecretVersionMockReturn: fakesm.AccessSecretVersionMockReturn{Res: &res, Err: nil},
+				AddSecretVersionMockReturn:    fakesm.AddSecretVersionMockReturn{SecretVersion: &secretVersion, Err: nil},
+				Metadata:                      &apiextensionsv1.JSON{Raw: []byte(`{"adoptUnmanaged": true}`)},
+				Owner:                         "ns/ps",
+				Adopt:                         true,
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		{
 			desc: "don't push a secret with the same key and value",
 			args: args{
@@ -754,7 +949,11 @@ func TestPushSecret(t *testing.T) {
 				SecretKey: secretKey,
 				Metadata:  tc.args.Metadata,
 				RemoteKey: "/baz",
+				Owner:     tc.args.Owner,
+				Adopt:     tc.args.Adopt,
 			}
+			var version string
+			data.Version = &version
 
 			err := c.PushSecret(context.Background(), s, data)
 			if err != nil {
@@ -771,6 +970,9 @@ func TestPushSecret(t *testing.T) {
 			if tc.want.err != nil {
 				t.Errorf("expected to receive an error but got nil")
 			}
+			if tc.want.version != "" && version != tc.want.version {
+				t.Errorf("received an unexpected version: %q, expected %q", version, tc.want.version)
+			}
 		})
 	}
 }
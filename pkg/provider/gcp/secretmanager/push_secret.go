@@ -23,11 +23,14 @@ import (
 	"github.com/tidwall/sjson"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 type Metadata struct {
 	Annotations map[string]string `json:"annotations"`
 	Labels      map[string]string `json:"labels"`
+	// AdoptUnmanaged allows the Adopt update policy to take over secrets not managed by external-secrets.
+	AdoptUnmanaged bool `json:"adoptUnmanaged,omitempty"`
 }
 
 func newPushSecretBuilder(payload []byte, data esv1beta1.PushSecretData) (pushSecretBuilder, error) {
@@ -59,9 +62,19 @@ type psBuilder struct {
 	pushSecretData esv1beta1.PushSecretData
 }
 
-func (b *psBuilder) buildMetadata(_, labels map[string]string) (map[string]string, map[string]string, error) {
+func (b *psBuilder) buildMetadata(annotations, labels map[string]string) (map[string]string, map[string]string, error) {
+	owner, _ := utils.PushSecretOwner(b.pushSecretData)
 	if manager, ok := labels[managedByKey]; !ok || manager != managedByValue {
-		return nil, nil, fmt.Errorf("secret %v is not managed by external secrets", b.pushSecretData.GetRemoteKey())
+		adoptUnmanaged, err := utils.AdoptUnmanaged(b.pushSecretData)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode PushSecret metadata: %w", err)
+		}
+		if !adoptUnmanaged {
+			return nil, nil, fmt.Errorf("secret %v is not managed by external secrets", b.pushSecretData.GetRemoteKey())
+		}
+	}
+	if err := utils.CheckRemoteOwner(b.pushSecretData, b.pushSecretData.GetRemoteKey(), annotations[utils.RemoteOwnerKey]); err != nil {
+		return nil, nil, err
 	}
 
 	var metadata Metadata
@@ -81,7 +94,15 @@ func (b *psBuilder) buildMetadata(_, labels map[string]string) (map[string]strin
 	}
 	newLabels[managedByKey] = managedByValue
 
-	return metadata.Annotations, newLabels, nil
+	newAnnotations := metadata.Annotations
+	if owner != "" {
+		if newAnnotations == nil {
+			newAnnotations = map[string]string{}
+		}
+		newAnnotations[utils.RemoteOwnerKey] = owner
+	}
+
+	return newAnnotations, newLabels, nil
 }
 
 func (b *psBuilder) needUpdate(original []byte) bool {
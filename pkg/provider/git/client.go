@@ -0,0 +1,349 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/provider/sops"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errNotImplemented   = "not implemented"
+	errFindByTags       = "find by tags is not supported"
+	errInvalidPattern   = "invalid find.path pattern %q: %w"
+	errPropertyNotFound = "property %s not found in file %s"
+	errNotAnObject      = "file %s: %s is not an object"
+	errParseFile        = "unable to parse file %s: %w"
+	errReadFile         = "unable to read file %s: %w"
+	errDecryptFile      = "unable to decrypt file %s: %w"
+	errNoKeys           = "file is encrypted, but spec.provider.git.keys is not set"
+	errSSHKey           = "unable to parse ssh private key: %w"
+	errKnownHosts       = "unable to parse known hosts: %w"
+
+	defaultUsername = "git"
+)
+
+// Client reads the files of a repository at the ref of the store.
+type Client struct {
+	kube      kclient.Client
+	store     *esv1beta1.GitProvider
+	storeKind string
+	// namespace of the ExternalSecret referencing the store.
+	namespace       string
+	refreshInterval time.Duration
+	auth            transport.AuthMethod
+	keys            *sops.Keyring
+	cacheKey        string
+	// resolved is false for a ClusterSecretStore with referent credentials without an ExternalSecret.
+	resolved bool
+
+	// snapshot is the tree read by this client, so that all files come from the same commit.
+	snapshot *object.Tree
+}
+
+func (c *Client) resolveAuth(ctx context.Context) error {
+	auth := c.store.Auth
+	switch {
+	case auth == nil:
+		return nil
+	case auth.BasicAuth != nil:
+		password, err := resolvers.SecretKeyRef(ctx, c.kube, c.storeKind, c.namespace, &auth.BasicAuth.PasswordSecretRef)
+		if err != nil {
+			return err
+		}
+		username := auth.BasicAuth.Username
+		if username == "" {
+			username = defaultUsername
+		}
+		c.auth = &githttp.BasicAuth{Username: username, Password: password}
+	case auth.SSH != nil:
+		privateKey, err := resolvers.SecretKeyRef(ctx, c.kube, c.storeKind, c.namespace, &auth.SSH.PrivateKeySecretRef)
+		if err != nil {
+			return err
+		}
+		passphrase := ""
+		if ref := auth.SSH.PassphraseSecretRef; ref != nil {
+			passphrase, err = resolvers.SecretKeyRef(ctx, c.kube, c.storeKind, c.namespace, ref)
+			if err != nil {
+				return err
+			}
+		}
+		knownHosts, err := resolvers.SecretKeyRef(ctx, c.kube, c.storeKind, c.namespace, &auth.SSH.KnownHostsSecretRef)
+		if err != nil {
+			return err
+		}
+		username := auth.SSH.Username
+		if username == "" {
+			username = sshUser(c.store.URL)
+		}
+		keys, err := gitssh.NewPublicKeys(username, []byte(privateKey), passphrase)
+		if err != nil {
+			return fmt.Errorf(errSSHKey, err)
+		}
+		keys.HostKeyCallback, err = hostKeyCallback(knownHosts)
+		if err != nil {
+			return fmt.Errorf(errKnownHosts, err)
+		}
+		c.auth = keys
+	}
+	return nil
+}
+
+// sshUser returns the user of an ssh or scp-like URL, or git.
+func sshUser(repoURL string) string {
+	repoURL = strings.TrimPrefix(repoURL, "ssh://")
+	if user, rest, ok := strings.Cut(repoURL, "@"); ok && user != "" && !strings.ContainsAny(user, "/:") && rest != "" {
+		return user
+	}
+	return defaultUsername
+}
+
+// tree returns the tree of the ref, shared by all calls of this client.
+func (c *Client) tree(ctx context.Context) (*object.Tree, error) {
+	if c.snapshot != nil {
+		return c.snapshot, nil
+	}
+	tree, err := clones.get(c.cacheKey).tree(ctx, &remote{
+		url:             c.store.URL,
+		ref:             c.store.Ref,
+		auth:            c.auth,
+		caBundle:        c.store.CABundle,
+		refreshInterval: c.refreshInterval,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.snapshot = tree
+	return tree, nil
+}
+
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	data, err := c.file(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return data, nil
+	}
+	doc, err := document(ref.Key, data)
+	if err != nil {
+		return nil, err
+	}
+	val := gjson.GetBytes(doc, ref.Property)
+	if !val.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	return resultBytes(val), nil
+}
+
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	data, err := c.file(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := document(ref.Key, data)
+	if err != nil {
+		return nil, err
+	}
+	val := gjson.ParseBytes(doc)
+	if ref.Property != "" {
+		val = val.Get(ref.Property)
+		if !val.Exists() {
+			return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+		}
+	}
+	if !val.IsObject() {
+		return nil, fmt.Errorf(errNotAnObject, ref.Key, ref.Property)
+	}
+	secretMap := make(map[string][]byte)
+	val.ForEach(func(key, value gjson.Result) bool {
+		secretMap[key.String()] = resultBytes(value)
+		return true
+	})
+	return secretMap, nil
+}
+
+// GetAllSecrets returns the files whose path matches find.name and find.path.
+// find.path is a glob pattern if it contains a pattern character, otherwise a directory or path prefix.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Tags != nil {
+		return nil, errors.New(errFindByTags)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+	matchPath := func(string) bool { return true }
+	if ref.Path != nil {
+		pattern := *ref.Path
+		if strings.ContainsAny(pattern, `*?[\`) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf(errInvalidPattern, pattern, err)
+			}
+			matchPath = func(name string) bool {
+				ok, _ := path.Match(pattern, name)
+				return ok
+			}
+		} else {
+			matchPath = func(name string) bool { return strings.HasPrefix(name, pattern) }
+		}
+	}
+
+	tree, err := c.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secretMap := make(map[string][]byte)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if hidden(f.Name) || !matchPath(f.Name) || (matcher != nil && !matcher.MatchName(f.Name)) {
+			return nil
+		}
+		data, err := c.read(f)
+		if err != nil {
+			return err
+		}
+		secretMap[f.Name] = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return secretMap, nil
+}
+
+// hidden returns true if the file or one of its directories is hidden, like .gitignore or .github/.
+func hidden(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// file returns the content of the file at the path, decrypted if needed.
+func (c *Client) file(ctx context.Context, name string) ([]byte, error) {
+	tree, err := c.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f, err := tree.File(strings.TrimPrefix(name, "/"))
+	if errors.Is(err, object.ErrFileNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errReadFile, name, err)
+	}
+	return c.read(f)
+}
+
+// read returns the content of the file. age-encrypted files are decrypted to their plaintext,
+// SOPS-encrypted documents to JSON.
+func (c *Client) read(f *object.File) ([]byte, error) {
+	r, err := f.Reader()
+	if err != nil {
+		return nil, fmt.Errorf(errReadFile, f.Name, err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf(errReadFile, f.Name, err)
+	}
+
+	age, encrypted := sops.IsAgeFile(data), sops.IsEncrypted(data)
+	if !age && !encrypted {
+		return data, nil
+	}
+	if c.keys == nil {
+		return nil, fmt.Errorf(errDecryptFile, f.Name, errors.New(errNoKeys))
+	}
+	if age {
+		data, err = c.keys.DecryptAge(data)
+	} else {
+		data, err = c.keys.Decrypt(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptFile, f.Name, err)
+	}
+	return data, nil
+}
+
+// document converts a YAML or JSON file to JSON.
+func document(name string, data []byte) ([]byte, error) {
+	doc, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf(errParseFile, name, err)
+	}
+	return doc, nil
+}
+
+// resultBytes returns strings as they are and other values as JSON.
+func resultBytes(val gjson.Result) []byte {
+	if val.Type == gjson.String {
+		return []byte(val.Str)
+	}
+	return []byte(val.Raw)
+}
+
+func (c *Client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New(errNotImplemented)
+}
+
+func (c *Client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New(errNotImplemented)
+}
+
+func (c *Client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
+	return false, errors.New(errNotImplemented)
+}
+
+// Validate fetches the repository and resolves the ref.
+// The credentials of a ClusterSecretStore with referent references are only known for an ExternalSecret.
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	if !c.resolved {
+		return esv1beta1.ValidationResultUnknown, nil
+	}
+	if _, err := c.tree(context.Background()); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *Client) Close(_ context.Context) error {
+	return nil
+}
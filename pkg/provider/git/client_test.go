@@ -0,0 +1,297 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/file"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func init() {
+	// serve file:// repositories in-process instead of running git
+	client.InstallProtocol("file", server.DefaultServer)
+}
+
+// testRepo is a repository pushed to a local bare repository.
+type testRepo struct {
+	t    *testing.T
+	work *gogit.Repository
+	dir  string
+	url  string
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	dir := t.TempDir()
+	work, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	bare := t.TempDir()
+	_, err = gogit.PlainInit(bare, true)
+	require.NoError(t, err)
+	url := "file://" + bare
+	_, err = work.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{url}})
+	require.NoError(t, err)
+	return &testRepo{t: t, work: work, dir: dir, url: url}
+}
+
+// commit writes the files and commits them to master.
+func (r *testRepo) commit(files map[string]string) plumbing.Hash {
+	r.t.Helper()
+	wt, err := r.work.Worktree()
+	require.NoError(r.t, err)
+	for name, content := range files {
+		p := filepath.Join(r.dir, name)
+		require.NoError(r.t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(r.t, os.WriteFile(p, []byte(content), 0o600))
+		_, err = wt.Add(name)
+		require.NoError(r.t, err)
+	}
+	hash, err := wt.Commit("update", &gogit.CommitOptions{Author: testSignature()})
+	require.NoError(r.t, err)
+	return hash
+}
+
+func (r *testRepo) push() {
+	r.t.Helper()
+	err := r.work.Push(&gogit.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"},
+	})
+	require.NoError(r.t, err)
+}
+
+func testSignature() *object.Signature {
+	return &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}
+}
+
+func newTestClient(t *testing.T, spec *esv1beta1.GitProvider) esv1beta1.SecretsClient {
+	t.Helper()
+	identity, err := os.ReadFile("testdata/identity.txt")
+	require.NoError(t, err)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-keys", Namespace: "default"},
+		Data:       map[string][]byte{"age": identity},
+	}).Build()
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "git", Namespace: "default"},
+		Spec:       esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Git: spec}},
+	}
+	c, err := (&Provider{}).NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	return c
+}
+
+func readTestdata(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestClientGetSecret(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.commit(map[string]string{
+		"README.md":              "# config\n",
+		"config/app.yaml":        "database:\n  host: db.internal\n  port: 5432\nfeatures: [a, b]\n",
+		"config/app.json":        `{"log":{"level":"debug"}}`,
+		"secrets/db.enc.yaml":    readTestdata(t, "db.enc.yaml"),
+		"secrets/token.age":      readTestdata(t, "token.age"),
+		".github/workflows.yaml": "on: push\n",
+	})
+	repo.push()
+	c := newTestClient(t, &esv1beta1.GitProvider{
+		URL:  repo.url,
+		Keys: &esv1beta1.SopsKeys{Age: []esmeta.SecretKeySelector{{Name: "git-keys", Key: "age"}}},
+	})
+	ctx := context.Background()
+
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	data, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "README.md"})
+	require.NoError(t, err)
+	assert.Equal(t, "# config\n", string(data))
+
+	for property, expected := range map[string]string{"database.host": "db.internal", "database.port": "5432", "features": `["a","b"]`} {
+		data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app.yaml", Property: property})
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app.json", Property: "log.level"})
+	require.NoError(t, err)
+	assert.Equal(t, "debug", string(data))
+
+	// encrypted files are decrypted
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "secrets/db.enc.yaml", Property: "database.password"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(data))
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "secrets/token.age"})
+	require.NoError(t, err)
+	assert.Equal(t, "t0k3n", string(data))
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "config/missing.yaml"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app.yaml", Property: "database.user"})
+	assert.EqualError(t, err, "property database.user not found in file config/app.yaml")
+
+	secretMap, err := c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "secrets/db.enc.yaml", Property: "database"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"username": []byte("admin"), "password": []byte("s3cr3t")}, secretMap)
+	_, err = c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "config/app.yaml", Property: "features"})
+	assert.EqualError(t, err, "file config/app.yaml: features is not an object")
+
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: ptr.To("config/")})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"config/app.yaml", "config/app.json"}, keys(secretMap))
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: ptr.To("*/*.yaml")})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"config/app.yaml", "secrets/db.enc.yaml"}, keys(secretMap))
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: `\.age$`}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"secrets/token.age": []byte("t0k3n")}, secretMap)
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "workflows"}})
+	require.NoError(t, err)
+	assert.Empty(t, secretMap)
+	_, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}})
+	assert.EqualError(t, err, errFindByTags)
+
+	// encrypted files need keys
+	_, err = newTestClient(t, &esv1beta1.GitProvider{URL: repo.url}).GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "secrets/token.age"})
+	assert.EqualError(t, err, "unable to decrypt file secrets/token.age: file is encrypted, but spec.provider.git.keys is not set")
+}
+
+func TestClientRef(t *testing.T) {
+	repo := newTestRepo(t)
+	first := repo.commit(map[string]string{"app.yaml": "version: 1\n"})
+	_, err := repo.work.CreateTag("v1", first, &gogit.CreateTagOptions{Tagger: testSignature(), Message: "v1"})
+	require.NoError(t, err)
+	require.NoError(t, repo.work.Storer.SetReference(plumbing.NewHashReference("refs/heads/release", first)))
+	repo.commit(map[string]string{"app.yaml": "version: 2\n"})
+	repo.push()
+	ctx := context.Background()
+
+	version := func(ref string) string {
+		t.Helper()
+		data, err := newTestClient(t, &esv1beta1.GitProvider{URL: repo.url, Ref: ref}).GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app.yaml", Property: "version"})
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "2", version(""))
+	assert.Equal(t, "2", version("master"))
+	assert.Equal(t, "1", version("release"))
+	assert.Equal(t, "1", version("v1"))
+	assert.Equal(t, "1", version("refs/tags/v1"))
+	assert.Equal(t, "1", version(first.String()))
+	assert.Equal(t, "1", version(first.String()[:7]))
+
+	_, err = newTestClient(t, &esv1beta1.GitProvider{URL: repo.url, Ref: "missing"}).Validate()
+	assert.EqualError(t, err, `unable to resolve ref "missing": reference not found`)
+
+	// the ref is looked up again once the refresh interval elapsed
+	repo.commit(map[string]string{"app.yaml": "version: 3\n"})
+	repo.push()
+	assert.Equal(t, "2", version(""))
+	clones.mu.Lock()
+	for _, r := range clones.repositories {
+		r.checked = time.Time{}
+	}
+	clones.mu.Unlock()
+	assert.Equal(t, "3", version(""))
+	assert.Equal(t, "1", version("v1"))
+
+	// new refs are cloned on demand
+	require.NoError(t, repo.work.Storer.SetReference(plumbing.NewHashReference("refs/heads/feature", first)))
+	repo.push()
+	assert.Equal(t, "1", version("feature"))
+
+	_, err = newTestClient(t, &esv1beta1.GitProvider{URL: "file:///missing"}).Validate()
+	assert.ErrorContains(t, err, "unable to list refs of repository")
+}
+
+func TestShallowClone(t *testing.T) {
+	if _, err := exec.LookPath("git-upload-pack"); err != nil {
+		t.Skip("git is not installed")
+	}
+	// the in-process server does not support shallow clones
+	client.InstallProtocol("file", file.DefaultClient)
+	defer client.InstallProtocol("file", server.DefaultServer)
+
+	repo := newTestRepo(t)
+	first := repo.commit(map[string]string{"app.yaml": "version: 1\n"})
+	repo.commit(map[string]string{"app.yaml": "version: 2\n"})
+	repo.push()
+	ctx := context.Background()
+
+	for ref, shallow := range map[string]bool{"master": true, first.String(): false} {
+		rm := &remote{url: repo.url, ref: ref, refreshInterval: time.Hour}
+		r := &repository{}
+		_, err := r.tree(ctx, rm)
+		require.NoError(t, err)
+		shallows, err := r.repo.Storer.Shallow()
+		require.NoError(t, err)
+		assert.Equal(t, shallow, len(shallows) > 0, ref)
+	}
+}
+
+func TestCacheBound(t *testing.T) {
+	c := &cache{repositories: map[string]*repository{}}
+	c.get("oldest").touch(time.Now().Add(-time.Minute))
+	for i := 1; i <= maxCachedRepositories; i++ {
+		c.get(strconv.Itoa(i))
+	}
+	assert.Len(t, c.repositories, maxCachedRepositories)
+	assert.NotContains(t, c.repositories, "oldest")
+
+	c.get("1").touch(time.Now().Add(-2 * cacheTTL))
+	c.get("2")
+	assert.NotContains(t, c.repositories, "1")
+}
+
+func TestSSHUser(t *testing.T) {
+	assert.Equal(t, "deploy", sshUser("deploy@git.example.com:org/repo.git"))
+	assert.Equal(t, "deploy", sshUser("ssh://deploy@git.example.com:2222/org/repo.git"))
+	assert.Equal(t, "git", sshUser("ssh://git.example.com/org/repo.git"))
+}
+
+func keys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
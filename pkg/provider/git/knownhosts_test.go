@@ -0,0 +1,102 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func newTestHostKey(t *testing.T) (ssh.PublicKey, ssh.Signer) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+	return signer.PublicKey(), signer
+}
+
+func TestHostKeyCallback(t *testing.T) {
+	github, _ := newTestHostKey(t)
+	internal, _ := newTestHostKey(t)
+	hashed, _ := newTestHostKey(t)
+	revoked, _ := newTestHostKey(t)
+	ca, caSigner := newTestHostKey(t)
+	other, _ := newTestHostKey(t)
+
+	hostCert := &ssh.Certificate{
+		Key:             other,
+		CertType:        ssh.HostCert,
+		ValidPrincipals: []string{"git.corp.example"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	require.NoError(t, hostCert.SignCert(rand.Reader, caSigner))
+
+	knownHosts := strings.Join([]string{
+		"# comment",
+		knownhosts.Line([]string{"github.com"}, github),
+		"",
+		"*.internal.example,!secret.internal.example " + strings.TrimSpace(string(ssh.MarshalAuthorizedKey(internal))),
+		knownhosts.Line([]string{knownhosts.HashHostname("[gitea.example]:2222")}, hashed),
+		"@revoked * " + strings.TrimSpace(string(ssh.MarshalAuthorizedKey(revoked))),
+		"@cert-authority *.corp.example " + strings.TrimSpace(string(ssh.MarshalAuthorizedKey(ca))),
+	}, "\n")
+	callback, err := hostKeyCallback(knownHosts)
+	require.NoError(t, err)
+
+	remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 22}
+	tests := []struct {
+		name     string
+		hostname string
+		key      ssh.PublicKey
+		wantErr  string
+	}{
+		{name: "plain host", hostname: "github.com:22", key: github},
+		{name: "host name case", hostname: "GitHub.com:22", key: github},
+		{name: "wrong key", hostname: "github.com:22", key: internal, wantErr: "knownhosts: key mismatch"},
+		{name: "other port", hostname: "github.com:2222", key: github, wantErr: "knownhosts: key is unknown"},
+		{name: "wildcard", hostname: "git.internal.example:22", key: internal},
+		{name: "negated pattern case", hostname: "Secret.Internal.example:22", key: internal, wantErr: "knownhosts: key is unknown"},
+		{name: "negated pattern", hostname: "secret.internal.example:22", key: internal, wantErr: "knownhosts: key is unknown"},
+		{name: "hashed host with port", hostname: "gitea.example:2222", key: hashed},
+		{name: "hashed host on other port", hostname: "gitea.example:22", key: hashed, wantErr: "knownhosts: key is unknown"},
+		{name: "revoked key", hostname: "github.com:22", key: revoked, wantErr: "knownhosts: key is revoked"},
+		{name: "certificate of the authority", hostname: "git.corp.example:22", key: hostCert},
+		{name: "certificate of other hosts", hostname: "git.other.example:22", key: hostCert, wantErr: "ssh: no authorities for hostname: git.other.example:22"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := callback(tt.hostname, remote, tt.key)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	_, err = hostKeyCallback("# no keys\n")
+	assert.EqualError(t, err, "no host keys")
+	_, err = hostKeyCallback("github.com ssh-ed25519 invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,257 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/sops"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errMissingProvider   = "missing: spec.provider.git"
+	errMissingURL        = "missing: spec.provider.git.url"
+	errInvalidURL        = "invalid spec.provider.git.url: %w"
+	errInvalidAuth       = "invalid spec.provider.git.auth: exactly one of basicAuth or ssh must be set"
+	errInvalidBasicAuth  = "invalid spec.provider.git.auth.basicAuth: %w"
+	errInvalidSSHAuth    = "invalid spec.provider.git.auth.ssh: %w"
+	errBasicAuthScheme   = "invalid spec.provider.git.auth.basicAuth: only used with an http or https url"
+	errSSHAuthScheme     = "invalid spec.provider.git.auth.ssh: only used with an ssh url"
+	errInvalidCABundle   = "invalid spec.provider.git.caBundle: only used with an https url"
+	errInvalidAgeKey     = "invalid spec.provider.git.keys.age[%d]: %w"
+	errInvalidPGPKey     = "invalid spec.provider.git.keys.pgp[%d]: %w"
+	errUnsupportedScheme = "scheme must be https, http or ssh"
+
+	// defaultRefreshInterval is the interval the clone of a repository is fetched at,
+	// unless the store sets a refresh interval.
+	defaultRefreshInterval = 5 * time.Minute
+)
+
+// https://github.com/external-secrets/external-secrets/issues/644
+var _ esv1beta1.SecretsClient = &Client{}
+var _ esv1beta1.Provider = &Provider{}
+
+// Provider reads files of Git repositories.
+type Provider struct{}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Git: &esv1beta1.GitProvider{},
+	})
+}
+
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+// Features returns the optional features the provider supports.
+func (p *Provider) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{
+		FindByName: true,
+		FindByPath: true,
+	}
+}
+
+// NewClient resolves the credentials and keys of the store,
+// the repository is cloned or fetched on first use.
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	spec, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		kube:            kube,
+		store:           spec,
+		storeKind:       store.GetKind(),
+		namespace:       namespace,
+		refreshInterval: defaultRefreshInterval,
+	}
+	if interval := store.GetSpec().RefreshInterval; interval > 0 {
+		c.refreshInterval = time.Duration(interval) * time.Second
+	}
+
+	// allow SecretStore controller validation to pass
+	// when using referent namespace.
+	if c.storeKind == esv1beta1.ClusterSecretStoreKind && c.namespace == "" && isReferentSpec(spec) {
+		return c, nil
+	}
+
+	if err := c.resolveAuth(ctx); err != nil {
+		return nil, err
+	}
+	if spec.Keys != nil {
+		keys, err := sops.ResolveKeys(ctx, kube, c.storeKind, namespace, spec.Keys)
+		if err != nil {
+			return nil, err
+		}
+		c.keys = keys
+	}
+	c.cacheKey = cacheKey(store, spec, namespace)
+	c.resolved = true
+	return c, nil
+}
+
+// cacheKey identifies the clone of a store. Stores never share a clone, and neither do
+// the namespaces of a ClusterSecretStore with referent credentials.
+func cacheKey(store esv1beta1.GenericStore, spec *esv1beta1.GitProvider, namespace string) string {
+	key := []string{store.GetKind(), store.GetNamespace(), store.GetName(), spec.URL, spec.Ref}
+	if store.GetKind() == esv1beta1.ClusterSecretStoreKind && isReferentSpec(spec) {
+		key = append(key, namespace)
+	}
+	return strings.Join(key, "\x00")
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	spec, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if spec.URL == "" {
+		return nil, errors.New(errMissingURL)
+	}
+	scheme, err := urlScheme(spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidURL, err)
+	}
+	if len(spec.CABundle) > 0 && scheme != "https" {
+		return nil, errors.New(errInvalidCABundle)
+	}
+
+	if auth := spec.Auth; auth != nil {
+		if (auth.BasicAuth == nil) == (auth.SSH == nil) {
+			return nil, errors.New(errInvalidAuth)
+		}
+		if auth.BasicAuth != nil {
+			if scheme == "ssh" {
+				return nil, errors.New(errBasicAuthScheme)
+			}
+			if err := validateSecretKeySelector(store, auth.BasicAuth.PasswordSecretRef); err != nil {
+				return nil, fmt.Errorf(errInvalidBasicAuth, err)
+			}
+		}
+		if auth.SSH != nil {
+			if scheme != "ssh" {
+				return nil, errors.New(errSSHAuthScheme)
+			}
+			refs := []esmeta.SecretKeySelector{auth.SSH.PrivateKeySecretRef, auth.SSH.KnownHostsSecretRef}
+			if auth.SSH.PassphraseSecretRef != nil {
+				refs = append(refs, *auth.SSH.PassphraseSecretRef)
+			}
+			for _, ref := range refs {
+				if err := validateSecretKeySelector(store, ref); err != nil {
+					return nil, fmt.Errorf(errInvalidSSHAuth, err)
+				}
+			}
+		}
+	}
+
+	if keys := spec.Keys; keys != nil {
+		for i := range keys.Age {
+			if err := validateSecretKeySelector(store, keys.Age[i]); err != nil {
+				return nil, fmt.Errorf(errInvalidAgeKey, i, err)
+			}
+		}
+		for i := range keys.PGP {
+			key := &keys.PGP[i]
+			if err := validateSecretKeySelector(store, key.SecretRef); err != nil {
+				return nil, fmt.Errorf(errInvalidPGPKey, i, err)
+			}
+			if key.PassphraseSecretRef != nil {
+				if err := validateSecretKeySelector(store, *key.PassphraseSecretRef); err != nil {
+					return nil, fmt.Errorf(errInvalidPGPKey, i, err)
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// urlScheme returns the scheme of the repository URL, scp-like URLs use ssh.
+func urlScheme(repoURL string) (string, error) {
+	if !strings.Contains(repoURL, "://") {
+		// user@host:path
+		host, path, ok := strings.Cut(repoURL, ":")
+		if !ok || host == "" || path == "" || strings.Contains(host, "/") {
+			return "", errors.New(errUnsupportedScheme)
+		}
+		return "ssh", nil
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "https", "http", "ssh":
+		if u.Host == "" {
+			return "", errors.New("host is required")
+		}
+		return u.Scheme, nil
+	}
+	return "", errors.New(errUnsupportedScheme)
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.GitProvider, error) {
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Git == nil {
+		return nil, errors.New(errMissingProvider)
+	}
+	return storeSpec.Provider.Git, nil
+}
+
+func validateSecretKeySelector(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if ref.Name == "" || ref.Key == "" {
+		return errors.New("secret name and key are required")
+	}
+	return utils.ValidateReferentSecretSelector(store, ref)
+}
+
+// isReferentSpec returns true if the credentials or keys of a ClusterSecretStore
+// are resolved in the namespace of the ExternalSecret.
+func isReferentSpec(spec *esv1beta1.GitProvider) bool {
+	var refs []*esmeta.SecretKeySelector
+	if auth := spec.Auth; auth != nil {
+		if auth.BasicAuth != nil {
+			refs = append(refs, &auth.BasicAuth.PasswordSecretRef)
+		}
+		if auth.SSH != nil {
+			refs = append(refs, &auth.SSH.PrivateKeySecretRef, &auth.SSH.KnownHostsSecretRef, auth.SSH.PassphraseSecretRef)
+		}
+	}
+	if keys := spec.Keys; keys != nil {
+		for i := range keys.Age {
+			refs = append(refs, &keys.Age[i])
+		}
+		for i := range keys.PGP {
+			refs = append(refs, &keys.PGP[i].SecretRef, keys.PGP[i].PassphraseSecretRef)
+		}
+	}
+	for _, ref := range refs {
+		if ref != nil && ref.Namespace == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestValidateStore(t *testing.T) {
+	sshAuth := &esv1beta1.GitAuth{SSH: &esv1beta1.GitSSHAuth{
+		PrivateKeySecretRef: esmeta.SecretKeySelector{Name: "git-auth", Key: "id_ed25519"},
+		KnownHostsSecretRef: esmeta.SecretKeySelector{Name: "git-auth", Key: "known_hosts"},
+	}}
+	basicAuth := &esv1beta1.GitAuth{BasicAuth: &esv1beta1.GitBasicAuth{
+		PasswordSecretRef: esmeta.SecretKeySelector{Name: "git-auth", Key: "token"},
+	}}
+	tests := []struct {
+		name    string
+		kind    string
+		spec    *esv1beta1.GitProvider
+		wantErr string
+	}{
+		{
+			name: "valid public repository",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.GitProvider{URL: "https://git.example.com/org/config.git", Ref: "v1.2.0", CABundle: []byte("ca")},
+		},
+		{
+			name: "valid basic auth",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.GitProvider{URL: "https://git.example.com/org/config.git", Auth: basicAuth},
+		},
+		{
+			name: "valid ssh auth",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.GitProvider{
+				URL:  "git@git.example.com:org/config.git",
+				Auth: sshAuth,
+				Keys: &esv1beta1.SopsKeys{Age: []esmeta.SecretKeySelector{{Name: "git-keys", Key: "age"}}},
+			},
+		},
+		{
+			name:    "missing provider",
+			kind:    esv1beta1.SecretStoreKind,
+			wantErr: errMissingProvider,
+		},
+		{
+			name:    "missing url",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.GitProvider{},
+			wantErr: errMissingURL,
+		},
+		{
+			name:    "unsupported scheme",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.GitProvider{URL: "file:///srv/git/config.git"},
+			wantErr: "invalid spec.provider.git.url: scheme must be https, http or ssh",
+		},
+		{
+			name:    "caBundle with ssh",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.GitProvider{URL: "ssh://git@git.example.com/org/config.git", CABundle: []byte("ca"), Auth: sshAuth},
+			wantErr: errInvalidCABundle,
+		},
+		{
+			name:    "empty auth",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.GitProvider{URL: "https://git.example.com/org/config.git", Auth: &esv1beta1.GitAuth{}},
+			wantErr: errInvalidAuth,
+		},
+		{
+			name:    "ssh auth with https",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.GitProvider{URL: "https://git.example.com/org/config.git", Auth: sshAuth},
+			wantErr: errSSHAuthScheme,
+		},
+		{
+			name:    "basic auth with ssh",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.GitProvider{URL: "git@git.example.com:org/config.git", Auth: basicAuth},
+			wantErr: errBasicAuthScheme,
+		},
+		{
+			name: "missing known hosts",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.GitProvider{URL: "git@git.example.com:org/config.git", Auth: &esv1beta1.GitAuth{SSH: &esv1beta1.GitSSHAuth{
+				PrivateKeySecretRef: esmeta.SecretKeySelector{Name: "git-auth", Key: "id_ed25519"},
+			}}},
+			wantErr: "invalid spec.provider.git.auth.ssh: secret name and key are required",
+		},
+		{
+			name: "namespace with SecretStore",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.GitProvider{URL: "https://git.example.com/org/config.git", Auth: &esv1beta1.GitAuth{BasicAuth: &esv1beta1.GitBasicAuth{
+				PasswordSecretRef: esmeta.SecretKeySelector{Name: "git-auth", Key: "token", Namespace: ptr.To("other")},
+			}}},
+			wantErr: "invalid spec.provider.git.auth.basicAuth: namespace not allowed with namespaced SecretStore",
+		},
+		{
+			name: "namespace with ClusterSecretStore",
+			kind: esv1beta1.ClusterSecretStoreKind,
+			spec: &esv1beta1.GitProvider{URL: "https://git.example.com/org/config.git", Auth: &esv1beta1.GitAuth{BasicAuth: &esv1beta1.GitBasicAuth{
+				PasswordSecretRef: esmeta.SecretKeySelector{Name: "git-auth", Key: "token", Namespace: ptr.To("other")},
+			}}},
+		},
+		{
+			name: "invalid age key",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.GitProvider{
+				URL:  "https://git.example.com/org/config.git",
+				Keys: &esv1beta1.SopsKeys{Age: []esmeta.SecretKeySelector{{Name: "git-keys"}}},
+			},
+			wantErr: "invalid spec.provider.git.keys.age[0]: secret name and key are required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{Kind: tt.kind},
+				Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Git: tt.spec}},
+			}
+			_, err := (&Provider{}).ValidateStore(store)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const (
+	errClone      = "unable to clone repository: %w"
+	errListRefs   = "unable to list refs of repository: %w"
+	errResolveRef = "unable to resolve ref %q: %w"
+
+	// cacheTTL is the time after which the clone of a store that is no longer used is dropped.
+	cacheTTL = time.Hour
+	// maxCachedRepositories bounds the number of clones, the least recently used clone is dropped first.
+	maxCachedRepositories = 32
+)
+
+var (
+	commitHash   = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	abbrevCommit = regexp.MustCompile(`^[0-9a-f]{4,40}$`)
+)
+
+// clones are the repositories of all stores, kept in memory between reconciles.
+var clones = &cache{repositories: map[string]*repository{}}
+
+type cache struct {
+	mu           sync.Mutex
+	repositories map[string]*repository
+}
+
+// get returns the repository with the key. Repositories that were not used for cacheTTL are dropped,
+// and so are the least recently used ones beyond maxCachedRepositories.
+func (c *cache) get(key string) *repository {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, r := range c.repositories {
+		if k != key && now.Sub(r.used()) > cacheTTL {
+			delete(c.repositories, k)
+		}
+	}
+	r, ok := c.repositories[key]
+	if !ok {
+		for len(c.repositories) >= maxCachedRepositories {
+			c.evictOldest()
+		}
+		r = &repository{}
+		c.repositories[key] = r
+	}
+	r.touch(now)
+	return r
+}
+
+func (c *cache) evictOldest() {
+	oldestKey := ""
+	var oldest time.Time
+	for k, r := range c.repositories {
+		if used := r.used(); oldestKey == "" || used.Before(oldest) {
+			oldestKey, oldest = k, used
+		}
+	}
+	delete(c.repositories, oldestKey)
+}
+
+// repository is an in-memory clone of a remote repository at a single ref.
+// Branches and tags are cloned with a depth of one, commits need a full clone.
+type repository struct {
+	mu       sync.Mutex
+	repo     *gogit.Repository
+	commit   *object.Commit
+	lastUsed time.Time
+	// target is the hash the ref pointed to on the remote when cloned, zero for commits.
+	target plumbing.Hash
+	// checked is the time the ref was last compared with the remote.
+	checked time.Time
+}
+
+func (r *repository) used() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastUsed
+}
+
+func (r *repository) touch(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastUsed = now
+}
+
+// remote describes how a repository is cloned.
+type remote struct {
+	url             string
+	ref             string
+	auth            transport.AuthMethod
+	caBundle        []byte
+	refreshInterval time.Duration
+}
+
+// tree returns the tree of the commit the ref points to. The repository is cloned on first use.
+// Once the refresh interval elapsed the ref is looked up on the remote and the repository
+// is cloned again if the ref moved. Commits are never refreshed.
+func (r *repository) tree(ctx context.Context, rm *remote) (*object.Tree, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.repo != nil && (r.target.IsZero() || time.Since(r.checked) < rm.refreshInterval) {
+		return r.commit.Tree()
+	}
+	ref, err := lookupRef(ctx, rm)
+	if err != nil {
+		return nil, err
+	}
+	if r.repo != nil && ref.target == r.target {
+		r.checked = time.Now()
+		return r.commit.Tree()
+	}
+	if ref.name == "" {
+		err = r.cloneCommit(ctx, rm)
+	} else {
+		err = r.cloneRef(ctx, rm, ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.commit.Tree()
+}
+
+// remoteRef is a branch or a tag of the remote.
+type remoteRef struct {
+	name   plumbing.ReferenceName
+	target plumbing.Hash
+	// shallow is true if the remote supports cloning without history.
+	shallow bool
+}
+
+// lookupRef returns the branch or the tag the ref refers to on the remote, in this order.
+// The empty ref refers to the HEAD of the remote. A ref without name is returned for refs
+// that can only be commits.
+func lookupRef(ctx context.Context, rm *remote) (remoteRef, error) {
+	ep, err := transport.NewEndpoint(rm.url)
+	if err != nil {
+		return remoteRef{}, fmt.Errorf(errListRefs, err)
+	}
+	ep.CaBundle = rm.caBundle
+	cl, err := client.NewClient(ep)
+	if err != nil {
+		return remoteRef{}, fmt.Errorf(errListRefs, err)
+	}
+	session, err := cl.NewUploadPackSession(ep, rm.auth)
+	if err != nil {
+		return remoteRef{}, fmt.Errorf(errListRefs, err)
+	}
+	defer session.Close()
+	adv, err := session.AdvertisedReferencesContext(ctx)
+	if err != nil {
+		return remoteRef{}, fmt.Errorf(errListRefs, err)
+	}
+	refs, err := adv.AllReferences()
+	if err != nil {
+		return remoteRef{}, fmt.Errorf(errListRefs, err)
+	}
+
+	var names []plumbing.ReferenceName
+	switch {
+	case rm.ref == "":
+		names = []plumbing.ReferenceName{plumbing.HEAD}
+	case strings.HasPrefix(rm.ref, "refs/"):
+		names = []plumbing.ReferenceName{plumbing.ReferenceName(rm.ref)}
+	default:
+		names = []plumbing.ReferenceName{plumbing.NewBranchReferenceName(rm.ref), plumbing.NewTagReferenceName(rm.ref)}
+	}
+	for _, name := range names {
+		resolved, err := storer.ResolveReference(refs, name)
+		if err != nil {
+			continue
+		}
+		return remoteRef{
+			name:    resolved.Name(),
+			target:  resolved.Hash(),
+			shallow: adv.Capabilities.Supports(capability.Shallow),
+		}, nil
+	}
+	if rm.ref != "" && abbrevCommit.MatchString(rm.ref) {
+		return remoteRef{}, nil
+	}
+	return remoteRef{}, fmt.Errorf(errResolveRef, rm.ref, plumbing.ErrReferenceNotFound)
+}
+
+// cloneRef clones the commit of a branch or a tag, without its history if the remote supports it.
+func (r *repository) cloneRef(ctx context.Context, rm *remote, ref remoteRef) error {
+	depth := 0
+	if ref.shallow {
+		depth = 1
+	}
+	repo, err := gogit.CloneContext(ctx, memory.NewStorage(), nil, &gogit.CloneOptions{
+		URL:           rm.url,
+		Auth:          rm.auth,
+		CABundle:      rm.caBundle,
+		ReferenceName: ref.name,
+		SingleBranch:  true,
+		Depth:         depth,
+		Tags:          gogit.NoTags,
+	})
+	if err != nil {
+		return fmt.Errorf(errClone, err)
+	}
+	// the ref may have moved since it was looked up
+	cloned, err := repo.Reference(ref.name, true)
+	if err != nil {
+		return fmt.Errorf(errResolveRef, rm.ref, err)
+	}
+	commit, err := peel(repo, cloned.Hash())
+	if err != nil {
+		return fmt.Errorf(errResolveRef, rm.ref, err)
+	}
+	r.set(repo, commit, cloned.Hash())
+	return nil
+}
+
+// cloneCommit clones the whole repository to find a commit by its, possibly abbreviated, hash.
+func (r *repository) cloneCommit(ctx context.Context, rm *remote) error {
+	repo, err := gogit.CloneContext(ctx, memory.NewStorage(), nil, &gogit.CloneOptions{
+		URL:      rm.url,
+		Auth:     rm.auth,
+		CABundle: rm.caBundle,
+		Tags:     gogit.AllTags,
+	})
+	if err != nil {
+		return fmt.Errorf(errClone, err)
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(rm.ref))
+	if err != nil {
+		return fmt.Errorf(errResolveRef, rm.ref, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return fmt.Errorf(errResolveRef, rm.ref, err)
+	}
+	r.set(repo, commit, plumbing.ZeroHash)
+	return nil
+}
+
+func (r *repository) set(repo *gogit.Repository, commit *object.Commit, target plumbing.Hash) {
+	r.repo = repo
+	r.commit = commit
+	r.target = target
+	r.checked = time.Now()
+}
+
+// peel returns the commit of a commit or an annotated tag.
+func peel(repo *gogit.Repository, hash plumbing.Hash) (*object.Commit, error) {
+	obj, err := repo.Object(plumbing.AnyObject, hash)
+	if err != nil {
+		return nil, err
+	}
+	switch o := obj.(type) {
+	case *object.Commit:
+		return o, nil
+	case *object.Tag:
+		return o.Commit()
+	default:
+		return nil, fmt.Errorf("%s is not a commit", hash)
+	}
+}
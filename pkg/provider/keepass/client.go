@@ -0,0 +1,449 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepass
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tobischo/gokeepasslib/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errPropertyNotFound = "property %s not found in entry %s"
+	errGetSecret        = "unable to get Secret %s: %w"
+	errSecretKeyMissing = "key %s not found in Secret %s"
+	errUpdateSecret     = "unable to update Secret %s: %w"
+	errDownload         = "unable to download database: %w"
+	errOpenDatabase     = "unable to open database: %w"
+	errSaveDatabase     = "unable to save database: %w"
+	errReadOnly         = "database is read-only, pushing secrets requires spec.provider.keepass.database.secretRef"
+	errInvalidCA        = "failed to parse caBundle"
+	errNoKey            = "credentials are not resolved"
+	errTooLarge         = "database exceeds %d bytes"
+	errNotManaged       = "entry %s is not managed by external-secrets"
+	errReservedField    = "field %s is reserved for external-secrets"
+
+	// fieldManagedBy marks the entries written by the operator, fieldOwnedBy holds their owner.
+	fieldManagedBy = "managed-by"
+	fieldOwnedBy   = utils.RemoteOwnerKey
+	managedByValue = "external-secrets"
+
+	downloadTimeout = 30 * time.Second
+	// maxDatabaseSize limits the size of a downloaded database.
+	maxDatabaseSize = 64 << 20
+)
+
+// Client reads and writes the entries of a KeePass database.
+type Client struct {
+	kube      kclient.Client
+	store     *esv1beta1.KeePassProvider
+	storeKind string
+	// namespace of the ExternalSecret referencing the store.
+	namespace string
+	// credentials are the password and key file.
+	credentials *gokeepasslib.DBCredentials
+
+	// db is the decrypted database, read on first use.
+	db *database
+	// secret is the Secret the database was read from.
+	secret *corev1.Secret
+}
+
+// resolveKey reads the credentials of the store.
+func (c *Client) resolveKey(ctx context.Context) error {
+	var password *string
+	var keyFile []byte
+	if ref := c.store.Auth.PasswordSecretRef; ref != nil {
+		value, err := resolvers.SecretKeyRef(ctx, c.kube, c.storeKind, c.namespace, ref)
+		if err != nil {
+			return err
+		}
+		password = &value
+	}
+	if ref := c.store.Auth.KeyFileSecretRef; ref != nil {
+		value, err := resolvers.SecretKeyRef(ctx, c.kube, c.storeKind, c.namespace, ref)
+		if err != nil {
+			return err
+		}
+		keyFile = []byte(value)
+	}
+	creds, err := credentials(password, keyFile)
+	if err != nil {
+		return err
+	}
+	c.credentials = creds
+	return nil
+}
+
+// GetSecret returns the field of the entry named by the property, or an attachment if there
+// is no such field. Without property all fields of the entry are returned as JSON.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	db, err := c.database(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := db.entry(ref.Key)
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	fields := e.fields()
+	if ref.Property == "" {
+		return utils.JSONMarshal(fields)
+	}
+	if value, ok := fields[ref.Property]; ok {
+		return []byte(value), nil
+	}
+	data, ok, err := db.attachment(&e, ref.Property)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	return data, nil
+}
+
+// GetSecretMap returns the fields and attachments of the entry.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	db, err := c.database(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := db.entry(ref.Key)
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	secretMap := make(map[string][]byte)
+	for _, b := range e.item.Binaries {
+		key := b.Name
+		data, ok, err := db.attachment(&e, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			secretMap[key] = data
+		}
+	}
+	for key, value := range e.fields() {
+		secretMap[key] = []byte(value)
+	}
+	return secretMap, nil
+}
+
+// GetAllSecrets returns the fields as JSON of the entries whose path matches find.name
+// and starts with find.path, and which have all tags of find.tags.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+	db, err := c.database(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secretMap := make(map[string][]byte)
+	for _, e := range db.entries() {
+		if ref.Path != nil && !strings.HasPrefix(e.path, *ref.Path) {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(e.path) {
+			continue
+		}
+		if !matchTags(e.tags(), ref.Tags) {
+			continue
+		}
+		// the first entry with a path wins, as in GetSecret
+		if _, ok := secretMap[e.path]; ok {
+			continue
+		}
+		data, err := utils.JSONMarshal(e.fields())
+		if err != nil {
+			return nil, err
+		}
+		secretMap[e.path] = data
+	}
+	return secretMap, nil
+}
+
+// matchTags returns true if the entry has all tags. KeePass tags are labels, a tag
+// matches the label key=value, or the label key if the value is empty.
+func matchTags(entryTags []string, tags map[string]string) bool {
+	for key, value := range tags {
+		want := key
+		if value != "" {
+			want = key + "=" + value
+		}
+		found := false
+		for _, tag := range entryTags {
+			if tag == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// PushSecret writes the secret to the field of the entry named by the property,
+// the password by default. Without secret key and property every key of the Secret
+// is written to the field of the same name. Missing entries and groups are created.
+// Existing entries are only written if they are managed by external-secrets,
+// or if adoptUnmanaged is set in the metadata of the PushSecret.
+func (c *Client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	db, err := c.writableDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	owner, _ := utils.PushSecretOwner(data)
+	existing, exists := db.entry(data.GetRemoteKey())
+	if exists {
+		if !existing.managed() {
+			adopt, err := utils.AdoptUnmanaged(data)
+			if err != nil {
+				return err
+			}
+			if !adopt {
+				return fmt.Errorf(errNotManaged, data.GetRemoteKey())
+			}
+		}
+		if err := utils.CheckRemoteOwner(data, data.GetRemoteKey(), existing.ownedBy()); err != nil {
+			return err
+		}
+	}
+	e, err := db.ensureEntry(data.GetRemoteKey())
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	changed := !exists
+	if db.setValue(&e, fieldManagedBy, managedByValue, now) {
+		changed = true
+	}
+	if owner != "" && db.setValue(&e, fieldOwnedBy, owner, now) {
+		changed = true
+	}
+	if data.GetSecretKey() == "" && data.GetProperty() == "" {
+		for key, value := range secret.Data {
+			if isMarkerField(key) {
+				continue
+			}
+			if db.setValue(&e, key, string(value), now) {
+				changed = true
+			}
+		}
+	} else {
+		value, err := utils.PushSecretValue(secret, data)
+		if err != nil {
+			return err
+		}
+		property := data.GetProperty()
+		if property == "" {
+			property = fieldPassword
+		}
+		if isMarkerField(property) {
+			return fmt.Errorf(errReservedField, property)
+		}
+		if db.setValue(&e, property, string(value), now) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return c.save(ctx)
+}
+
+// DeleteSecret removes the field named by the property, or the whole entry without property.
+// Entries which are not managed by external-secrets, or owned by another PushSecret, are kept.
+func (c *Client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	db, err := c.writableDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	e, ok := db.entry(remoteRef.GetRemoteKey())
+	if !ok || !e.managed() || !utils.IsRemoteOwner(remoteRef, e.ownedBy()) {
+		return nil
+	}
+	now := time.Now()
+	if remoteRef.GetProperty() == "" {
+		db.deleteEntry(&e, now)
+	} else if !db.removeValue(&e, remoteRef.GetProperty(), now) {
+		return nil
+	}
+	return c.save(ctx)
+}
+
+// SecretExists returns true if the entry, and the field named by the property, exist.
+func (c *Client) SecretExists(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) (bool, error) {
+	db, err := c.database(ctx)
+	if err != nil {
+		return false, err
+	}
+	e, ok := db.entry(remoteRef.GetRemoteKey())
+	if !ok {
+		return false, nil
+	}
+	if remoteRef.GetProperty() == "" {
+		return true, nil
+	}
+	_, ok = e.fields()[remoteRef.GetProperty()]
+	return ok, nil
+}
+
+// Validate opens the database, which verifies the credentials.
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	if c.credentials == nil {
+		return esv1beta1.ValidationResultUnknown, nil
+	}
+	if _, err := c.database(context.Background()); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *Client) Close(_ context.Context) error {
+	return nil
+}
+
+// database returns the decrypted database.
+func (c *Client) database(ctx context.Context) (*database, error) {
+	if c.db != nil {
+		return c.db, nil
+	}
+	if c.credentials == nil {
+		return nil, fmt.Errorf(errOpenDatabase, errors.New(errNoKey))
+	}
+	var raw []byte
+	if ref := c.store.Database.SecretRef; ref != nil {
+		secret, err := c.getSecret(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		raw = secret.Data[ref.Key]
+		c.secret = secret
+	} else {
+		data, err := c.download(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(errDownload, err)
+		}
+		raw = data
+	}
+	db, err := openDatabase(raw, c.credentials)
+	if err != nil {
+		return nil, fmt.Errorf(errOpenDatabase, err)
+	}
+	c.db = db
+	return db, nil
+}
+
+// writableDatabase returns the decrypted database, if it is stored in a Secret.
+func (c *Client) writableDatabase(ctx context.Context) (*database, error) {
+	if c.store.Database.SecretRef == nil {
+		return nil, errors.New(errReadOnly)
+	}
+	return c.database(ctx)
+}
+
+// save writes the database back to the Secret it was read from. The update fails
+// if the Secret was changed in the meantime, the push is then retried on the next reconcile.
+func (c *Client) save(ctx context.Context) error {
+	data, err := c.db.save()
+	if err != nil {
+		c.db = nil
+		return fmt.Errorf(errSaveDatabase, err)
+	}
+	c.secret.Data[c.store.Database.SecretRef.Key] = data
+	if err := c.kube.Update(ctx, c.secret); err != nil {
+		// the database no longer matches the Secret
+		c.db = nil
+		return fmt.Errorf(errUpdateSecret, c.secret.Name, err)
+	}
+	return nil
+}
+
+func (c *Client) getSecret(ctx context.Context, ref *esmeta.SecretKeySelector) (*corev1.Secret, error) {
+	key := types.NamespacedName{
+		Namespace: c.namespace,
+		Name:      ref.Name,
+	}
+	if c.storeKind == esv1beta1.ClusterSecretStoreKind && ref.Namespace != nil {
+		key.Namespace = *ref.Namespace
+	}
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf(errGetSecret, key, err)
+	}
+	if _, ok := secret.Data[ref.Key]; !ok {
+		return nil, fmt.Errorf(errSecretKeyMissing, ref.Key, key)
+	}
+	return secret, nil
+}
+
+// download fetches the database from its URL.
+func (c *Client) download(ctx context.Context) ([]byte, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(c.store.Database.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(c.store.Database.CABundle) {
+			return nil, errors.New(errInvalidCA)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: downloadTimeout}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.store.Database.URL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDatabaseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDatabaseSize {
+		return nil, fmt.Errorf(errTooLarge, maxDatabaseSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,254 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepass
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+var testAuth = esv1beta1.KeePassAuth{
+	PasswordSecretRef: &esmeta.SecretKeySelector{Name: "keepass-auth", Key: "password"},
+	KeyFileSecretRef:  &esmeta.SecretKeySelector{Name: "keepass-auth", Key: "keyfile"},
+}
+
+func newTestClient(t *testing.T, kube kclient.Client, spec *esv1beta1.KeePassProvider) esv1beta1.SecretsClient {
+	t.Helper()
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "keepass", Namespace: "default"},
+		Spec:       esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{KeePass: spec}},
+	}
+	c, err := (&Provider{}).NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	return c
+}
+
+func newTestKube(t *testing.T, database []byte) kclient.Client {
+	t.Helper()
+	return clientfake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "keepass", Namespace: "default"},
+			Data:       map[string][]byte{"db.kdbx": database},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "keepass-auth", Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte(testPassword), "keyfile": readTestdata(t, "keyfile.keyx")},
+		},
+	).Build()
+}
+
+func TestClientGetSecret(t *testing.T) {
+	database := readTestdata(t, "kdbx4.kdbx")
+	kube := newTestKube(t, database)
+	spec := &esv1beta1.KeePassProvider{
+		Database: esv1beta1.KeePassDatabase{SecretRef: &esmeta.SecretKeySelector{Name: "keepass", Key: "db.kdbx"}},
+		Auth:     testAuth,
+	}
+	c := newTestClient(t, kube, spec)
+	ctx := context.Background()
+
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	data, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "Databases/postgres"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Title":"postgres","UserName":"admin","Password":"pg-s3cr3t","Port":"5432"}`, string(data))
+
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "Databases/postgres", Property: "Port"})
+	require.NoError(t, err)
+	assert.Equal(t, "5432", string(data))
+
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "stripe", Property: "cert.pem"})
+	require.NoError(t, err)
+	assert.Equal(t, "CERT", string(data))
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "stripe", Property: "URL"})
+	assert.EqualError(t, err, "property URL not found in entry stripe")
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "Recycle Bin/deleted"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+
+	secretMap, err := c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "stripe"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"Title":    []byte("stripe"),
+		"UserName": []byte("billing"),
+		"Password": []byte("sk_live_123"),
+		"cert.pem": []byte("CERT"),
+	}, secretMap)
+
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^Databases/"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Databases/postgres"}, keys(secretMap))
+
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Tags: map[string]string{"prod": "", "team": "payments"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stripe"}, keys(secretMap))
+	assert.JSONEq(t, `{"Title":"stripe","UserName":"billing","Password":"sk_live_123"}`, string(secretMap["stripe"]))
+
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Tags: map[string]string{"prod": ""}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"stripe", "Databases/postgres"}, keys(secretMap))
+
+	// wrong credentials
+	secret := &corev1.Secret{}
+	require.NoError(t, kube.Get(ctx, types.NamespacedName{Name: "keepass-auth", Namespace: "default"}, secret))
+	secret.Data["password"] = []byte("wrong")
+	require.NoError(t, kube.Update(ctx, secret))
+	_, err = newTestClient(t, kube, spec).Validate()
+	assert.EqualError(t, err, "unable to open database: invalid credentials or corrupted database")
+}
+
+func TestClientPushSecret(t *testing.T) {
+	database := readTestdata(t, "kdbx3.kdbx")
+	kube := newTestKube(t, database)
+	spec := &esv1beta1.KeePassProvider{
+		Database: esv1beta1.KeePassDatabase{SecretRef: &esmeta.SecretKeySelector{Name: "keepass", Key: "db.kdbx"}},
+		Auth:     testAuth,
+	}
+	ctx := context.Background()
+	source := &corev1.Secret{Data: map[string][]byte{"UserName": []byte("default"), "Password": []byte("r3d1s")}}
+	redis := pushSecretData("", "Services/redis", "")
+
+	// the whole secret is written to the fields of a new entry in a new group
+	require.NoError(t, newTestClient(t, kube, spec).PushSecret(ctx, source, redis))
+	version := databaseVersion(t, kube)
+	c := newTestClient(t, kube, spec)
+	data, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "Services/redis"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Title":"redis","UserName":"default","Password":"r3d1s"}`, string(data))
+	exists, err := c.SecretExists(ctx, redis)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// unchanged secrets do not rewrite the database
+	require.NoError(t, newTestClient(t, kube, spec).PushSecret(ctx, source, redis))
+	assert.Equal(t, version, databaseVersion(t, kube))
+
+	// unmanaged entries are only written with adoptUnmanaged
+	err = newTestClient(t, kube, spec).PushSecret(ctx, source, pushSecretData("Password", "stripe", ""))
+	assert.EqualError(t, err, "entry stripe is not managed by external-secrets")
+	adoptUnmanaged := &apiextensionsv1.JSON{Raw: []byte(`{"adoptUnmanaged": true}`)}
+	adopt := testingfake.PushSecretData{SecretKey: "Password", RemoteKey: "stripe", Owner: "default/stripe", Adopt: true, Metadata: adoptUnmanaged}
+	require.NoError(t, newTestClient(t, kube, spec).PushSecret(ctx, source, adopt))
+	adopt = testingfake.PushSecretData{SecretKey: "UserName", RemoteKey: "Databases/postgres", Property: "Replica", Owner: "default/postgres", Adopt: true, Metadata: adoptUnmanaged}
+	require.NoError(t, newTestClient(t, kube, spec).PushSecret(ctx, source, adopt))
+
+	// a secret key is written to the password, or to the property
+	c = newTestClient(t, kube, spec)
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "stripe", Property: "Password"})
+	require.NoError(t, err)
+	assert.Equal(t, "r3d1s", string(data))
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "Databases/postgres"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Title":"postgres","UserName":"admin","Password":"pg-s3cr3t","Port":"5432","Replica":"default"}`, string(data))
+
+	// entries owned by another PushSecret are neither written nor deleted
+	other := testingfake.PushSecretData{SecretKey: "Password", RemoteKey: "stripe", Owner: "default/other"}
+	err = newTestClient(t, kube, spec).PushSecret(ctx, source, other)
+	assert.EqualError(t, err, "remote secret stripe is owned by default/stripe")
+	require.NoError(t, newTestClient(t, kube, spec).DeleteSecret(ctx, other))
+	exists, err = newTestClient(t, kube, spec).SecretExists(ctx, other)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// delete a field, then the entry
+	require.NoError(t, newTestClient(t, kube, spec).DeleteSecret(ctx, pushSecretData("", "Databases/postgres", "Replica")))
+	c = newTestClient(t, kube, spec)
+	exists, err = c.SecretExists(ctx, pushSecretData("", "Databases/postgres", "Replica"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = c.SecretExists(ctx, pushSecretData("", "Databases/postgres", ""))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, newTestClient(t, kube, spec).DeleteSecret(ctx, redis))
+	c = newTestClient(t, kube, spec)
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "Services/redis"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+	// deleting a missing entry succeeds
+	require.NoError(t, c.DeleteSecret(ctx, redis))
+}
+
+func TestClientURL(t *testing.T) {
+	database := readTestdata(t, "kdbx4-chacha20.kdbx")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/db.kdbx" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(database)
+	}))
+	defer server.Close()
+	kube := newTestKube(t, nil)
+	ctx := context.Background()
+
+	c := newTestClient(t, kube, &esv1beta1.KeePassProvider{
+		Database: esv1beta1.KeePassDatabase{URL: server.URL + "/db.kdbx"},
+		Auth:     testAuth,
+	})
+	data, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "stripe", Property: "Password"})
+	require.NoError(t, err)
+	assert.Equal(t, "sk_live_123", string(data))
+	err = c.PushSecret(ctx, &corev1.Secret{}, pushSecretData("", "stripe", ""))
+	assert.EqualError(t, err, errReadOnly)
+
+	_, err = newTestClient(t, kube, &esv1beta1.KeePassProvider{
+		Database: esv1beta1.KeePassDatabase{URL: server.URL + "/missing.kdbx"},
+		Auth:     testAuth,
+	}).Validate()
+	assert.EqualError(t, err, "unable to download database: unexpected status 404 Not Found")
+}
+
+func pushSecretData(secretKey, remoteKey, property string) esv1alpha1.PushSecretData {
+	return esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{
+			SecretKey: secretKey,
+			RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: remoteKey, Property: property},
+		},
+	}
+}
+
+func databaseVersion(t *testing.T, kube kclient.Client) string {
+	t.Helper()
+	secret := &corev1.Secret{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: "keepass", Namespace: "default"}, secret))
+	return secret.ResourceVersion
+}
+
+func keys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
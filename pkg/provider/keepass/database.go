@@ -0,0 +1,429 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepass
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tobischo/gokeepasslib/v3"
+	"github.com/tobischo/gokeepasslib/v3/wrappers"
+)
+
+const (
+	fieldTitle    = "Title"
+	fieldPassword = "Password"
+
+	pathSeparator = "/"
+
+	kdbxSignature1 = 0x9AA2D903
+	kdbxSignature2 = 0xB54BFB67
+
+	// header field ids and the type of byte array values of the KDBX 4 header.
+	headerEnd           = 0
+	headerKdfParameters = 11
+	variantByteArray    = 0x42
+)
+
+var (
+	// kdfArgon2id is the default key derivation function of KeePassXC, gokeepasslib does not implement it.
+	kdfArgon2id = []byte{0x9e, 0x29, 0x8b, 0x19, 0x56, 0xdb, 0x47, 0x73, 0xb2, 0x3d, 0xfc, 0x3e, 0xc6, 0xf0, 0xa1, 0xe6}
+
+	errInvalidCredentials = errors.New("invalid credentials or corrupted database")
+	errUnsupportedKDF     = errors.New("the Argon2id key derivation function is not supported, use Argon2d or AES-KDF")
+)
+
+// credentials combines the password and the key file into the key of the database.
+// A nil password or key file is not part of the key.
+func credentials(password *string, keyFile []byte) (*gokeepasslib.DBCredentials, error) {
+	var creds *gokeepasslib.DBCredentials
+	var err error
+	switch {
+	case password != nil && keyFile != nil:
+		creds, err = gokeepasslib.NewPasswordAndKeyDataCredentials(*password, keyFile)
+	case password != nil:
+		creds = gokeepasslib.NewPasswordCredentials(*password)
+	case keyFile != nil:
+		creds, err = gokeepasslib.NewKeyDataCredentials(keyFile)
+	default:
+		return nil, errors.New("a password or key file is required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid key file: %w", err)
+	}
+	return creds, nil
+}
+
+// database is a decrypted KDBX 3.1 or 4 database. The protected values are kept unlocked.
+type database struct {
+	*gokeepasslib.Database
+}
+
+// openDatabase decrypts the database with the credentials.
+func openDatabase(data []byte, creds *gokeepasslib.DBCredentials) (db *database, err error) {
+	// the decoder does not verify the signature
+	if len(data) < 12 || binary.LittleEndian.Uint32(data) != kdbxSignature1 || binary.LittleEndian.Uint32(data[4:]) != kdbxSignature2 {
+		return nil, errors.New("not a KDBX database")
+	}
+	if major := binary.LittleEndian.Uint16(data[10:]); major != 3 && major != 4 {
+		return nil, fmt.Errorf("unsupported KDBX version %d.%d", major, binary.LittleEndian.Uint16(data[8:]))
+	}
+	// reject Argon2id from the header instead of reporting invalid credentials after decoding
+	if bytes.Equal(kdfUUID(data), kdfArgon2id) {
+		return nil, errUnsupportedKDF
+	}
+	// the decoder panics on some corrupted databases
+	defer func() {
+		if r := recover(); r != nil {
+			db, err = nil, errInvalidCredentials
+		}
+	}()
+
+	kdb := gokeepasslib.NewDatabase()
+	kdb.Credentials = creds
+	if err := gokeepasslib.NewDecoder(bytes.NewReader(data)).Decode(kdb); err != nil {
+		return nil, errInvalidCredentials
+	}
+	if kdb.Content.Root == nil || len(kdb.Content.Root.Groups) == 0 {
+		return nil, errors.New("database has no root group")
+	}
+	if err := kdb.UnlockProtectedEntries(); err != nil {
+		return nil, err
+	}
+	return &database{Database: kdb}, nil
+}
+
+// kdfUUID returns the UUID of the key derivation function of a KDBX 4 database,
+// or nil if the header does not contain one.
+func kdfUUID(data []byte) []byte {
+	if len(data) < 12 || binary.LittleEndian.Uint16(data[10:]) != 4 {
+		return nil
+	}
+	// the header fields are an id, a 4 byte length and the value
+	for rest := data[12:]; len(rest) >= 5; {
+		id, size := rest[0], binary.LittleEndian.Uint32(rest[1:])
+		rest = rest[5:]
+		if id == headerEnd || uint64(size) > uint64(len(rest)) {
+			return nil
+		}
+		if id == headerKdfParameters {
+			return variantValue(rest[:size], "$UUID", variantByteArray)
+		}
+		rest = rest[size:]
+	}
+	return nil
+}
+
+// variantValue returns the value of a key of a KDBX 4 variant dictionary: a 2 byte version
+// followed by a type, the length and name of the key and the length and value of each item.
+func variantValue(dict []byte, key string, kind byte) []byte {
+	if len(dict) < 2 {
+		return nil
+	}
+	for rest := dict[2:]; len(rest) >= 5; {
+		typ, keySize := rest[0], binary.LittleEndian.Uint32(rest[1:])
+		rest = rest[5:]
+		if typ == 0 || uint64(keySize)+4 > uint64(len(rest)) {
+			return nil
+		}
+		name := string(rest[:keySize])
+		valueSize := binary.LittleEndian.Uint32(rest[keySize:])
+		rest = rest[keySize+4:]
+		if uint64(valueSize) > uint64(len(rest)) {
+			return nil
+		}
+		if typ == kind && name == key {
+			return rest[:valueSize]
+		}
+		rest = rest[valueSize:]
+	}
+	return nil
+}
+
+// save encrypts the database with the format, cipher and key derivation function
+// it was read with. The seeds, IVs and the key of the inner stream are rotated.
+func (d *database) save() ([]byte, error) {
+	h := d.Header.FileHeaders
+	keys := [][]byte{h.MasterSeed, h.EncryptionIV}
+	if d.Header.IsKdbx4() {
+		keys = append(keys, h.KdfParameters.Salt[:], d.Content.InnerHeader.InnerRandomStreamKey)
+	} else {
+		keys = append(keys, h.TransformSeed, h.ProtectedStreamKey, h.StreamStartBytes)
+	}
+	// the protected values are unlocked, they are locked with the new key of the inner stream
+	for _, key := range keys {
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	if err := d.LockProtectedEntries(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := gokeepasslib.NewEncoder(&buf).Encode(d.Database); err != nil {
+		return nil, err
+	}
+	if err := d.UnlockProtectedEntries(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// entry is an entry of the database, addressed by the titles of its groups and its own title.
+// The root group is not part of the path.
+type entry struct {
+	path  string
+	group *gokeepasslib.Group
+	item  *gokeepasslib.Entry
+}
+
+// entries returns the entries of the database, the recycle bin and the history are skipped.
+func (d *database) entries() []entry {
+	meta := d.Content.Meta
+	var entries []entry
+	var walk func(group *gokeepasslib.Group, prefix string)
+	walk = func(group *gokeepasslib.Group, prefix string) {
+		for i := range group.Entries {
+			item := &group.Entries[i]
+			entries = append(entries, entry{path: prefix + item.GetTitle(), group: group, item: item})
+		}
+		for i := range group.Groups {
+			g := &group.Groups[i]
+			if meta != nil && meta.RecycleBinEnabled.Bool && g.UUID.Compare(meta.RecycleBinUUID) {
+				continue
+			}
+			walk(g, prefix+g.Name+pathSeparator)
+		}
+	}
+	walk(d.rootGroup(), "")
+	return entries
+}
+
+// entry returns the first entry with the path.
+func (d *database) entry(path string) (entry, bool) {
+	for _, e := range d.entries() {
+		if e.path == path {
+			return e, true
+		}
+	}
+	return entry{}, false
+}
+
+func (d *database) rootGroup() *gokeepasslib.Group {
+	return &d.Content.Root.Groups[0]
+}
+
+// fields returns the string fields of the entry, without the fields marking managed entries.
+func (e *entry) fields() map[string]string {
+	fields := map[string]string{}
+	for _, v := range e.item.Values {
+		if isMarkerField(v.Key) {
+			continue
+		}
+		fields[v.Key] = v.Value.Content
+	}
+	return fields
+}
+
+// managed returns true if the entry was written by external-secrets.
+func (e *entry) managed() bool {
+	v := e.item.Get(fieldManagedBy)
+	return v != nil && v.Value.Content == managedByValue
+}
+
+// ownedBy returns the owner of a managed entry, empty if it has none.
+func (e *entry) ownedBy() string {
+	if v := e.item.Get(fieldOwnedBy); v != nil {
+		return v.Value.Content
+	}
+	return ""
+}
+
+func isMarkerField(key string) bool {
+	return key == fieldManagedBy || key == fieldOwnedBy
+}
+
+// tags returns the tags of the entry, which are separated by semicolons or commas.
+func (e *entry) tags() []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(e.item.Tags, func(r rune) bool { return r == ';' || r == ',' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// attachment returns the content of the attachment of the entry.
+func (d *database) attachment(e *entry, key string) ([]byte, bool, error) {
+	for _, ref := range e.item.Binaries {
+		if ref.Name != key {
+			continue
+		}
+		b := d.FindBinary(ref.Value.ID)
+		if b == nil {
+			return nil, false, fmt.Errorf("attachment %d not found", ref.Value.ID)
+		}
+		data, err := d.binaryContent(b)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid attachment %d: %w", ref.Value.ID, err)
+		}
+		return data, true, nil
+	}
+	return nil, false, nil
+}
+
+// binaryContent returns the content of a binary. Binary.GetContentBytes is not used,
+// it base64 decodes KDBX 4 content and pads uncompressed KDBX 3.1 content.
+func (d *database) binaryContent(b *gokeepasslib.Binary) ([]byte, error) {
+	if d.Header.IsKdbx4() {
+		return b.Content, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b.Content)))
+	if err != nil || !b.Compressed.Bool {
+		return data, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// splitPath returns the group names and the title of an entry path.
+func splitPath(path string) ([]string, string, error) {
+	parts := strings.Split(path, pathSeparator)
+	for _, p := range parts {
+		if p == "" {
+			return nil, "", fmt.Errorf("invalid entry path %q", path)
+		}
+	}
+	return parts[:len(parts)-1], parts[len(parts)-1], nil
+}
+
+// ensureEntry returns the entry with the path, the entry and its groups are created if missing.
+func (d *database) ensureEntry(path string) (entry, error) {
+	if e, ok := d.entry(path); ok {
+		return e, nil
+	}
+	groups, title, err := splitPath(path)
+	if err != nil {
+		return entry{}, err
+	}
+	group := d.rootGroup()
+	for _, name := range groups {
+		var next *gokeepasslib.Group
+		for i := range group.Groups {
+			if group.Groups[i].Name == name {
+				next = &group.Groups[i]
+				break
+			}
+		}
+		if next == nil {
+			g := gokeepasslib.NewGroup()
+			g.Name = name
+			g.IconID = 48
+			group.Groups = append(group.Groups, g)
+			next = &group.Groups[len(group.Groups)-1]
+		}
+		group = next
+	}
+
+	group.Entries = append(group.Entries, gokeepasslib.NewEntry())
+	e := entry{path: path, group: group, item: &group.Entries[len(group.Entries)-1]}
+	d.setValue(&e, fieldTitle, title, time.Now())
+	return e, nil
+}
+
+// setValue sets the string field of the entry. It returns false if the value did not change.
+func (d *database) setValue(e *entry, key, value string, now time.Time) bool {
+	if v := e.item.Get(key); v != nil {
+		if v.Value.Content == value {
+			return false
+		}
+		v.Value.Content = value
+	} else {
+		e.item.Values = append(e.item.Values, gokeepasslib.ValueData{
+			Key:   key,
+			Value: gokeepasslib.V{Content: value, Protected: wrappers.NewBoolWrapper(d.protectField(key))},
+		})
+	}
+	e.item.Times.LastModificationTime = d.timestamp(now)
+	return true
+}
+
+// removeValue removes the string field of the entry. It returns false if the field does not exist.
+func (d *database) removeValue(e *entry, key string, now time.Time) bool {
+	i := e.item.GetIndex(key)
+	if i < 0 {
+		return false
+	}
+	e.item.Values = append(e.item.Values[:i], e.item.Values[i+1:]...)
+	e.item.Times.LastModificationTime = d.timestamp(now)
+	return true
+}
+
+// protectField returns true if the field is protected in memory. Standard fields follow
+// the memory protection settings of the database, custom fields except the markers of
+// managed entries are always protected.
+func (d *database) protectField(key string) bool {
+	mp := d.Content.Meta.MemoryProtection
+	switch key {
+	case fieldManagedBy, fieldOwnedBy:
+		return false
+	case "Title":
+		return mp.ProtectTitle.Bool
+	case "UserName":
+		return mp.ProtectUserName.Bool
+	case "Password":
+		return mp.ProtectPassword.Bool
+	case "URL":
+		return mp.ProtectURL.Bool
+	case "Notes":
+		return mp.ProtectNotes.Bool
+	default:
+		return true
+	}
+}
+
+// deleteEntry removes the entry and records its deletion, so that synchronizing
+// copies of the database do not restore it.
+func (d *database) deleteEntry(e *entry, now time.Time) {
+	for i := range e.group.Entries {
+		if &e.group.Entries[i] == e.item {
+			uuid := e.item.UUID
+			e.group.Entries = append(e.group.Entries[:i], e.group.Entries[i+1:]...)
+			d.Content.Root.DeletedObjects = append(d.Content.Root.DeletedObjects, gokeepasslib.DeletedObjectData{
+				UUID:         uuid,
+				DeletionTime: d.timestamp(now),
+			})
+			return
+		}
+	}
+}
+
+// timestamp returns the time as stored in the database.
+func (d *database) timestamp(t time.Time) *wrappers.TimeWrapper {
+	return &wrappers.TimeWrapper{Formatted: !d.Header.IsKdbx4(), Time: t.UTC().Truncate(time.Second)}
+}
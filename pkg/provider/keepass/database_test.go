@@ -0,0 +1,170 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepass
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tobischo/gokeepasslib/v3"
+	"k8s.io/utils/ptr"
+)
+
+// The databases in testdata are encrypted with testPassword and testdata/keyfile.keyx.
+// They hold the entries stripe, with the attachment cert.pem, and Databases/postgres,
+// whose history holds a previous password, and an entry in the recycle bin.
+const testPassword = "correct horse battery staple"
+
+var testDatabases = map[string]string{
+	"KDBX 3.1 AES AES-KDF":    "kdbx3.kdbx",
+	"KDBX 4 AES Argon2d":      "kdbx4.kdbx",
+	"KDBX 4 ChaCha20 AES-KDF": "kdbx4-chacha20.kdbx",
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func testCredentials(t *testing.T) *gokeepasslib.DBCredentials {
+	t.Helper()
+	creds, err := credentials(ptr.To(testPassword), readTestdata(t, "keyfile.keyx"))
+	require.NoError(t, err)
+	return creds
+}
+
+func TestOpenDatabase(t *testing.T) {
+	for name, file := range testDatabases {
+		t.Run(name, func(t *testing.T) {
+			data := readTestdata(t, file)
+
+			_, err := openDatabase(data, gokeepasslib.NewPasswordCredentials(testPassword))
+			assert.ErrorIs(t, err, errInvalidCredentials)
+			_, err = openDatabase(data[:len(data)/2], testCredentials(t))
+			assert.ErrorIs(t, err, errInvalidCredentials)
+
+			db, err := openDatabase(data, testCredentials(t))
+			require.NoError(t, err)
+			assertTestDatabase(t, db)
+		})
+	}
+
+	_, err := openDatabase([]byte("not a database"), testCredentials(t))
+	assert.EqualError(t, err, "not a KDBX database")
+
+	// databases of KeePassXC derive the key with Argon2id by default
+	data := readTestdata(t, "kdbx4.kdbx")
+	assert.Equal(t, gokeepasslib.KdfArgon2, kdfUUID(data))
+	assert.Nil(t, kdfUUID(readTestdata(t, "kdbx3.kdbx")))
+	assert.Nil(t, kdfUUID(data[:40]))
+	data = bytes.Replace(data, gokeepasslib.KdfArgon2, kdfArgon2id, 1)
+	_, err = openDatabase(data, testCredentials(t))
+	assert.ErrorIs(t, err, errUnsupportedKDF)
+	_, err = openDatabase(data, gokeepasslib.NewPasswordCredentials("wrong"))
+	assert.ErrorIs(t, err, errUnsupportedKDF)
+}
+
+func TestSaveDatabase(t *testing.T) {
+	for name, file := range testDatabases {
+		t.Run(name, func(t *testing.T) {
+			data := readTestdata(t, file)
+			db, err := openDatabase(data, testCredentials(t))
+			require.NoError(t, err)
+			now := time.Now()
+			e, err := db.ensureEntry("Services/redis")
+			require.NoError(t, err)
+			assert.True(t, db.setValue(&e, fieldPassword, "r3d1s", now))
+			assert.True(t, db.setValue(&e, "Port", "6379", now))
+			assert.False(t, db.setValue(&e, "Port", "6379", now))
+			postgres, ok := db.entry("Databases/postgres")
+			require.True(t, ok)
+			assert.True(t, db.removeValue(&postgres, "Port", now))
+			stripe, ok := db.entry("stripe")
+			require.True(t, ok)
+			db.deleteEntry(&stripe, now)
+
+			saved, err := db.save()
+			require.NoError(t, err)
+			// the seeds and IVs are rotated
+			assert.NotEqual(t, data[:256], saved[:256])
+			// the database can still be used after saving
+			_, ok = db.entry("Services/redis")
+			assert.True(t, ok)
+
+			db, err = openDatabase(saved, testCredentials(t))
+			require.NoError(t, err)
+			assert.Equal(t, data[:12], saved[:12], "the format version is kept")
+			e, ok = db.entry("Services/redis")
+			require.True(t, ok)
+			assert.Equal(t, map[string]string{"Title": "redis", "Password": "r3d1s", "Port": "6379"}, e.fields())
+			assert.True(t, e.item.Get(fieldPassword).Value.Protected.Bool)
+			assert.True(t, e.item.Get("Port").Value.Protected.Bool)
+			assert.False(t, e.item.Get(fieldTitle).Value.Protected.Bool)
+
+			postgres, ok = db.entry("Databases/postgres")
+			require.True(t, ok)
+			assert.Equal(t, map[string]string{"Title": "postgres", "UserName": "admin", "Password": "pg-s3cr3t"}, postgres.fields())
+			assert.Equal(t, "pg-old", postgres.item.Histories[0].Entries[0].GetPassword())
+			_, ok = db.entry("stripe")
+			assert.False(t, ok)
+			require.Len(t, db.Content.Root.DeletedObjects, 1)
+			assert.True(t, db.Content.Root.DeletedObjects[0].UUID.Compare(stripe.item.UUID))
+		})
+	}
+}
+
+func assertTestDatabase(t *testing.T, db *database) {
+	t.Helper()
+	var paths []string
+	for _, e := range db.entries() {
+		paths = append(paths, e.path)
+	}
+	assert.Equal(t, []string{"stripe", "Databases/postgres"}, paths)
+
+	stripe, ok := db.entry("stripe")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"Title": "stripe", "UserName": "billing", "Password": "sk_live_123"}, stripe.fields())
+	assert.Equal(t, []string{"prod", "team=payments"}, stripe.tags())
+	cert, ok, err := db.attachment(&stripe, "cert.pem")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "CERT", string(cert))
+
+	postgres, ok := db.entry("Databases/postgres")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"Title": "postgres", "UserName": "admin", "Password": "pg-s3cr3t", "Port": "5432"}, postgres.fields())
+	assert.Equal(t, []string{"prod", "team=platform"}, postgres.tags())
+	assert.Equal(t, "pg-old", postgres.item.Histories[0].Entries[0].GetPassword())
+}
+
+func TestCredentials(t *testing.T) {
+	_, err := credentials(nil, nil)
+	assert.EqualError(t, err, "a password or key file is required")
+	_, err = credentials(nil, []byte(`<KeyFile><Meta><Version>2.0</Version></Meta><Key><Data Hash="00000000">`+
+		"abababababababababababababababababababababababababababababababab"+`</Data></Key></KeyFile>`))
+	assert.EqualError(t, err, "invalid key file: key hash mismatch")
+
+	creds, err := credentials(nil, readTestdata(t, "keyfile.keyx"))
+	require.NoError(t, err)
+	assert.Nil(t, creds.Passphrase)
+	assert.Len(t, creds.Key, 32)
+}
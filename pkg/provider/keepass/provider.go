@@ -0,0 +1,167 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errMissingProvider = "missing: spec.provider.keepass"
+	errInvalidDatabase = "invalid spec.provider.keepass.database: exactly one of secretRef or url must be set"
+	errInvalidURL      = "invalid spec.provider.keepass.database.url: %w"
+	errInvalidCABundle = "invalid spec.provider.keepass.database.caBundle: only used with url"
+	errInvalidSecret   = "invalid spec.provider.keepass.database.secretRef: %w"
+	errMissingAuth     = "missing: spec.provider.keepass.auth, at least one of passwordSecretRef or keyFileSecretRef is required"
+	errInvalidPassword = "invalid spec.provider.keepass.auth.passwordSecretRef: %w"
+	errInvalidKeyFile  = "invalid spec.provider.keepass.auth.keyFileSecretRef: %w"
+)
+
+// https://github.com/external-secrets/external-secrets/issues/644
+var _ esv1beta1.SecretsClient = &Client{}
+var _ esv1beta1.Provider = &Provider{}
+
+// Provider reads and writes the entries of KeePass databases.
+type Provider struct{}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		KeePass: &esv1beta1.KeePassProvider{},
+	})
+}
+
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadWrite
+}
+
+// Features returns the optional features the provider supports.
+// Databases downloaded from a URL are read-only.
+func (p *Provider) Features(store esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	features := esv1beta1.SecretStoreFeatures{
+		FindByName: true,
+		FindByTags: true,
+		FindByPath: true,
+	}
+	if spec, err := getProvider(store); err == nil && spec.Database.SecretRef != nil {
+		features.PushWholeSecret = true
+		features.DeleteSecret = true
+		features.SecretExists = true
+	}
+	return features
+}
+
+// NewClient resolves the credentials of the store, the database is read and decrypted on first use.
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	spec, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		kube:      kube,
+		store:     spec,
+		storeKind: store.GetKind(),
+		namespace: namespace,
+	}
+
+	// allow SecretStore controller validation to pass
+	// when using referent namespace.
+	if c.storeKind == esv1beta1.ClusterSecretStoreKind && c.namespace == "" && isReferentSpec(spec) {
+		return c, nil
+	}
+
+	if err := c.resolveKey(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	spec, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	db := &spec.Database
+	if (db.SecretRef == nil) == (db.URL == "") {
+		return nil, errors.New(errInvalidDatabase)
+	}
+	if db.SecretRef != nil {
+		if err := validateSecretKeySelector(store, *db.SecretRef); err != nil {
+			return nil, fmt.Errorf(errInvalidSecret, err)
+		}
+		if len(db.CABundle) > 0 {
+			return nil, errors.New(errInvalidCABundle)
+		}
+	}
+	if db.URL != "" {
+		u, err := url.Parse(db.URL)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidURL, err)
+		}
+		if u.Scheme != "https" && u.Scheme != "http" {
+			return nil, fmt.Errorf(errInvalidURL, errors.New("scheme must be http or https"))
+		}
+	}
+
+	if spec.Auth.PasswordSecretRef == nil && spec.Auth.KeyFileSecretRef == nil {
+		return nil, errors.New(errMissingAuth)
+	}
+	if spec.Auth.PasswordSecretRef != nil {
+		if err := validateSecretKeySelector(store, *spec.Auth.PasswordSecretRef); err != nil {
+			return nil, fmt.Errorf(errInvalidPassword, err)
+		}
+	}
+	if spec.Auth.KeyFileSecretRef != nil {
+		if err := validateSecretKeySelector(store, *spec.Auth.KeyFileSecretRef); err != nil {
+			return nil, fmt.Errorf(errInvalidKeyFile, err)
+		}
+	}
+	return nil, nil
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.KeePassProvider, error) {
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.KeePass == nil {
+		return nil, errors.New(errMissingProvider)
+	}
+	return storeSpec.Provider.KeePass, nil
+}
+
+func validateSecretKeySelector(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if ref.Name == "" || ref.Key == "" {
+		return errors.New("secret name and key are required")
+	}
+	return utils.ValidateReferentSecretSelector(store, ref)
+}
+
+// isReferentSpec returns true if the database or the credentials of a ClusterSecretStore
+// are resolved in the namespace of the ExternalSecret.
+func isReferentSpec(spec *esv1beta1.KeePassProvider) bool {
+	for _, ref := range []*esmeta.SecretKeySelector{spec.Database.SecretRef, spec.Auth.PasswordSecretRef, spec.Auth.KeyFileSecretRef} {
+		if ref != nil && ref.Namespace == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepass
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestValidateStore(t *testing.T) {
+	secretDB := esv1beta1.KeePassDatabase{SecretRef: &esmeta.SecretKeySelector{Name: "keepass", Key: "db.kdbx"}}
+	password := esv1beta1.KeePassAuth{PasswordSecretRef: &esmeta.SecretKeySelector{Name: "keepass-auth", Key: "password"}}
+	tests := []struct {
+		name    string
+		kind    string
+		spec    *esv1beta1.KeePassProvider
+		wantErr string
+	}{
+		{
+			name: "valid store",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.KeePassProvider{Database: secretDB, Auth: password},
+		},
+		{
+			name: "valid URL",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.KeePassProvider{
+				Database: esv1beta1.KeePassDatabase{URL: "https://files.example.com/team.kdbx", CABundle: []byte("ca")},
+				Auth:     esv1beta1.KeePassAuth{KeyFileSecretRef: &esmeta.SecretKeySelector{Name: "keepass-auth", Key: "keyfile"}},
+			},
+		},
+		{
+			name:    "missing provider",
+			kind:    esv1beta1.SecretStoreKind,
+			wantErr: errMissingProvider,
+		},
+		{
+			name:    "missing database",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.KeePassProvider{Auth: password},
+			wantErr: errInvalidDatabase,
+		},
+		{
+			name: "secretRef and url",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.KeePassProvider{Database: esv1beta1.KeePassDatabase{
+				SecretRef: secretDB.SecretRef,
+				URL:       "https://files.example.com/team.kdbx",
+			}, Auth: password},
+			wantErr: errInvalidDatabase,
+		},
+		{
+			name:    "unsupported URL scheme",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.KeePassProvider{Database: esv1beta1.KeePassDatabase{URL: "file:///etc/team.kdbx"}, Auth: password},
+			wantErr: "invalid spec.provider.keepass.database.url: scheme must be http or https",
+		},
+		{
+			name: "caBundle with secretRef",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.KeePassProvider{Database: esv1beta1.KeePassDatabase{
+				SecretRef: secretDB.SecretRef,
+				CABundle:  []byte("ca"),
+			}, Auth: password},
+			wantErr: errInvalidCABundle,
+		},
+		{
+			name: "namespace with SecretStore",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.KeePassProvider{Database: esv1beta1.KeePassDatabase{
+				SecretRef: &esmeta.SecretKeySelector{Name: "keepass", Key: "db.kdbx", Namespace: ptr.To("other")},
+			}, Auth: password},
+			wantErr: "invalid spec.provider.keepass.database.secretRef: namespace not allowed with namespaced SecretStore",
+		},
+		{
+			name: "namespace with ClusterSecretStore",
+			kind: esv1beta1.ClusterSecretStoreKind,
+			spec: &esv1beta1.KeePassProvider{Database: esv1beta1.KeePassDatabase{
+				SecretRef: &esmeta.SecretKeySelector{Name: "keepass", Key: "db.kdbx", Namespace: ptr.To("other")},
+			}, Auth: password},
+		},
+		{
+			name:    "missing credentials",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.KeePassProvider{Database: secretDB},
+			wantErr: errMissingAuth,
+		},
+		{
+			name: "invalid key file reference",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.KeePassProvider{Database: secretDB, Auth: esv1beta1.KeePassAuth{
+				KeyFileSecretRef: &esmeta.SecretKeySelector{Name: "keepass-auth"},
+			}},
+			wantErr: "invalid spec.provider.keepass.auth.keyFileSecretRef: secret name and key are required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{Kind: tt.kind},
+				Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{KeePass: tt.spec}},
+			}
+			_, err := (&Provider{}).ValidateStore(store)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
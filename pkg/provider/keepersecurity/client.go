@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 const (
@@ -58,6 +59,12 @@ const (
 	URLType            = "url"
 )
 
+var (
+	loginTypeRegexp    = regexp.MustCompile(LoginTypeExpr)
+	passwordTypeRegexp = regexp.MustCompile(PasswordType)
+	urlTypeRegexp      = regexp.MustCompile(URLTypeExpr)
+)
+
 type Client struct {
 	ksmClient SecurityClient
 	folderID  string
@@ -132,6 +139,10 @@ func (c *Client) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFi
 	if ref.Path != nil {
 		return nil, fmt.Errorf(errPathNotImplemented)
 	}
+	nameRegexp, err := regexp.Compile(ref.Name.RegExp)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidRegex, ref.Name.RegExp, err)
+	}
 	secretData := make(map[string][]byte)
 	records, err := c.findSecrets()
 	if err != nil {
@@ -142,11 +153,7 @@ func (c *Client) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFi
 		if err != nil {
 			return nil, err
 		}
-		match, err := regexp.MatchString(ref.Name.RegExp, secret.Title)
-		if err != nil {
-			return nil, fmt.Errorf(errInvalidRegex, ref.Name.RegExp, err)
-		}
-		if !match {
+		if !nameRegexp.MatchString(secret.Title) {
 			continue
 		}
 		secretData[secret.Title], err = secret.getItem(esv1beta1.ExternalSecretDataRemoteRef{})
@@ -163,11 +170,10 @@ func (c *Client) Close(_ context.Context) error {
 }
 
 func (c *Client) PushSecret(_ context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
-	if data.GetSecretKey() == "" {
-		return fmt.Errorf("pushing the whole secret is not yet implemented")
+	value, err := utils.PushSecretValue(secret, data)
+	if err != nil {
+		return err
 	}
-
-	value := secret.Data[data.GetSecretKey()]
 	parts, err := c.buildSecretNameAndKey(data)
 	if err != nil {
 		return err
@@ -212,8 +218,38 @@ func (c *Client) DeleteSecret(_ context.Context, remoteRef esv1beta1.PushSecretR
 	return nil
 }
 
-func (c *Client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
-	return false, fmt.Errorf("not implemented")
+// SecretExists checks if a record with the name of the remote key exists
+// and has a value for the field the remote key points to.
+func (c *Client) SecretExists(_ context.Context, remoteRef esv1beta1.PushSecretRemoteRef) (bool, error) {
+	parts, err := c.buildSecretNameAndKey(remoteRef)
+	if err != nil {
+		return false, err
+	}
+	record, err := c.findSecretByName(parts[0])
+	if err != nil {
+		return false, err
+	}
+	if record == nil {
+		return false, nil
+	}
+
+	return hasFieldValue(record, parts[1]), nil
+}
+
+// hasFieldValue checks if the record has a value for the field a key is pushed to.
+func hasFieldValue(record *ksm.Record, key string) bool {
+	normalizedKey := strings.ToLower(key)
+
+	switch {
+	case loginTypeRegexp.MatchString(normalizedKey):
+		return record.GetFieldValueByType(LoginType) != ""
+	case passwordTypeRegexp.MatchString(normalizedKey):
+		return record.GetFieldValueByType(PasswordType) != ""
+	case urlTypeRegexp.MatchString(normalizedKey):
+		return record.GetFieldValueByType(URLType) != ""
+	default:
+		return record.GetCustomFieldValueByLabel(key) != ""
+	}
 }
 
 func (c *Client) buildSecretNameAndKey(remoteRef esv1beta1.PushSecretRemoteRef) ([]string, error) {
@@ -228,20 +264,17 @@ func (c *Client) buildSecretNameAndKey(remoteRef esv1beta1.PushSecretRemoteRef)
 func (c *Client) createSecret(name, key string, value []byte) (string, error) {
 	normalizedKey := strings.ToLower(key)
 	externalSecretRecord := ksm.NewRecordCreate(externalSecretType, name)
-	login := regexp.MustCompile(LoginTypeExpr)
-	pass := regexp.MustCompile(PasswordType)
-	url := regexp.MustCompile(URLTypeExpr)
 
 	switch {
-	case login.MatchString(normalizedKey):
+	case loginTypeRegexp.MatchString(normalizedKey):
 		externalSecretRecord.Fields = append(externalSecretRecord.Fields,
 			ksm.NewLogin(string(value)),
 		)
-	case pass.MatchString(normalizedKey):
+	case passwordTypeRegexp.MatchString(normalizedKey):
 		externalSecretRecord.Fields = append(externalSecretRecord.Fields,
 			ksm.NewPassword(string(value)),
 		)
-	case url.MatchString(normalizedKey):
+	case urlTypeRegexp.MatchString(normalizedKey):
 		externalSecretRecord.Fields = append(externalSecretRecord.Fields,
 			ksm.NewUrl(string(value)),
 		)
@@ -257,17 +290,14 @@ func (c *Client) createSecret(name, key string, value []byte) (string, error) {
 
 func (c *Client) updateSecret(secret *ksm.Record, key string, value []byte) error {
 	normalizedKey := strings.ToLower(key)
-	login := regexp.MustCompile(LoginTypeExpr)
-	pass := regexp.MustCompile(PasswordType)
-	url := regexp.MustCompile(URLTypeExpr)
 	custom := false
 
 	switch {
-	case login.MatchString(normalizedKey):
+	case loginTypeRegexp.MatchString(normalizedKey):
 		secret.SetFieldValueSingle(LoginType, string(value))
-	case pass.MatchString(normalizedKey):
+	case passwordTypeRegexp.MatchString(normalizedKey):
 		secret.SetPassword(string(value))
-	case url.MatchString(normalizedKey):
+	case urlTypeRegexp.MatchString(normalizedKey):
 		secret.SetFieldValueSingle(URLType, string(value))
 	default:
 		custom = true
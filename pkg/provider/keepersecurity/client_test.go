@@ -529,6 +529,34 @@ func TestClientPushSecret(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Push whole secret as json",
+			fields: fields{
+				ksmClient: &fake.MockKeeperClient{
+					GetSecretByTitleFn: func(recordTitle string) (*ksm.Record, error) {
+						return nil, errors.New("NotFound")
+					},
+					CreateSecretWithRecordDataFn: func(recUID, folderUid string, recordData *ksm.RecordCreate) (string, error) {
+						if len(recordData.Custom) != 1 {
+							return "", errors.New("unexpected record fields")
+						}
+						value := recordData.Custom[0].(ksm.Secret).Value
+						if len(value) != 1 || value[0] != `{"secret-key":"foo"}` {
+							return "", fmt.Errorf("unexpected record value %v", value)
+						}
+						return "record5", nil
+					},
+				},
+				folderID: folderID,
+			},
+			args: args{
+				data: testingfake.PushSecretData{
+					RemoteKey: "record5/config",
+				},
+				value: []byte("foo"),
+			},
+			wantErr: false,
+		},
 		{
 			name: "Push existing valid secret",
 			fields: fields{
@@ -632,6 +660,98 @@ func TestClientPushSecret(t *testing.T) {
 	}
 }
 
+func TestClientSecretExists(t *testing.T) {
+	record := &ksm.Record{
+		RecordDict: map[string]any{
+			"type":  externalSecretType,
+			"title": record0,
+			"fields": []any{
+				map[string]any{"type": LoginType, "value": []any{"foo"}},
+			},
+			"custom": []any{
+				map[string]any{"type": secretType, "label": "config", "value": []any{"bar"}},
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		remoteKey string
+		getFn     func(recordTitle string) (*ksm.Record, error)
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "Invalid remote ref",
+			remoteKey: record0,
+			wantErr:   true,
+		},
+		{
+			name:      "Record does not exist",
+			remoteKey: invalidRecord,
+			getFn: func(recordTitle string) (*ksm.Record, error) {
+				return nil, nil
+			},
+			want: false,
+		},
+		{
+			name:      "Standard field exists",
+			remoteKey: validExistingRecord,
+			getFn: func(recordTitle string) (*ksm.Record, error) {
+				return record, nil
+			},
+			want: true,
+		},
+		{
+			name:      "Standard field does not exist",
+			remoteKey: "record0/password",
+			getFn: func(recordTitle string) (*ksm.Record, error) {
+				return record, nil
+			},
+			want: false,
+		},
+		{
+			name:      "Custom field exists",
+			remoteKey: "record0/config",
+			getFn: func(recordTitle string) (*ksm.Record, error) {
+				return record, nil
+			},
+			want: true,
+		},
+		{
+			name:      "Custom field does not exist",
+			remoteKey: "record0/other",
+			getFn: func(recordTitle string) (*ksm.Record, error) {
+				return record, nil
+			},
+			want: false,
+		},
+		{
+			name:      "Unable to get records",
+			remoteKey: validExistingRecord,
+			getFn: func(recordTitle string) (*ksm.Record, error) {
+				return nil, errors.New("Unable to get records")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				ksmClient: &fake.MockKeeperClient{GetSecretByTitleFn: tt.getFn},
+				folderID:  folderID,
+			}
+			got, err := c.SecretExists(context.Background(), testingfake.PushSecretData{RemoteKey: tt.remoteKey})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SecretExists() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SecretExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func generateRecords() []*ksm.Record {
 	var records []*ksm.Record
 	for i := 0; i < 3; i++ {
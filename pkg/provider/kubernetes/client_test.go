@@ -731,6 +731,82 @@ func TestDeleteSecret(t *testing.T) {
 	}
 }
 
+func TestSecretExists(t *testing.T) {
+	secretMap := map[string]*v1.Secret{
+		"mysec": {
+			Data: map[string][]byte{
+				"token": []byte(`foobar`),
+			},
+		},
+	}
+	tests := []struct {
+		name   string
+		client KClient
+		ref    esv1beta1.PushSecretRemoteRef
+		want   bool
+
+		wantErr bool
+	}{
+		{
+			name:   "secret exists",
+			client: &fakeClient{t: t, secretMap: secretMap},
+			ref: v1alpha1.PushSecretRemoteRef{
+				RemoteKey: "mysec",
+			},
+			want: true,
+		},
+		{
+			name:   "secret does not exist",
+			client: &fakeClient{t: t, secretMap: secretMap},
+			ref: v1alpha1.PushSecretRemoteRef{
+				RemoteKey: "othersec",
+			},
+			want: false,
+		},
+		{
+			name:   "property exists",
+			client: &fakeClient{t: t, secretMap: secretMap},
+			ref: v1alpha1.PushSecretRemoteRef{
+				RemoteKey: "mysec",
+				Property:  "token",
+			},
+			want: true,
+		},
+		{
+			name:   "property does not exist",
+			client: &fakeClient{t: t, secretMap: secretMap},
+			ref: v1alpha1.PushSecretRemoteRef{
+				RemoteKey: "mysec",
+				Property:  "secret",
+			},
+			want: false,
+		},
+		{
+			name:   "error getting secret",
+			client: &fakeClient{t: t, secretMap: secretMap, err: errors.New(errSomethingWentWrong)},
+			ref: v1alpha1.PushSecretRemoteRef{
+				RemoteKey: "mysec",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Client{
+				userSecretClient: tt.client,
+			}
+			got, err := p.SecretExists(context.Background(), tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProviderKubernetes.SecretExists() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ProviderKubernetes.SecretExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPushSecret(t *testing.T) {
 	secretKey := "secret-key"
 	type fields struct {
@@ -1703,6 +1703,62 @@ func TestProviderOnePasswordDeleteItem(t *testing.T) {
 	}
 }
 
+func TestProviderOnePasswordSecretExists(t *testing.T) {
+	testCases := []struct {
+		setupNote   string
+		ref         fakeRef
+		expected    bool
+		expectedErr error
+	}{
+		{
+			setupNote: "item with password field exists",
+			ref:       fakeRef{key: myItem},
+			expected:  true,
+		},
+		{
+			setupNote: "item with property field exists",
+			ref:       fakeRef{key: myItem, prop: key1},
+			expected:  true,
+		},
+		{
+			setupNote: "item exists without property field",
+			ref:       fakeRef{key: myItem, prop: key2},
+			expected:  false,
+		},
+		{
+			setupNote: "item does not exist",
+			ref:       fakeRef{key: myOtherItem},
+			expected:  false,
+		},
+		{
+			setupNote:   "more than one item",
+			ref:         fakeRef{key: mySharedItem},
+			expectedErr: ErrExpectedOneItem,
+		},
+	}
+
+	provider := &ProviderOnePassword{
+		vaults: map[string]int{myVault: 1},
+		client: fake.NewMockClient().
+			AddPredictableVault(myVault).
+			AddPredictableItemWithField(myVault, myItem, password, value1).
+			AppendItemField(myVaultID, myItemID, onepassword.ItemField{Label: key1, Value: value1}).
+			AddPredictableItemWithField(myVault, mySharedItem, key1, value1).
+			AddPredictableItemWithField(myVault, mySharedItem, key1, value1),
+	}
+	for _, tc := range testCases {
+		t.Run(tc.setupNote, func(t *testing.T) {
+			exists, err := provider.SecretExists(context.Background(), tc.ref)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf(errDoesNotMatchMsgF, tc.setupNote, tc.expectedErr, err)
+			}
+			if exists != tc.expected {
+				t.Errorf("%s: unexpected result: -expected, +got:\n-%v\n+%v\n", tc.setupNote, tc.expected, exists)
+			}
+		})
+	}
+}
+
 func TestUpdateFields(t *testing.T) {
 	type testCase struct {
 		inputFields    []*onepassword.ItemField
@@ -26,6 +26,8 @@ type OracleMockVaultClient struct {
 	CreatedCount    int
 	UpdatedCount    int
 	DeletedCount    int
+	// LastContent is the base64 encoded content of the last created or updated secret.
+	LastContent string
 }
 
 func (o *OracleMockVaultClient) ListSecrets(_ context.Context, _ vault.ListSecretsRequest) (response vault.ListSecretsResponse, err error) {
@@ -34,16 +36,24 @@ func (o *OracleMockVaultClient) ListSecrets(_ context.Context, _ vault.ListSecre
 	}, nil
 }
 
-func (o *OracleMockVaultClient) CreateSecret(_ context.Context, _ vault.CreateSecretRequest) (response vault.CreateSecretResponse, err error) {
+func (o *OracleMockVaultClient) CreateSecret(_ context.Context, request vault.CreateSecretRequest) (response vault.CreateSecretResponse, err error) {
 	o.CreatedCount++
+	o.setLastContent(request.SecretContent)
 	return vault.CreateSecretResponse{}, nil
 }
 
-func (o *OracleMockVaultClient) UpdateSecret(_ context.Context, _ vault.UpdateSecretRequest) (response vault.UpdateSecretResponse, err error) {
+func (o *OracleMockVaultClient) UpdateSecret(_ context.Context, request vault.UpdateSecretRequest) (response vault.UpdateSecretResponse, err error) {
 	o.UpdatedCount++
+	o.setLastContent(request.SecretContent)
 	return vault.UpdateSecretResponse{}, nil
 }
 
+func (o *OracleMockVaultClient) setLastContent(content vault.SecretContentDetails) {
+	if c, ok := content.(vault.Base64SecretContentDetails); ok && c.Content != nil {
+		o.LastContent = *c.Content
+	}
+}
+
 func (o *OracleMockVaultClient) ScheduleSecretDeletion(_ context.Context, _ vault.ScheduleSecretDeletionRequest) (response vault.ScheduleSecretDeletionResponse, err error) {
 	o.DeletedCount++
 	return vault.ScheduleSecretDeletionResponse{}, nil
@@ -96,11 +96,10 @@ const (
 )
 
 func (vms *VaultManagementService) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
-	if data.GetSecretKey() == "" {
-		return fmt.Errorf("pushing the whole secret is not yet implemented")
+	value, err := utils.PushSecretValue(secret, data)
+	if err != nil {
+		return err
 	}
-
-	value := secret.Data[data.GetSecretKey()]
 	secretName := data.GetRemoteKey()
 	encodedValue := base64.StdEncoding.EncodeToString(value)
 	sec, action, err := vms.getSecretBundleWithCode(ctx, secretName)
@@ -159,8 +158,28 @@ func (vms *VaultManagementService) DeleteSecret(ctx context.Context, remoteRef e
 	}
 }
 
-func (vms *VaultManagementService) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
-	return false, fmt.Errorf("not implemented")
+// SecretExists checks if the secret exists and is not scheduled for deletion.
+// If a property is set, the secret value has to be a JSON object containing the property.
+func (vms *VaultManagementService) SecretExists(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) (bool, error) {
+	resp, action, err := vms.getSecretBundleWithCode(ctx, remoteRef.GetRemoteKey())
+	switch action {
+	case SecretNotFound:
+		return false, nil
+	case SecretExists:
+		if resp.TimeOfDeletion != nil {
+			return false, nil
+		}
+		if remoteRef.GetProperty() == "" {
+			return true, nil
+		}
+		payload, err := decodeBundle(resp)
+		if err != nil {
+			return false, err
+		}
+		return gjson.Get(string(payload), remoteRef.GetProperty()).Exists(), nil
+	default:
+		return false, sanitizeOCISDKErr(err)
+	}
 }
 
 func (vms *VaultManagementService) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
@@ -249,6 +268,18 @@ func (vms *VaultManagementService) Capabilities() esv1beta1.SecretStoreCapabilit
 	return esv1beta1.SecretStoreReadOnly
 }
 
+// Features returns the optional features the provider supports.
+func (vms *VaultManagementService) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{
+		FindByName:      true,
+		FindByTags:      true,
+		Versions:        true,
+		PushWholeSecret: true,
+		DeleteSecret:    true,
+		SecretExists:    true,
+	}
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (vms *VaultManagementService) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	storeSpec := store.GetSpec()
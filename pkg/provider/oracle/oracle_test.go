@@ -605,6 +605,25 @@ func TestOracleVaultPushSecret(t *testing.T) {
 			},
 			"created",
 		},
+		"create the whole secret as json": {
+			&VaultManagementService{
+				Client: &fakeoracle.OracleMockClient{
+					SecretBundles: map[string]secrets.SecretBundle{
+						s2id: s2bundle,
+					},
+				},
+				VaultClient: &fakeoracle.OracleMockVaultClient{},
+			},
+			testingfake.PushSecretData{
+				RemoteKey: s1id,
+			},
+			func(vms *VaultManagementService) bool {
+				client := vms.VaultClient.(*fakeoracle.OracleMockVaultClient)
+				return client.CreatedCount == 1 &&
+					client.LastContent == base64.StdEncoding.EncodeToString([]byte(`{"test-secret-key":"created"}`))
+			},
+			"created",
+		},
 		"update a secret if exists": {
 			&VaultManagementService{
 				Client: &fakeoracle.OracleMockClient{
@@ -721,6 +740,68 @@ func TestOracleVaultDeleteSecret(t *testing.T) {
 	}
 }
 
+func TestOracleVaultSecretExists(t *testing.T) {
+	jsonID := "json"
+	jsonBundle := makeSecretBundle(jsonID, false)
+	jsonBundle.SecretBundleContent = secrets.Base64SecretBundleContentDetails{
+		Content: ptr.To(base64.StdEncoding.EncodeToString([]byte(`{"foo":"bar"}`))),
+	}
+	vms := &VaultManagementService{
+		Client: &fakeoracle.OracleMockClient{
+			SecretBundles: map[string]secrets.SecretBundle{
+				s1id:   s1bundle,
+				s3id:   s3bundle,
+				jsonID: jsonBundle,
+			},
+		},
+		VaultClient: &fakeoracle.OracleMockVaultClient{},
+	}
+	var testCases = map[string]struct {
+		remoteRef esv1beta1.PushSecretRemoteRef
+		exists    bool
+	}{
+		"secret exists": {
+			esv1alpha1.PushSecretRemoteRef{
+				RemoteKey: s1id,
+			},
+			true,
+		},
+		"secret not found": {
+			esv1alpha1.PushSecretRemoteRef{
+				RemoteKey: s2id,
+			},
+			false,
+		},
+		"secret is deleting": {
+			esv1alpha1.PushSecretRemoteRef{
+				RemoteKey: s3id,
+			},
+			false,
+		},
+		"property exists": {
+			esv1alpha1.PushSecretRemoteRef{
+				RemoteKey: jsonID,
+				Property:  "foo",
+			},
+			true,
+		},
+		"property not found": {
+			esv1alpha1.PushSecretRemoteRef{
+				RemoteKey: jsonID,
+				Property:  "baz",
+			},
+			false,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			exists, err := vms.SecretExists(context.Background(), testCase.remoteRef)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.exists, exists)
+		})
+	}
+}
+
 var (
 	s1id      = "test1"
 	s2id      = "mysecret"
@@ -52,6 +52,13 @@ func (provider *ProviderPassbolt) Capabilities() esv1beta1.SecretStoreCapabiliti
 	return esv1beta1.SecretStoreReadOnly
 }
 
+// Features returns the optional features the provider supports.
+func (provider *ProviderPassbolt) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{
+		FindByName: true,
+	}
+}
+
 type Client interface {
 	CheckSession(ctx context.Context) bool
 	Login(ctx context.Context) error
@@ -56,6 +56,11 @@ func (p *PasswordDepot) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadOnly
 }
 
+// Features returns the optional features the provider supports.
+func (p *PasswordDepot) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{}
+}
+
 // Client for interacting with kubernetes cluster...?
 type passwordDepotClient struct {
 	kube      kclient.Client
@@ -235,6 +235,24 @@ func TestPushSecret(t *testing.T) {
 		assert.Equal(t, data, db.secret(secretName).versions[0].data)
 	})
 
+	t.Run("whole secret as json", func(t *testing.T) {
+		ctx := context.Background()
+		c := newTestClient()
+		secretName := "whole-secret-test"
+		wholeSecret := &corev1.Secret{
+			Data: map[string][]byte{
+				"username": []byte("user"),
+				"password": []byte("pass"),
+			},
+		}
+
+		pushErr := c.PushSecret(ctx, wholeSecret, testingfake.PushSecretData{RemoteKey: fmt.Sprintf("name:%s", secretName)})
+
+		assert.NoError(t, pushErr)
+		assert.Len(t, db.secret(secretName).versions, 1)
+		assert.JSONEq(t, `{"username":"user","password":"pass"}`, string(db.secret(secretName).versions[0].data))
+	})
+
 	t.Run("to secret created by us", func(t *testing.T) {
 		ctx := context.Background()
 		c := newTestClient()
@@ -402,6 +420,49 @@ func TestGetAllSecrets(t *testing.T) {
 	}
 }
 
+func TestSecretExists(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient()
+
+	testCases := map[string]struct {
+		ref    testingfake.PushSecretData
+		exists bool
+	}{
+		"existing secret by name": {
+			ref:    testingfake.PushSecretData{RemoteKey: "name:json-data"},
+			exists: true,
+		},
+		"existing secret by path": {
+			ref:    testingfake.PushSecretData{RemoteKey: "path:/subpath/nested-secret"},
+			exists: true,
+		},
+		"existing json property": {
+			ref:    testingfake.PushSecretData{RemoteKey: "name:json-nested", Property: "root.intermediate.leaf"},
+			exists: true,
+		},
+		"non existing json property": {
+			ref:    testingfake.PushSecretData{RemoteKey: "name:json-nested", Property: "root.intermediate.missing"},
+			exists: false,
+		},
+		"non existing secret name": {
+			ref:    testingfake.PushSecretData{RemoteKey: "name:not-a-secret"},
+			exists: false,
+		},
+		"non existing secret id": {
+			ref:    testingfake.PushSecretData{RemoteKey: "id:730aa98d-ec0c-4426-8202-b11aeec8ea1e"},
+			exists: false,
+		},
+	}
+
+	for tcName, tc := range testCases {
+		t.Run(tcName, func(t *testing.T) {
+			exists, err := c.SecretExists(ctx, tc.ref)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exists, exists)
+		})
+	}
+}
+
 func TestDeleteSecret(t *testing.T) {
 	ctx := context.Background()
 	c := newTestClient()
@@ -0,0 +1,43 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// parseAgeIdentities parses the identities, one per line as created by age-keygen.
+// Empty lines and comments are ignored.
+func parseAgeIdentities(s string) ([]age.Identity, error) {
+	return age.ParseIdentities(strings.NewReader(s))
+}
+
+// decryptAge decrypts the armored or binary age file with one of the identities.
+func decryptAge(identities []age.Identity, file []byte) ([]byte, error) {
+	var r io.Reader = bytes.NewReader(file)
+	if trimmed := bytes.TrimSpace(file); bytes.HasPrefix(trimmed, []byte(armor.Header)) {
+		r = armor.NewReader(bytes.NewReader(trimmed))
+	}
+	plaintext, err := age.Decrypt(r, identities...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(plaintext)
+}
@@ -0,0 +1,285 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+)
+
+const (
+	errNotImplemented   = "not implemented"
+	errFindByTags       = "find by tags is not supported"
+	errPropertyNotFound = "property %s not found in document %s"
+	errNotAnObject      = "document %s: %s is not an object"
+	errGetConfigMap     = "unable to get ConfigMap %s: %w"
+	errGetSecret        = "unable to get Secret %s: %w"
+	errReadPath         = "unable to read documents from %s: %w"
+	errDecryptDocument  = "unable to decrypt document %s: %w"
+	errNoKeys           = "keys are not resolved"
+)
+
+// Client reads and decrypts the documents of a store.
+type Client struct {
+	kube      kclient.Client
+	store     *esv1beta1.SopsProvider
+	storeKind string
+	// namespace of the ExternalSecret referencing the store.
+	namespace string
+	keys      *Keyring
+
+	// documents are the encrypted documents by name, read on first use.
+	documents map[string][]byte
+	// decrypted are the decrypted documents as JSON by name.
+	decrypted map[string][]byte
+}
+
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	data, err := c.document(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return data, nil
+	}
+	val := gjson.GetBytes(data, ref.Property)
+	if !val.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	return resultBytes(val), nil
+}
+
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	data, err := c.document(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	val := gjson.ParseBytes(data)
+	if ref.Property != "" {
+		val = val.Get(ref.Property)
+		if !val.Exists() {
+			return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+		}
+	}
+	if !val.IsObject() {
+		return nil, fmt.Errorf(errNotAnObject, ref.Key, ref.Property)
+	}
+	secretMap := make(map[string][]byte)
+	val.ForEach(func(key, value gjson.Result) bool {
+		secretMap[key.String()] = resultBytes(value)
+		return true
+	})
+	return secretMap, nil
+}
+
+// GetAllSecrets returns the documents whose name matches find.name and starts with find.path.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Tags != nil {
+		return nil, errors.New(errFindByTags)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+	if err := c.load(ctx); err != nil {
+		return nil, err
+	}
+	secretMap := make(map[string][]byte)
+	for name := range c.documents {
+		if ref.Path != nil && !strings.HasPrefix(name, *ref.Path) {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(name) {
+			continue
+		}
+		data, err := c.document(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		secretMap[name] = data
+	}
+	return secretMap, nil
+}
+
+// resultBytes returns strings as they are and other values as JSON.
+func resultBytes(val gjson.Result) []byte {
+	if val.Type == gjson.String {
+		return []byte(val.Str)
+	}
+	return []byte(val.Raw)
+}
+
+// document returns the decrypted document as JSON.
+func (c *Client) document(ctx context.Context, name string) ([]byte, error) {
+	if data, ok := c.decrypted[name]; ok {
+		return data, nil
+	}
+	if err := c.load(ctx); err != nil {
+		return nil, err
+	}
+	raw, ok := c.documents[name]
+	if !ok {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	if c.keys == nil {
+		return nil, fmt.Errorf(errDecryptDocument, name, errors.New(errNoKeys))
+	}
+	data, err := c.keys.Decrypt(raw)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptDocument, name, err)
+	}
+	if c.decrypted == nil {
+		c.decrypted = make(map[string][]byte)
+	}
+	c.decrypted[name] = data
+	return data, nil
+}
+
+// load reads the encrypted documents of all sources.
+func (c *Client) load(ctx context.Context) error {
+	if c.documents != nil {
+		return nil
+	}
+	documents := make(map[string][]byte)
+	add := func(name string, data []byte) {
+		// the first source containing a document wins
+		if _, ok := documents[name]; !ok {
+			documents[name] = data
+		}
+	}
+	for i := range c.store.Documents {
+		source := &c.store.Documents[i]
+		switch {
+		case source.ConfigMapRef != nil:
+			var cm corev1.ConfigMap
+			key := c.objectKey(source.ConfigMapRef)
+			if err := c.kube.Get(ctx, key, &cm); err != nil {
+				return fmt.Errorf(errGetConfigMap, key, err)
+			}
+			for name, data := range cm.Data {
+				add(name, []byte(data))
+			}
+			for name, data := range cm.BinaryData {
+				add(name, data)
+			}
+		case source.SecretRef != nil:
+			var secret corev1.Secret
+			key := c.objectKey(source.SecretRef)
+			if err := c.kube.Get(ctx, key, &secret); err != nil {
+				return fmt.Errorf(errGetSecret, key, err)
+			}
+			for name, data := range secret.Data {
+				add(name, data)
+			}
+		case source.Path != "":
+			if err := readPath(source.Path, add); err != nil {
+				return fmt.Errorf(errReadPath, source.Path, err)
+			}
+		}
+	}
+	c.documents = documents
+	return nil
+}
+
+func (c *Client) objectKey(ref *esv1beta1.SopsObjectRef) types.NamespacedName {
+	key := types.NamespacedName{Name: ref.Name, Namespace: c.namespace}
+	if c.storeKind == esv1beta1.ClusterSecretStoreKind && ref.Namespace != nil {
+		key.Namespace = *ref.Namespace
+	}
+	return key
+}
+
+// readPath reads the file or the files of the directory, their names are relative to the directory.
+// Hidden files and directories are skipped, which includes the data directories of mounted volumes.
+func readPath(root string, add func(name string, data []byte)) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(root)
+		if err != nil {
+			return err
+		}
+		add(filepath.Base(root), data)
+		return nil
+	}
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		add(filepath.ToSlash(name), data)
+		return nil
+	})
+}
+
+func (c *Client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New(errNotImplemented)
+}
+
+func (c *Client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New(errNotImplemented)
+}
+
+func (c *Client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
+	return false, errors.New(errNotImplemented)
+}
+
+// Validate reports the store as ready once its keys are resolved.
+// The keys of a ClusterSecretStore with referent references are only known for an ExternalSecret.
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	if c.keys == nil {
+		return esv1beta1.ValidationResultUnknown, nil
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *Client) Close(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,230 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// The documents in testdata are encrypted with the sops CLI for the age identity in testdata/age.txt.
+// app.enc.json is encrypted for the PGP key in testdata/pgp.asc with the passphrase "passphrase",
+// groups.enc.yaml for both keys in two key groups with a Shamir threshold of 2.
+
+func TestClientAge(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "documents", Namespace: "default"},
+			Data: map[string]string{
+				"app.yaml":   readTestdata(t, "app.enc.yaml"),
+				"other.yaml": readTestdata(t, "other.enc.yaml"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "sops-age", Namespace: "default"},
+			Data:       map[string][]byte{"keys.txt": []byte(readTestdata(t, "age.txt"))},
+		},
+	).Build()
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "sops", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Sops: &esv1beta1.SopsProvider{
+			Documents: []esv1beta1.SopsDocumentSource{{ConfigMapRef: &esv1beta1.SopsObjectRef{Name: "documents"}}},
+			Keys: esv1beta1.SopsKeys{
+				Age: []esmeta.SecretKeySelector{{Name: "sops-age", Key: "keys.txt"}},
+			},
+		}}},
+	}
+	ctx := context.Background()
+	c, err := (&Provider{}).NewClient(ctx, store, kube, "default")
+	require.NoError(t, err)
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	data, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app.yaml"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"database":{"host":"db.example.com","port":5432,"password":"s3cr3t","replicas":["db-0","db-1"]},"api_token":"t0k3n","debug":true}`, string(data))
+
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app.yaml", Property: "database.password"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(data))
+
+	data, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app.yaml", Property: "database.replicas"})
+	require.NoError(t, err)
+	assert.Equal(t, `["db-0","db-1"]`, string(data))
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app.yaml", Property: "database.user"})
+	assert.EqualError(t, err, "property database.user not found in document app.yaml")
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "missing.yaml"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+
+	secretMap, err := c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app.yaml", Property: "database"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"host":     []byte("db.example.com"),
+		"port":     []byte("5432"),
+		"password": []byte("s3cr3t"),
+		"replicas": []byte(`["db-0","db-1"]`),
+	}, secretMap)
+
+	secretMap, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^other"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"other.yaml": []byte(`{"password":"other"}`)}, secretMap)
+
+	_, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "a"}})
+	assert.EqualError(t, err, errFindByTags)
+}
+
+func TestClientPGP(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "documents", Namespace: "apps"},
+			Data:       map[string][]byte{"app.json": []byte(readTestdata(t, "app.enc.json"))},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "sops-pgp", Namespace: "sops"},
+			Data:       map[string][]byte{"key.asc": []byte(readTestdata(t, "pgp.asc")), "passphrase": []byte("passphrase")},
+		},
+	).Build()
+	store := &esv1beta1.ClusterSecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.ClusterSecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "sops"},
+		Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Sops: &esv1beta1.SopsProvider{
+			Documents: []esv1beta1.SopsDocumentSource{{SecretRef: &esv1beta1.SopsObjectRef{Name: "documents"}}},
+			Keys: esv1beta1.SopsKeys{
+				PGP: []esv1beta1.SopsPGPKey{{
+					SecretRef:           esmeta.SecretKeySelector{Name: "sops-pgp", Namespace: ptr.To("sops"), Key: "key.asc"},
+					PassphraseSecretRef: &esmeta.SecretKeySelector{Name: "sops-pgp", Namespace: ptr.To("sops"), Key: "passphrase"},
+				}},
+			},
+		}}},
+	}
+	ctx := context.Background()
+
+	// the documents are resolved in the namespace of the ExternalSecret, validation is skipped
+	c, err := (&Provider{}).NewClient(ctx, store, kube, "")
+	require.NoError(t, err)
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultUnknown, result)
+
+	c, err = (&Provider{}).NewClient(ctx, store, kube, "apps")
+	require.NoError(t, err)
+	secretMap, err := c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app.json"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"password": []byte("s3cr3t"), "port": []byte("5432")}, secretMap)
+}
+
+func TestClientPath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "prod"), 0o700))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "..data"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "prod", "db.yaml"), []byte(readTestdata(t, "prod.enc.yaml")), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dev.yaml"), []byte(readTestdata(t, "dev.enc.yaml")), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..data", "dev.yaml"), []byte("hidden"), 0o600))
+
+	c := &Client{
+		store: &esv1beta1.SopsProvider{Documents: []esv1beta1.SopsDocumentSource{{Path: dir}}},
+		keys:  testKeyring(t),
+	}
+	ctx := context.Background()
+	secretMap, err := c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: ptr.To("prod/")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"prod/db.yaml": []byte(`{"password":"prod"}`)}, secretMap)
+
+	data, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "dev.yaml", Property: "password"})
+	require.NoError(t, err)
+	assert.Equal(t, "dev", string(data))
+}
+
+func TestDecrypt(t *testing.T) {
+	k := testKeyring(t)
+	doc := []byte(readTestdata(t, "app.enc.yaml"))
+	_, err := k.Decrypt(doc)
+	require.NoError(t, err)
+
+	// unencrypted values are covered by the MAC
+	partial := []byte(readTestdata(t, "partial.enc.yaml"))
+	data, err := k.Decrypt(partial)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user_unencrypted":"admin","password":"s3cr3t"}`, string(data))
+	tampered := bytes.Replace(partial, []byte("user_unencrypted: admin"), []byte("user_unencrypted: root"), 1)
+	_, err = k.Decrypt(tampered)
+	assert.EqualError(t, err, errMACMismatch)
+
+	// encrypted values are bound to their path
+	tampered = bytes.Replace(doc, []byte("api_token:"), []byte("api_key:"), 1)
+	_, err = k.Decrypt(tampered)
+	assert.ErrorContains(t, err, "unable to decrypt values")
+
+	_, err = k.Decrypt([]byte("password: s3cr3t\n"))
+	assert.EqualError(t, err, errNotSopsDocument)
+
+	// documents split into key groups need a key of each group
+	groups := []byte(readTestdata(t, "groups.enc.yaml"))
+	_, err = k.Decrypt(groups)
+	assert.ErrorContains(t, err, "none of the keys can decrypt the data key")
+	pgpKeys, err := parsePGPKey(readTestdata(t, "pgp.asc"), "passphrase")
+	require.NoError(t, err)
+	data, err = (&Keyring{age: k.age, pgp: pgpKeys}).Decrypt(groups)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"password":"s3cr3t"}`, string(data))
+
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	_, err = (&Keyring{age: []age.Identity{other}}).Decrypt(doc)
+	assert.ErrorContains(t, err, "none of the keys can decrypt the data key")
+}
+
+func TestParseAgeIdentities(t *testing.T) {
+	identities, err := parseAgeIdentities(readTestdata(t, "age.txt"))
+	require.NoError(t, err)
+	assert.Len(t, identities, 1)
+
+	_, err = parseAgeIdentities("AGE-SECRET-KEY-1QQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQ\n")
+	assert.Error(t, err)
+	_, err = parseAgeIdentities("# no identity\n")
+	assert.ErrorContains(t, err, "no secret keys found")
+}
+
+func readTestdata(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+// testKeyring returns a keyring with the age identity the documents in testdata are encrypted for.
+func testKeyring(t *testing.T) *Keyring {
+	t.Helper()
+	identities, err := parseAgeIdentities(readTestdata(t, "age.txt"))
+	require.NoError(t, err)
+	return &Keyring{age: identities}
+}
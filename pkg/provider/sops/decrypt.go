@@ -0,0 +1,140 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	gosops "github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	"github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/keyservice"
+	sopsjson "github.com/getsops/sops/v3/stores/json"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"google.golang.org/grpc"
+)
+
+const (
+	errParseDocument   = "unable to parse document: %w"
+	errNotSopsDocument = "not a SOPS document, the sops metadata is missing"
+	errNoMatchingKey   = "none of the keys can decrypt the data key: %w"
+	errDecryptValues   = "unable to decrypt values: %w"
+	errDecryptMAC      = "unable to decrypt the MAC: %w"
+	errMissingMAC      = "the document has no MAC"
+	errMACMismatch     = "MAC mismatch, the document was modified after it was encrypted"
+	errKeyType         = "unsupported key type %T, only age and PGP keys are supported"
+	errEncrypt         = "encryption is not supported"
+)
+
+// Decrypt decrypts the SOPS document, YAML or JSON, and returns its data as JSON.
+// The MAC of the document is verified, so tampered documents are rejected.
+//
+// decrypt.Data of SOPS is not used as it decrypts the data key with the keys found in the
+// environment and the file system of the controller, while only the keys of the keyring may be used.
+func (k *Keyring) Decrypt(raw []byte) ([]byte, error) {
+	var loader gosops.EncryptedFileLoader = sopsyaml.NewStore(&config.YAMLStoreConfig{})
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		loader = sopsjson.NewStore(&config.JSONStoreConfig{})
+	}
+	tree, err := loader.LoadEncryptedFile(raw)
+	if errors.Is(err, gosops.MetadataNotFound) {
+		return nil, errors.New(errNotSopsDocument)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errParseDocument, err)
+	}
+	if tree.Metadata.MessageAuthenticationCode == "" {
+		return nil, errors.New(errMissingMAC)
+	}
+	key, err := tree.Metadata.GetDataKeyWithKeyServices([]keyservice.KeyServiceClient{&keyService{keys: k}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errNoMatchingKey, err)
+	}
+
+	cipher := aes.NewCipher()
+	mac, err := tree.Decrypt(key, cipher)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptValues, err)
+	}
+	originalMAC, err := cipher.Decrypt(tree.Metadata.MessageAuthenticationCode, key, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptMAC, err)
+	}
+	if s, ok := originalMAC.(string); !ok || !hmac.Equal([]byte(s), []byte(mac)) {
+		return nil, errors.New(errMACMismatch)
+	}
+	if len(tree.Branches) == 0 {
+		return json.Marshal(map[string]any{})
+	}
+	return json.Marshal(plainValue(tree.Branches[0]))
+}
+
+// plainValue converts the decrypted tree into maps and slices, comments are dropped.
+func plainValue(v any) any {
+	switch v := v.(type) {
+	case gosops.TreeBranch:
+		m := make(map[string]any, len(v))
+		for _, item := range v {
+			if key, ok := item.Key.(string); ok {
+				m[key] = plainValue(item.Value)
+			}
+		}
+		return m
+	case []any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			if _, ok := item.(gosops.Comment); !ok {
+				items = append(items, plainValue(item))
+			}
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+// keyService decrypts the data key of a document with the keys of the keyring.
+type keyService struct {
+	keys *Keyring
+}
+
+func (s *keyService) Decrypt(_ context.Context, req *keyservice.DecryptRequest, _ ...grpc.CallOption) (*keyservice.DecryptResponse, error) {
+	var (
+		plaintext []byte
+		err       error
+	)
+	switch key := req.GetKey().GetKeyType().(type) {
+	case *keyservice.Key_AgeKey:
+		plaintext, err = decryptAge(s.keys.age, req.GetCiphertext())
+	case *keyservice.Key_PgpKey:
+		plaintext, err = decryptPGP(s.keys.pgp, string(req.GetCiphertext()))
+	default:
+		err = fmt.Errorf(errKeyType, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+}
+
+func (s *keyService) Encrypt(context.Context, *keyservice.EncryptRequest, ...grpc.CallOption) (*keyservice.EncryptResponse, error) {
+	return nil, errors.New(errEncrypt)
+}
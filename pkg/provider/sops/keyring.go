@@ -0,0 +1,103 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"gopkg.in/yaml.v3"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errParseAgeKey = "invalid keys.age[%d]: %w"
+	errParsePGPKey = "invalid keys.pgp[%d]: %w"
+
+	ageBinaryPrefix = "age-encryption.org/v1\n"
+)
+
+// Keyring holds the private keys documents are decrypted with.
+// It is shared with other providers which read SOPS documents and age files.
+type Keyring struct {
+	age []age.Identity
+	pgp openpgp.EntityList
+}
+
+// ResolveKeys reads and parses the private keys referenced by the keys.
+func ResolveKeys(ctx context.Context, kube kclient.Client, storeKind, namespace string, keys *esv1beta1.SopsKeys) (*Keyring, error) {
+	k := &Keyring{}
+	for i := range keys.Age {
+		identities, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &keys.Age[i])
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseAgeIdentities(identities)
+		if err != nil {
+			return nil, fmt.Errorf(errParseAgeKey, i, err)
+		}
+		k.age = append(k.age, parsed...)
+	}
+	for i := range keys.PGP {
+		key := &keys.PGP[i]
+		armored, err := resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, &key.SecretRef)
+		if err != nil {
+			return nil, err
+		}
+		passphrase := ""
+		if key.PassphraseSecretRef != nil {
+			passphrase, err = resolvers.SecretKeyRef(ctx, kube, storeKind, namespace, key.PassphraseSecretRef)
+			if err != nil {
+				return nil, err
+			}
+		}
+		entities, err := parsePGPKey(armored, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf(errParsePGPKey, i, err)
+		}
+		k.pgp = append(k.pgp, entities...)
+	}
+	return k, nil
+}
+
+// DecryptAge decrypts the armored or binary age file with one of the age identities.
+func (k *Keyring) DecryptAge(file []byte) ([]byte, error) {
+	return decryptAge(k.age, file)
+}
+
+// IsEncrypted returns true if the YAML or JSON document contains SOPS metadata.
+func IsEncrypted(raw []byte) bool {
+	var doc struct {
+		Metadata *struct {
+			MAC string `yaml:"mac"`
+		} `yaml:"sops"`
+	}
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return false
+	}
+	return doc.Metadata != nil && doc.Metadata.MAC != ""
+}
+
+// IsAgeFile returns true if the file is an armored or binary age file.
+func IsAgeFile(raw []byte) bool {
+	return bytes.HasPrefix(raw, []byte(ageBinaryPrefix)) || bytes.HasPrefix(bytes.TrimSpace(raw), []byte(armor.Header))
+}
@@ -0,0 +1,191 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errMissingProvider     = "missing: spec.provider.sops"
+	errMissingDocuments    = "missing: spec.provider.sops.documents"
+	errMissingKeys         = "missing: spec.provider.sops.keys, at least one age or PGP key is required"
+	errInvalidSource       = "invalid spec.provider.sops.documents[%d]: exactly one of configMapRef, secretRef or path must be set"
+	errInvalidObjectRef    = "invalid spec.provider.sops.documents[%d]: %w"
+	errPathNotAllowed      = "invalid spec.provider.sops.documents[%d]: only a ClusterSecretStore may read documents from a path"
+	errRelativePath        = "invalid spec.provider.sops.documents[%d]: path must be absolute"
+	errInvalidAgeKey       = "invalid spec.provider.sops.keys.age[%d]: %w"
+	errInvalidPGPKey       = "invalid spec.provider.sops.keys.pgp[%d]: %w"
+	errMissingObjectName   = "name is required"
+	errNamespaceNotAllowed = "namespace not allowed with namespaced SecretStore"
+)
+
+// https://github.com/external-secrets/external-secrets/issues/644
+var _ esv1beta1.SecretsClient = &Client{}
+var _ esv1beta1.Provider = &Provider{}
+
+// Provider decrypts SOPS-encrypted documents stored in ConfigMaps, Secrets or files.
+type Provider struct{}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Sops: &esv1beta1.SopsProvider{},
+	})
+}
+
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+// Features returns the optional features the provider supports.
+func (p *Provider) Features(_ esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	return esv1beta1.SecretStoreFeatures{
+		FindByName: true,
+		FindByPath: true,
+	}
+}
+
+// NewClient resolves the keys of the store, the documents are read and decrypted on first use.
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Sops == nil {
+		return nil, errors.New(errMissingProvider)
+	}
+	c := &Client{
+		kube:      kube,
+		store:     storeSpec.Provider.Sops,
+		storeKind: store.GetKind(),
+		namespace: namespace,
+	}
+
+	// allow SecretStore controller validation to pass
+	// when using referent namespace.
+	if c.storeKind == esv1beta1.ClusterSecretStoreKind && c.namespace == "" && isReferentSpec(c.store) {
+		return c, nil
+	}
+
+	keys, err := ResolveKeys(ctx, kube, c.storeKind, namespace, &c.store.Keys)
+	if err != nil {
+		return nil, err
+	}
+	c.keys = keys
+	return c, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Sops == nil {
+		return nil, errors.New(errMissingProvider)
+	}
+	spec := storeSpec.Provider.Sops
+	clusterScope := store.GetObjectKind().GroupVersionKind().Kind == esv1beta1.ClusterSecretStoreKind
+	if len(spec.Documents) == 0 {
+		return nil, errors.New(errMissingDocuments)
+	}
+	for i := range spec.Documents {
+		source := &spec.Documents[i]
+		set := 0
+		for _, ok := range []bool{source.ConfigMapRef != nil, source.SecretRef != nil, source.Path != ""} {
+			if ok {
+				set++
+			}
+		}
+		if set != 1 {
+			return nil, fmt.Errorf(errInvalidSource, i)
+		}
+		for _, ref := range []*esv1beta1.SopsObjectRef{source.ConfigMapRef, source.SecretRef} {
+			if ref == nil {
+				continue
+			}
+			if ref.Name == "" {
+				return nil, fmt.Errorf(errInvalidObjectRef, i, errors.New(errMissingObjectName))
+			}
+			if !clusterScope && ref.Namespace != nil {
+				return nil, fmt.Errorf(errInvalidObjectRef, i, errors.New(errNamespaceNotAllowed))
+			}
+		}
+		if source.Path != "" {
+			// the filesystem of the controller must not be exposed to namespaced stores
+			if !clusterScope {
+				return nil, fmt.Errorf(errPathNotAllowed, i)
+			}
+			if !filepath.IsAbs(source.Path) {
+				return nil, fmt.Errorf(errRelativePath, i)
+			}
+		}
+	}
+
+	if len(spec.Keys.Age) == 0 && len(spec.Keys.PGP) == 0 {
+		return nil, errors.New(errMissingKeys)
+	}
+	for i := range spec.Keys.Age {
+		if err := validateSecretKeySelector(store, spec.Keys.Age[i]); err != nil {
+			return nil, fmt.Errorf(errInvalidAgeKey, i, err)
+		}
+	}
+	for i := range spec.Keys.PGP {
+		key := &spec.Keys.PGP[i]
+		if err := validateSecretKeySelector(store, key.SecretRef); err != nil {
+			return nil, fmt.Errorf(errInvalidPGPKey, i, err)
+		}
+		if key.PassphraseSecretRef != nil {
+			if err := validateSecretKeySelector(store, *key.PassphraseSecretRef); err != nil {
+				return nil, fmt.Errorf(errInvalidPGPKey, i, err)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func validateSecretKeySelector(store esv1beta1.GenericStore, ref esmeta.SecretKeySelector) error {
+	if ref.Name == "" || ref.Key == "" {
+		return errors.New("secret name and key are required")
+	}
+	return utils.ValidateReferentSecretSelector(store, ref)
+}
+
+// isReferentSpec returns true if the keys or documents of a ClusterSecretStore
+// are resolved in the namespace of the ExternalSecret.
+func isReferentSpec(spec *esv1beta1.SopsProvider) bool {
+	for i := range spec.Keys.Age {
+		if spec.Keys.Age[i].Namespace == nil {
+			return true
+		}
+	}
+	for i := range spec.Keys.PGP {
+		key := &spec.Keys.PGP[i]
+		if key.SecretRef.Namespace == nil || (key.PassphraseSecretRef != nil && key.PassphraseSecretRef.Namespace == nil) {
+			return true
+		}
+	}
+	for i := range spec.Documents {
+		for _, ref := range []*esv1beta1.SopsObjectRef{spec.Documents[i].ConfigMapRef, spec.Documents[i].SecretRef} {
+			if ref != nil && ref.Namespace == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
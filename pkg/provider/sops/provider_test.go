@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestValidateStore(t *testing.T) {
+	ageKeys := esv1beta1.SopsKeys{Age: []esmeta.SecretKeySelector{{Name: "sops-age", Key: "keys.txt"}}}
+	configMap := esv1beta1.SopsDocumentSource{ConfigMapRef: &esv1beta1.SopsObjectRef{Name: "documents"}}
+	tests := []struct {
+		name    string
+		kind    string
+		spec    *esv1beta1.SopsProvider
+		wantErr string
+	}{
+		{
+			name: "valid store",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.SopsProvider{Documents: []esv1beta1.SopsDocumentSource{configMap}, Keys: ageKeys},
+		},
+		{
+			name:    "missing provider",
+			kind:    esv1beta1.SecretStoreKind,
+			wantErr: errMissingProvider,
+		},
+		{
+			name:    "missing documents",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.SopsProvider{Keys: ageKeys},
+			wantErr: errMissingDocuments,
+		},
+		{
+			name: "several sources in one entry",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.SopsProvider{Documents: []esv1beta1.SopsDocumentSource{{
+				ConfigMapRef: &esv1beta1.SopsObjectRef{Name: "documents"},
+				SecretRef:    &esv1beta1.SopsObjectRef{Name: "documents"},
+			}}, Keys: ageKeys},
+			wantErr: "invalid spec.provider.sops.documents[0]: exactly one of configMapRef, secretRef or path must be set",
+		},
+		{
+			name: "namespace with SecretStore",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.SopsProvider{Documents: []esv1beta1.SopsDocumentSource{{
+				SecretRef: &esv1beta1.SopsObjectRef{Name: "documents", Namespace: ptr.To("other")},
+			}}, Keys: ageKeys},
+			wantErr: "invalid spec.provider.sops.documents[0]: namespace not allowed with namespaced SecretStore",
+		},
+		{
+			name:    "path with SecretStore",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.SopsProvider{Documents: []esv1beta1.SopsDocumentSource{{Path: "/etc/sops"}}, Keys: ageKeys},
+			wantErr: "invalid spec.provider.sops.documents[0]: only a ClusterSecretStore may read documents from a path",
+		},
+		{
+			name: "path with ClusterSecretStore",
+			kind: esv1beta1.ClusterSecretStoreKind,
+			spec: &esv1beta1.SopsProvider{Documents: []esv1beta1.SopsDocumentSource{{Path: "/etc/sops"}}, Keys: ageKeys},
+		},
+		{
+			name:    "relative path",
+			kind:    esv1beta1.ClusterSecretStoreKind,
+			spec:    &esv1beta1.SopsProvider{Documents: []esv1beta1.SopsDocumentSource{{Path: "sops"}}, Keys: ageKeys},
+			wantErr: "invalid spec.provider.sops.documents[0]: path must be absolute",
+		},
+		{
+			name:    "missing keys",
+			kind:    esv1beta1.SecretStoreKind,
+			spec:    &esv1beta1.SopsProvider{Documents: []esv1beta1.SopsDocumentSource{configMap}},
+			wantErr: errMissingKeys,
+		},
+		{
+			name: "invalid PGP passphrase reference",
+			kind: esv1beta1.SecretStoreKind,
+			spec: &esv1beta1.SopsProvider{Documents: []esv1beta1.SopsDocumentSource{configMap}, Keys: esv1beta1.SopsKeys{
+				PGP: []esv1beta1.SopsPGPKey{{
+					SecretRef:           esmeta.SecretKeySelector{Name: "sops-pgp", Key: "key.asc"},
+					PassphraseSecretRef: &esmeta.SecretKeySelector{Name: "sops-pgp"},
+				}},
+			}},
+			wantErr: "invalid spec.provider.sops.keys.pgp[0]: secret name and key are required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{Kind: tt.kind},
+				Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Sops: tt.spec}},
+			}
+			_, err := (&Provider{}).ValidateStore(store)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -21,6 +21,10 @@ type PushSecretData struct {
 	SecretKey string
 	RemoteKey string
 	Property  string
+	Owner     string
+	Adopt     bool
+	// Version receives the remote version reported by the provider, if set.
+	Version *string
 }
 
 func (f PushSecretData) GetMetadata() *apiextensionsv1.JSON {
@@ -38,3 +42,17 @@ func (f PushSecretData) GetRemoteKey() string {
 func (f PushSecretData) GetProperty() string {
 	return f.Property
 }
+
+func (f PushSecretData) GetOwner() string {
+	return f.Owner
+}
+
+func (f PushSecretData) GetAdopt() bool {
+	return f.Adopt
+}
+
+func (f PushSecretData) SetRemoteVersion(version string) {
+	if f.Version != nil {
+		*f.Version = version
+	}
+}
@@ -103,6 +103,18 @@ func (c *client) setAuth(ctx context.Context, cfg *vault.Config) error {
 		return err
 	}
 
+	tokenExists, err = setGCPAuthToken(ctx, c, signGCPJWT)
+	if tokenExists {
+		c.log.V(1).Info("Retrieved new token using GCP auth")
+		return err
+	}
+
+	tokenExists, err = setAzureAuthToken(ctx, c, fetchAzureToken)
+	if tokenExists {
+		c.log.V(1).Info("Retrieved new token using Azure auth")
+		return err
+	}
+
 	return errors.New(errAuthFormat)
 }
 
@@ -0,0 +1,186 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault"
+)
+
+const (
+	defaultAzureAuthMountPath = "azure"
+	defaultAzureAuthResource  = "https://management.azure.com/"
+
+	errAzureMissingWorkloadEnvVars = "missing environment variables. AZURE_CLIENT_ID, AZURE_TENANT_ID and AZURE_FEDERATED_TOKEN_FILE must be set"
+	errAzureReadTokenFile          = "unable to read token file %s: %w"
+	errAzureMissingClientID        = "missing clientID: service account annotation '%s' is missing"
+	errAzureMissingTenantID        = "missing tenantID: set auth.azure.tenantId or service account annotation '%s'"
+	errAzureAccessToken            = "unable to fetch Azure access token: %w"
+)
+
+// azureTokenFetcher returns an Azure access token for the resource configured in the Azure auth method.
+type azureTokenFetcher func(ctx context.Context, c *client, azureAuth *esv1beta1.VaultAzureAuth) (string, error)
+
+func setAzureAuthToken(ctx context.Context, v *client, fetchToken azureTokenFetcher) (bool, error) {
+	azureAuth := v.store.Auth.Azure
+	if azureAuth != nil {
+		err := v.requestTokenWithAzureAuth(ctx, azureAuth, fetchToken)
+		if err != nil {
+			return true, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (c *client) requestTokenWithAzureAuth(ctx context.Context, azureAuth *esv1beta1.VaultAzureAuth, fetchToken azureTokenFetcher) error {
+	path := defaultAzureAuthMountPath
+	if azureAuth.Path != "" {
+		path = azureAuth.Path
+	}
+	jwt, err := fetchToken(ctx, c, azureAuth)
+	if err != nil {
+		return fmt.Errorf(errAzureAccessToken, err)
+	}
+
+	// https://developer.hashicorp.com/vault/api-docs/auth/azure#login
+	parameters := map[string]any{
+		"role": strings.TrimSpace(azureAuth.Role),
+		"jwt":  jwt,
+	}
+	for name, value := range map[string]string{
+		"subscription_id":     azureAuth.SubscriptionID,
+		"resource_group_name": azureAuth.ResourceGroupName,
+		"vm_name":             azureAuth.VMName,
+		"vmss_name":           azureAuth.VMSSName,
+		"resource_id":         azureAuth.ResourceID,
+	} {
+		if value != "" {
+			parameters[name] = value
+		}
+	}
+	url := strings.Join([]string{"auth", path, "login"}, "/")
+	vaultResult, err := c.logical.WriteWithContext(ctx, url, parameters)
+	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultWriteSecretData, err)
+	if err != nil {
+		return err
+	}
+
+	token, err := vaultResult.TokenID()
+	if err != nil {
+		return fmt.Errorf(errVaultToken, err)
+	}
+	c.client.SetToken(token)
+	return nil
+}
+
+// fetchAzureToken fetches the access token of the managed identity or workload identity.
+func fetchAzureToken(ctx context.Context, c *client, azureAuth *esv1beta1.VaultAzureAuth) (string, error) {
+	resource := defaultAzureAuthResource
+	if azureAuth.Resource != "" {
+		resource = azureAuth.Resource
+	}
+	scope := fmt.Sprintf("%s/.default", strings.TrimSuffix(resource, "/"))
+	if azureAuth.AuthType != nil && *azureAuth.AuthType == esv1beta1.AzureManagedIdentity {
+		opts := &azidentity.ManagedIdentityCredentialOptions{}
+		if azureAuth.IdentityID != nil {
+			opts.ID = azidentity.ClientID(*azureAuth.IdentityID)
+		}
+		creds, err := azidentity.NewManagedIdentityCredential(opts)
+		if err != nil {
+			return "", err
+		}
+		token, err := creds.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{scope}})
+		if err != nil {
+			return "", err
+		}
+		return token.Token, nil
+	}
+
+	clientID, tenantID, token, err := c.azureWorkloadIdentity(ctx, azureAuth)
+	if err != nil {
+		return "", err
+	}
+	tp, err := keyvault.NewTokenProvider(ctx, token, clientID, tenantID, keyvault.AadEndpointForType(azureAuth.EnvironmentType), scope)
+	if err != nil {
+		return "", err
+	}
+	return tp.OAuthToken(), nil
+}
+
+// azureWorkloadIdentity returns the client ID, tenant ID and federated token of the workload identity.
+// Without serviceAccountRef the workload identity of the controller is used,
+// which the azure workload identity webhook provides as environment variables.
+func (c *client) azureWorkloadIdentity(ctx context.Context, azureAuth *esv1beta1.VaultAzureAuth) (string, string, string, error) {
+	if azureAuth.ServiceAccountRef == nil {
+		clientID := os.Getenv("AZURE_CLIENT_ID")
+		tenantID := os.Getenv("AZURE_TENANT_ID")
+		tokenFilePath := os.Getenv("AZURE_FEDERATED_TOKEN_FILE")
+		if clientID == "" || tenantID == "" || tokenFilePath == "" {
+			return "", "", "", errors.New(errAzureMissingWorkloadEnvVars)
+		}
+		token, err := os.ReadFile(tokenFilePath)
+		if err != nil {
+			return "", "", "", fmt.Errorf(errAzureReadTokenFile, tokenFilePath, err)
+		}
+		return clientID, tenantID, string(token), nil
+	}
+
+	saRef := azureAuth.ServiceAccountRef
+	ns := c.namespace
+	if c.storeKind == esv1beta1.ClusterSecretStoreKind && saRef.Namespace != nil {
+		ns = *saRef.Namespace
+	}
+	var sa corev1.ServiceAccount
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: saRef.Name, Namespace: ns}, &sa); err != nil {
+		return "", "", "", err
+	}
+	clientID := sa.Annotations[keyvault.AnnotationClientID]
+	if clientID == "" {
+		return "", "", "", fmt.Errorf(errAzureMissingClientID, keyvault.AnnotationClientID)
+	}
+	tenantID := sa.Annotations[keyvault.AnnotationTenantID]
+	if azureAuth.TenantID != nil {
+		tenantID = *azureAuth.TenantID
+	}
+	if tenantID == "" {
+		tenantID = os.Getenv("AZURE_TENANT_ID")
+	}
+	if tenantID == "" {
+		return "", "", "", fmt.Errorf(errAzureMissingTenantID, keyvault.AnnotationTenantID)
+	}
+	audiences := []string{keyvault.AzureDefaultAudience}
+	if len(saRef.Audiences) > 0 {
+		audiences = append(audiences, saRef.Audiences...)
+	}
+	token, err := keyvault.FetchSAToken(ctx, ns, saRef.Name, audiences, c.corev1)
+	if err != nil {
+		return "", "", "", err
+	}
+	return clientID, tenantID, token, nil
+}
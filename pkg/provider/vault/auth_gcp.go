@@ -0,0 +1,170 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	iamcredentials "google.golang.org/api/iamcredentials/v1"
+	"google.golang.org/api/option"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/provider/gcp/secretmanager"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	defaultGCPAuthMountPath = "gcp"
+	gcpSAAnnotation         = "iam.gke.io/gcp-service-account"
+	// gcpJWTExpiry is the lifetime of the signed JWT, Vault rejects JWTs valid for more than 15 minutes by default.
+	gcpJWTExpiry = 10 * time.Minute
+
+	errGCPNoServiceAccount = "unable to determine the GCP service account, set auth.gcp.serviceAccountEmail"
+	errGCPCredentials      = "unable to parse GCP service account key: %w"
+	errGCPSignJWT          = "unable to sign JWT as GCP service account %s: %w"
+)
+
+// gcpJWTSigner signs the JWT payload as the GCP service account using the credentials of the token source.
+type gcpJWTSigner func(ctx context.Context, ts oauth2.TokenSource, serviceAccount, payload string) (string, error)
+
+func setGCPAuthToken(ctx context.Context, v *client, signJWT gcpJWTSigner) (bool, error) {
+	gcpAuth := v.store.Auth.GCP
+	if gcpAuth != nil {
+		err := v.requestTokenWithGCPAuth(ctx, gcpAuth, signJWT)
+		if err != nil {
+			return true, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (c *client) requestTokenWithGCPAuth(ctx context.Context, gcpAuth *esv1beta1.VaultGCPAuth, signJWT gcpJWTSigner) error {
+	role := strings.TrimSpace(gcpAuth.Role)
+	path := defaultGCPAuthMountPath
+	if gcpAuth.Path != "" {
+		path = gcpAuth.Path
+	}
+	serviceAccount, err := c.gcpServiceAccountEmail(ctx, gcpAuth)
+	if err != nil {
+		return err
+	}
+	projectID := gcpAuth.ProjectID
+	if gcpAuth.WorkloadIdentity != nil && gcpAuth.WorkloadIdentity.ClusterProjectID != "" {
+		projectID = gcpAuth.WorkloadIdentity.ClusterProjectID
+	}
+	ts, err := secretmanager.NewTokenSource(ctx, esv1beta1.GCPSMAuth{
+		SecretRef:        gcpAuth.SecretRef,
+		WorkloadIdentity: gcpAuth.WorkloadIdentity,
+	}, projectID, c.storeKind, c.kube, c.namespace)
+	if err != nil {
+		return err
+	}
+
+	// https://developer.hashicorp.com/vault/docs/auth/gcp#iam-login
+	payload, err := json.Marshal(map[string]any{
+		"sub": serviceAccount,
+		"aud": fmt.Sprintf("vault/%s", role),
+		"exp": time.Now().Add(gcpJWTExpiry).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	jwt, err := signJWT(ctx, ts, serviceAccount, string(payload))
+	if err != nil {
+		return fmt.Errorf(errGCPSignJWT, serviceAccount, err)
+	}
+
+	parameters := map[string]any{
+		"role": role,
+		"jwt":  jwt,
+	}
+	url := strings.Join([]string{"auth", path, "login"}, "/")
+	vaultResult, err := c.logical.WriteWithContext(ctx, url, parameters)
+	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultWriteSecretData, err)
+	if err != nil {
+		return err
+	}
+
+	token, err := vaultResult.TokenID()
+	if err != nil {
+		return fmt.Errorf(errVaultToken, err)
+	}
+	c.client.SetToken(token)
+	return nil
+}
+
+// gcpServiceAccountEmail returns the GCP service account to log in as.
+func (c *client) gcpServiceAccountEmail(ctx context.Context, gcpAuth *esv1beta1.VaultGCPAuth) (string, error) {
+	if gcpAuth.ServiceAccountEmail != "" {
+		return gcpAuth.ServiceAccountEmail, nil
+	}
+	if gcpAuth.SecretRef != nil {
+		credentials, err := resolvers.SecretKeyRef(ctx, c.kube, c.storeKind, c.namespace, &gcpAuth.SecretRef.SecretAccessKey)
+		if err != nil {
+			return "", err
+		}
+		var key struct {
+			ClientEmail string `json:"client_email"`
+		}
+		if err := json.Unmarshal([]byte(credentials), &key); err != nil {
+			return "", fmt.Errorf(errGCPCredentials, err)
+		}
+		if key.ClientEmail != "" {
+			return key.ClientEmail, nil
+		}
+	}
+	if gcpAuth.WorkloadIdentity != nil {
+		saRef := gcpAuth.WorkloadIdentity.ServiceAccountRef
+		key := types.NamespacedName{Name: saRef.Name, Namespace: c.namespace}
+		if c.storeKind == esv1beta1.ClusterSecretStoreKind && saRef.Namespace != nil {
+			key.Namespace = *saRef.Namespace
+		}
+		var sa corev1.ServiceAccount
+		if err := c.kube.Get(ctx, key, &sa); err != nil {
+			return "", err
+		}
+		if email := sa.Annotations[gcpSAAnnotation]; email != "" {
+			return email, nil
+		}
+	}
+	return "", errors.New(errGCPNoServiceAccount)
+}
+
+// signGCPJWT signs the JWT payload using the signJwt method of the IAM Credentials API.
+func signGCPJWT(ctx context.Context, ts oauth2.TokenSource, serviceAccount, payload string) (string, error) {
+	svc, err := iamcredentials.NewService(ctx, option.WithTokenSource(ts))
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("projects/-/serviceAccounts/%s", serviceAccount)
+	resp, err := svc.Projects.ServiceAccounts.SignJwt(name, &iamcredentials.SignJwtRequest{
+		Payload: payload,
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	return resp.SignedJwt, nil
+}
@@ -16,10 +16,18 @@ package vault
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	vault "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -165,3 +173,138 @@ func TestSetAuthNamespace(t *testing.T) {
 		})
 	}
 }
+
+// newVaultStandIn returns a client of a local Vault stand-in which records the login requests.
+func newVaultStandIn(t *testing.T, logins map[string]map[string]any) *client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		logins[r.URL.Path] = body
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"auth": map[string]any{"client_token": "s.cloud"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	cfg := vault.DefaultConfig()
+	cfg.Address = srv.URL
+	vc, err := NewVaultClient(cfg)
+	require.NoError(t, err)
+	vc.SetToken("")
+	return &client{
+		client:    vc,
+		logical:   vc.Logical(),
+		namespace: "default",
+		storeKind: esv1beta1.SecretStoreKind,
+	}
+}
+
+func TestSetGCPAuthToken(t *testing.T) {
+	logins := map[string]map[string]any{}
+	c := newVaultStandIn(t, logins)
+	c.kube = clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcp-key", Namespace: "default"},
+		Data: map[string][]byte{
+			"key.json": []byte(`{"type":"service_account","client_email":"eso@project.iam.gserviceaccount.com"}`),
+		},
+	}).Build()
+	c.store = &esv1beta1.VaultProvider{
+		Auth: esv1beta1.VaultAuth{
+			GCP: &esv1beta1.VaultGCPAuth{
+				Role: "eso",
+				SecretRef: &esv1beta1.GCPSMAuthSecretRef{
+					SecretAccessKey: esmeta.SecretKeySelector{Name: "gcp-key", Key: "key.json"},
+				},
+			},
+		},
+	}
+
+	var payload map[string]any
+	signJWT := func(ctx context.Context, ts oauth2.TokenSource, serviceAccount, claims string) (string, error) {
+		assert.Equal(t, "eso@project.iam.gserviceaccount.com", serviceAccount)
+		require.NoError(t, json.Unmarshal([]byte(claims), &payload))
+		return "signed-jwt", nil
+	}
+	ok, err := setGCPAuthToken(context.Background(), c, signJWT)
+	require.True(t, ok)
+	require.NoError(t, err)
+	assert.Equal(t, "s.cloud", c.client.Token())
+	assert.Equal(t, "eso@project.iam.gserviceaccount.com", payload["sub"])
+	assert.Equal(t, "vault/eso", payload["aud"])
+	assert.Equal(t, map[string]any{"role": "eso", "jwt": "signed-jwt"}, logins["/v1/auth/gcp/login"])
+
+	// the service account can be set explicitly and the mount path changed
+	c.store.Auth.GCP.Path = "gcp-prod"
+	c.store.Auth.GCP.ServiceAccountEmail = "vault@project.iam.gserviceaccount.com"
+	signJWT = func(ctx context.Context, ts oauth2.TokenSource, serviceAccount, claims string) (string, error) {
+		assert.Equal(t, "vault@project.iam.gserviceaccount.com", serviceAccount)
+		return "", errors.New("permission denied")
+	}
+	ok, err = setGCPAuthToken(context.Background(), c, signJWT)
+	assert.True(t, ok)
+	assert.EqualError(t, err, "unable to sign JWT as GCP service account vault@project.iam.gserviceaccount.com: permission denied")
+	assert.NotContains(t, logins, "/v1/auth/gcp-prod/login")
+
+	// without GCP auth the next method is tried
+	c.store.Auth.GCP = nil
+	ok, err = setGCPAuthToken(context.Background(), c, signJWT)
+	assert.False(t, ok)
+	assert.NoError(t, err)
+}
+
+func TestSetAzureAuthToken(t *testing.T) {
+	logins := map[string]map[string]any{}
+	c := newVaultStandIn(t, logins)
+	c.store = &esv1beta1.VaultProvider{
+		Auth: esv1beta1.VaultAuth{
+			Azure: &esv1beta1.VaultAzureAuth{
+				Path:              "azure-aks",
+				Role:              "eso",
+				SubscriptionID:    "0000-1111",
+				ResourceGroupName: "aks",
+			},
+		},
+	}
+
+	fetchToken := func(ctx context.Context, c *client, azureAuth *esv1beta1.VaultAzureAuth) (string, error) {
+		return "access-token", nil
+	}
+	ok, err := setAzureAuthToken(context.Background(), c, fetchToken)
+	require.True(t, ok)
+	require.NoError(t, err)
+	assert.Equal(t, "s.cloud", c.client.Token())
+	assert.Equal(t, map[string]any{
+		"role":                "eso",
+		"jwt":                 "access-token",
+		"subscription_id":     "0000-1111",
+		"resource_group_name": "aks",
+	}, logins["/v1/auth/azure-aks/login"])
+
+	fetchToken = func(ctx context.Context, c *client, azureAuth *esv1beta1.VaultAzureAuth) (string, error) {
+		return "", errors.New("identity not found")
+	}
+	ok, err = setAzureAuthToken(context.Background(), c, fetchToken)
+	assert.True(t, ok)
+	assert.EqualError(t, err, "unable to fetch Azure access token: identity not found")
+}
+
+func TestAzureWorkloadIdentity(t *testing.T) {
+	c := &client{
+		namespace: "default",
+		storeKind: esv1beta1.SecretStoreKind,
+		kube: clientfake.NewClientBuilder().WithObjects(&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "eso", Namespace: "default"},
+		}).Build(),
+	}
+	_, _, _, err := c.azureWorkloadIdentity(context.Background(), &esv1beta1.VaultAzureAuth{
+		ServiceAccountRef: &esmeta.ServiceAccountSelector{Name: "eso"},
+	})
+	assert.EqualError(t, err, "missing clientID: service account annotation 'azure.workload.identity/client-id' is missing")
+
+	t.Setenv("AZURE_CLIENT_ID", "")
+	_, _, _, err = c.azureWorkloadIdentity(context.Background(), &esv1beta1.VaultAzureAuth{})
+	assert.EqualError(t, err, errAzureMissingWorkloadEnvVars)
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/go-logr/logr"
 	vault "github.com/hashicorp/vault/api"
@@ -31,6 +32,7 @@ import (
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/cache"
 	"github.com/external-secrets/external-secrets/pkg/provider/vault/util"
 	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
@@ -48,6 +50,15 @@ type client struct {
 	token     util.Token
 	namespace string
 	storeKind string
+	// tokenKey and tokenVersion identify the token of the store in the token manager, if enabled.
+	tokenKey     *cache.Key
+	tokenVersion string
+	// tokenManaged is true if the token is owned by the token manager and must not be revoked on close.
+	tokenManaged bool
+	// mountCASRequired caches whether the configuration of a KV v2 mount requires check-and-set,
+	// keyed by the path of the configuration.
+	mountCASMu       sync.Mutex
+	mountCASRequired map[string]bool
 }
 
 func (c *client) newConfig(ctx context.Context) (*vault.Config, error) {
@@ -184,8 +195,8 @@ func getCertFromConfigMap(v *client) ([]byte, error) {
 
 func (c *client) Close(ctx context.Context) error {
 	// Revoke the token if we have one set, it wasn't sourced from a TokenSecretRef,
-	// and token caching isn't enabled
-	if !enableCache && c.client.Token() != "" && c.store.Auth.TokenSecretRef == nil {
+	// and neither token caching nor the token manager own it
+	if !enableCache && !c.tokenManaged && c.client.Token() != "" && c.store.Auth.TokenSecretRef == nil {
 		err := revokeTokenIfValid(ctx, c.client)
 		if err != nil {
 			return err
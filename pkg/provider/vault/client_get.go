@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"strconv"
 	"strings"
 
 	"github.com/tidwall/gjson"
@@ -68,6 +70,11 @@ func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretData
 		if err != nil {
 			return nil, err
 		}
+		// The custom_metadata stored along the data of KV v1 secrets by PushSecret is not part of the secret value.
+		if _, ok := data["custom_metadata"]; ok && c.store.Version == esv1beta1.VaultKVStoreV1 && ref.Property == "" {
+			data = maps.Clone(data)
+			delete(data, "custom_metadata")
+		}
 	}
 
 	return getSecretValue(data, ref.Property)
@@ -118,6 +125,14 @@ func (c *client) SecretExists(ctx context.Context, ref esv1beta1.PushSecretRemot
 }
 
 func (c *client) readSecret(ctx context.Context, path, version string) (map[string]any, error) {
+	secretData, _, err := c.readSecretVersion(ctx, path, version)
+	return secretData, err
+}
+
+// readSecretVersion reads the secret like readSecret and additionally returns the KV v2 version of the data read.
+// The version of a deleted secret is returned together with a NoSecretError, it is 0 for KV v1
+// and for secrets which never existed.
+func (c *client) readSecretVersion(ctx context.Context, path, version string) (map[string]any, int64, error) {
 	dataPath := c.buildPath(path)
 
 	// path formated according to vault docs for v1 and v2 API
@@ -131,29 +146,36 @@ func (c *client) readSecret(ctx context.Context, path, version string) (map[stri
 	vaultSecret, err := c.logical.ReadWithDataWithContext(ctx, dataPath, params)
 	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultReadSecretData, err)
 	if err != nil {
-		return nil, fmt.Errorf(errReadSecret, err)
+		return nil, 0, fmt.Errorf(errReadSecret, err)
 	}
 	if vaultSecret == nil {
-		return nil, esv1beta1.NoSecretError{}
+		return nil, 0, esv1beta1.NoSecretError{}
 	}
 	secretData := vaultSecret.Data
+	var secretVersion int64
 	if c.store.Version == esv1beta1.VaultKVStoreV2 {
+		if metadata, ok := vaultSecret.Data["metadata"].(map[string]any); ok && metadata["version"] != nil {
+			secretVersion, err = strconv.ParseInt(fmt.Sprint(metadata["version"]), 10, 64)
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid version %v of secret: %w", metadata["version"], err)
+			}
+		}
 		// Vault KV2 has data embedded within sub-field
 		// reference - https://www.vaultproject.io/api/secret/kv/kv-v2#read-secret-version
 		dataInt, ok := vaultSecret.Data["data"]
 		if !ok {
-			return nil, errors.New(errDataField)
+			return nil, 0, errors.New(errDataField)
 		}
 		if dataInt == nil {
-			return nil, esv1beta1.NoSecretError{}
+			return nil, secretVersion, esv1beta1.NoSecretError{}
 		}
 		secretData, ok = dataInt.(map[string]any)
 		if !ok {
-			return nil, errors.New(errJSONUnmarshall)
+			return nil, 0, errors.New(errJSONUnmarshall)
 		}
 	}
 
-	return secretData, nil
+	return secretData, secretVersion, nil
 }
 
 func getSecretValue(data map[string]any, property string) ([]byte, error) {
@@ -185,7 +207,19 @@ func getSecretValue(data map[string]any, property string) ([]byte, error) {
 }
 
 func (c *client) readSecretMetadata(ctx context.Context, path string) (map[string]string, error) {
-	metadata := make(map[string]string)
+	data, err := c.readMetadata(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New(errNotFound)
+	}
+	return getCustomMetadata(data), nil
+}
+
+// readMetadata reads the metadata of the secret. It returns nil if the secret does not exist.
+// For KV v1 the metadata is part of the secret data.
+func (c *client) readMetadata(ctx context.Context, path string) (map[string]any, error) {
 	url, err := c.buildMetadataPath(path)
 	if err != nil {
 		return nil, err
@@ -196,20 +230,26 @@ func (c *client) readSecretMetadata(ctx context.Context, path string) (map[strin
 		return nil, fmt.Errorf(errReadSecret, err)
 	}
 	if secret == nil {
-		return nil, errors.New(errNotFound)
+		return nil, nil
 	}
-	t, ok := secret.Data["custom_metadata"]
+	return secret.Data, nil
+}
+
+// getCustomMetadata returns the custom_metadata of the metadata read by readMetadata.
+func getCustomMetadata(data map[string]any) map[string]string {
+	t, ok := data["custom_metadata"]
 	if !ok {
-		return nil, nil
+		return nil
 	}
 	d, ok := t.(map[string]any)
 	if !ok {
-		return metadata, nil
+		return map[string]string{}
 	}
+	metadata := make(map[string]string, len(d))
 	for k, v := range d {
 		metadata[k] = v.(string)
 	}
-	return metadata, nil
+	return metadata
 }
 
 func (c *client) buildMetadataPath(path string) (string, error) {
@@ -20,7 +20,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
+	vault "github.com/hashicorp/vault/api"
 	corev1 "k8s.io/api/core/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
@@ -29,30 +32,26 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
+const errCheckAndSetConflict = "secret %s was changed since version %d was read, not overwriting it"
+
 func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
-	var (
-		value []byte
-		err   error
-	)
-	key := data.GetSecretKey()
-	if key == "" {
-		// Must convert secret values to string, otherwise data will be sent as base64 to Vault
-		secretStringVal := make(map[string]string)
-		for k, v := range secret.Data {
-			secretStringVal[k] = string(v)
-		}
-		value, err = utils.JSONMarshal(secretStringVal)
-		if err != nil {
-			return fmt.Errorf("failed to serialize secret content as JSON: %w", err)
-		}
-	} else {
-		value = secret.Data[key]
+	// Secret values are converted to string, otherwise data will be sent as base64 to Vault
+	value, err := utils.PushSecretValue(secret, data)
+	if err != nil {
+		return err
+	}
+	customMetadata := map[string]string{
+		"managed-by": "external-secrets",
+	}
+	owner, _ := utils.PushSecretOwner(data)
+	if owner != "" {
+		customMetadata[utils.RemoteOwnerKey] = owner
 	}
 	label := map[string]any{
-		"custom_metadata": map[string]string{
-			"managed-by": "external-secrets",
-		},
+		"custom_metadata": customMetadata,
 	}
+	// claim is set if the ownership marker of an existing secret has to be updated.
+	claim := false
 	secretVal := make(map[string]any)
 	path := c.buildPath(data.GetRemoteKey())
 	metaPath, err := c.buildMetadataPath(data.GetRemoteKey())
@@ -61,21 +60,42 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 	}
 
 	// Retrieve the secret map from vault and convert the secret value in string form.
-	vaultSecret, err := c.readSecret(ctx, path, "")
+	// The version of the data read is the one check-and-set writes are based on.
+	vaultSecret, casVersion, err := c.readSecretVersion(ctx, path, "")
 	// If error is not of type secret not found, we should error
 	if err != nil && !errors.Is(err, esv1beta1.NoSecretError{}) {
 		return err
 	}
-	// If the secret exists (err == nil), we should check if it is managed by external-secrets
-	if err == nil {
-		metadata, err := c.readSecretMetadata(ctx, data.GetRemoteKey())
+	exists := err == nil
+	var rawMetadata map[string]any
+	// If the secret exists, we should check if it is managed by external-secrets
+	if exists {
+		rawMetadata, err = c.readMetadata(ctx, data.GetRemoteKey())
 		if err != nil {
 			return err
 		}
+		if rawMetadata == nil {
+			return errors.New(errNotFound)
+		}
+		metadata := getCustomMetadata(rawMetadata)
 		manager, ok := metadata["managed-by"]
 		if !ok || manager != "external-secrets" {
-			return fmt.Errorf("secret not managed by external-secrets")
+			adoptUnmanaged, err := utils.AdoptUnmanaged(data)
+			if err != nil {
+				return fmt.Errorf("failed to parse metadata: %w", err)
+			}
+			if !adoptUnmanaged {
+				return fmt.Errorf("secret not managed by external-secrets")
+			}
+		}
+		if err := utils.CheckRemoteOwner(data, data.GetRemoteKey(), metadata[utils.RemoteOwnerKey]); err != nil {
+			return err
 		}
+		claim = manager != "external-secrets" || (owner != "" && metadata[utils.RemoteOwnerKey] != owner)
+	}
+	useCAS, err := c.checkAndSetRequired(ctx, data.GetRemoteKey(), rawMetadata)
+	if err != nil {
+		return err
 	}
 	// Remove the metadata map to check the reconcile difference
 	if c.store.Version == esv1beta1.VaultKVStoreV1 {
@@ -92,7 +112,7 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 	if err != nil {
 		return fmt.Errorf("error marshaling vault secret: %w", err)
 	}
-	if bytes.Equal(vaultSecretValue, value) {
+	if bytes.Equal(vaultSecretValue, value) && !claim {
 		return nil
 	}
 	// If a Push of a property only, we should merge and add/update the property
@@ -103,7 +123,7 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 				return fmt.Errorf("error marshaling vault secret: %w", err)
 			}
 			// If the property has the same value, don't update the secret
-			if bytes.Equal([]byte(d), value) {
+			if bytes.Equal([]byte(d), value) && !claim {
 				return nil
 			}
 		}
@@ -127,6 +147,9 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 		secretToPush = map[string]any{
 			"data": secretVal,
 		}
+		if useCAS {
+			secretToPush["options"] = map[string]any{"cas": casVersion}
+		}
 	}
 	if err != nil {
 		return fmt.Errorf("failed to convert value to a valid JSON: %w", err)
@@ -140,9 +163,19 @@ func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv
 		}
 	}
 	// Otherwise, create or update the version.
-	_, err = c.logical.WriteWithContext(ctx, path, secretToPush)
+	written, err := c.logical.WriteWithContext(ctx, path, secretToPush)
 	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultWriteSecretData, err)
-	return err
+	if useCAS && isCheckAndSetMismatch(err) {
+		return fmt.Errorf(errCheckAndSetConflict, data.GetRemoteKey(), casVersion)
+	}
+	if err != nil {
+		return err
+	}
+	// KV v2 returns the version of the written secret.
+	if written != nil && written.Data["version"] != nil {
+		utils.SetRemoteVersion(data, fmt.Sprint(written.Data["version"]))
+	}
+	return nil
 }
 
 func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
@@ -152,7 +185,7 @@ func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecre
 		return err
 	}
 	// Retrieve the secret map from vault and convert the secret value in string form.
-	secretVal, err := c.readSecret(ctx, path, "")
+	secretVal, casVersion, err := c.readSecretVersion(ctx, path, "")
 	// If error is not of type secret not found, we should error
 	if err != nil && errors.Is(err, esv1beta1.NoSecretError{}) {
 		return nil
@@ -160,14 +193,21 @@ func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecre
 	if err != nil {
 		return err
 	}
-	metadata, err := c.readSecretMetadata(ctx, remoteRef.GetRemoteKey())
+	rawMetadata, err := c.readMetadata(ctx, remoteRef.GetRemoteKey())
 	if err != nil {
 		return err
 	}
+	if rawMetadata == nil {
+		return errors.New(errNotFound)
+	}
+	metadata := getCustomMetadata(rawMetadata)
 	manager, ok := metadata["managed-by"]
 	if !ok || manager != "external-secrets" {
 		return nil
 	}
+	if !utils.IsRemoteOwner(remoteRef, metadata[utils.RemoteOwnerKey]) {
+		return nil
+	}
 	// If Push for a Property, we need to delete the property and update the secret
 	if remoteRef.GetProperty() != "" {
 		delete(secretVal, remoteRef.GetProperty())
@@ -176,14 +216,24 @@ func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecre
 			delete(secretVal, "custom_metadata")
 		}
 		if len(secretVal) > 0 {
+			useCAS, err := c.checkAndSetRequired(ctx, remoteRef.GetRemoteKey(), rawMetadata)
+			if err != nil {
+				return err
+			}
 			secretToPush := secretVal
 			if c.store.Version == esv1beta1.VaultKVStoreV2 {
 				secretToPush = map[string]any{
 					"data": secretVal,
 				}
+				if useCAS {
+					secretToPush["options"] = map[string]any{"cas": casVersion}
+				}
 			}
 			_, err = c.logical.WriteWithContext(ctx, path, secretToPush)
 			metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultDeleteSecret, err)
+			if useCAS && isCheckAndSetMismatch(err) {
+				return fmt.Errorf(errCheckAndSetConflict, remoteRef.GetRemoteKey(), casVersion)
+			}
 			return err
 		}
 	}
@@ -201,3 +251,80 @@ func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecre
 	}
 	return nil
 }
+
+// checkAndSetRequired returns true if a write of the secret with the given metadata has to use check-and-set,
+// because the store, the secret or the configuration of its KV v2 mount require it.
+// The configuration of a mount is read once per client.
+// If the configuration of the mount may not be read, writes to mounts requiring check-and-set are rejected by Vault.
+func (c *client) checkAndSetRequired(ctx context.Context, key string, metadata map[string]any) (bool, error) {
+	if c.store.Version != esv1beta1.VaultKVStoreV2 {
+		return false, nil
+	}
+	if c.store.CheckAndSet != nil && c.store.CheckAndSet.Required {
+		return true, nil
+	}
+	if required, _ := metadata["cas_required"].(bool); required {
+		return true, nil
+	}
+	configPath := c.buildConfigPath(key)
+	c.mountCASMu.Lock()
+	defer c.mountCASMu.Unlock()
+	if required, ok := c.mountCASRequired[configPath]; ok {
+		return required, nil
+	}
+	required, err := c.readMountCASRequired(ctx, configPath)
+	if err != nil {
+		return false, err
+	}
+	if c.mountCASRequired == nil {
+		c.mountCASRequired = make(map[string]bool)
+	}
+	c.mountCASRequired[configPath] = required
+	return required, nil
+}
+
+// readMountCASRequired reads whether the configuration of a KV v2 mount requires check-and-set.
+func (c *client) readMountCASRequired(ctx context.Context, configPath string) (bool, error) {
+	config, err := c.logical.ReadWithDataWithContext(ctx, configPath, nil)
+	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultReadSecretData, err)
+	var respErr *vault.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot read the configuration of the secret engine: %w", err)
+	}
+	if config == nil {
+		return false, nil
+	}
+	required, _ := config.Data["cas_required"].(bool)
+	return required, nil
+}
+
+// buildConfigPath returns the path of the configuration of the KV v2 mount the secret is stored in.
+// Without a path in the store, the mount is taken from the key like in buildPath.
+func (c *client) buildConfigPath(key string) string {
+	if c.store.Path != nil {
+		return *c.store.Path + "/config"
+	}
+	mount, _, found := strings.Cut(key, "/data/")
+	if !found {
+		mount, _, _ = strings.Cut(key, "/")
+	}
+	return mount + "/config"
+}
+
+// isCheckAndSetMismatch returns true if Vault rejected a write because the secret was changed
+// since the version passed as check-and-set parameter.
+func isCheckAndSetMismatch(err error) bool {
+	var respErr *vault.ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+	for _, msg := range respErr.Errors {
+		if strings.Contains(msg, "check-and-set parameter did not match the current version") {
+			return true
+		}
+	}
+	return false
+}
@@ -16,12 +16,16 @@ package vault
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
+	vault "github.com/hashicorp/vault/api"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
@@ -34,6 +38,7 @@ const (
 	fakeValue    = "fake-value"
 	managedBy    = "managed-by"
 	managedByESO = "external-secrets"
+	ownedBy      = "owned-by"
 )
 
 func TestDeleteSecret(t *testing.T) {
@@ -148,6 +153,29 @@ func TestDeleteSecret(t *testing.T) {
 				err: nil,
 			},
 		},
+		"DeleteSecretOwnedByOtherKV2": {
+			reason: "delete v2 secret is a no-op if it is owned by another PushSecret",
+			ref:    &testingfake.PushSecretData{RemoteKey: "secret", Owner: "ns/ps"},
+			args: args{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault,
+				vLogical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(map[string]any{
+						"data": map[string]any{
+							fakeKey: fakeValue,
+						},
+						"custom_metadata": map[string]any{
+							managedBy: managedByESO,
+							ownedBy:   "ns/other",
+						},
+					}, nil),
+					WriteWithContextFn:  fake.ExpectWriteWithContextNoCall(),
+					DeleteWithContextFn: fake.ExpectDeleteWithContextNoCall(),
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"DeleteSecretSuccessKV1": {
 			reason: "delete secret succeeds if secret is managed by ESO and exists in vault v1",
 			args: args{
@@ -348,13 +376,15 @@ func TestDeleteSecret(t *testing.T) {
 func TestPushSecret(t *testing.T) {
 	secretKey := "secret-key"
 	noPermission := errors.New("no permission")
+	adoptUnmanaged := &apiextensionsv1.JSON{Raw: []byte(`{"adoptUnmanaged": true}`)}
 	type args struct {
 		store    *esv1beta1.VaultProvider
 		vLogical util.Logical
 	}
 
 	type want struct {
-		err error
+		err     error
+		version string
 	}
 	tests := map[string]struct {
 		reason string
@@ -390,6 +420,20 @@ func TestPushSecret(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SetSecretReportsVersionKV2": {
+			reason: "the version of the written secret is reported",
+			args: args{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault,
+				vLogical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(nil, nil),
+					WriteWithContextFn:        fake.NewWriteWithContextFn(map[string]any{"version": json.Number("3")}, nil),
+				},
+			},
+			want: want{
+				err:     nil,
+				version: "3",
+			},
+		},
 		"SetSecretWithWriteErrorKV1": {
 			reason: "secret cannot be pushed if write fails",
 			args: args{
@@ -649,6 +693,95 @@ func TestPushSecret(t *testing.T) {
 				err: errors.New("secret not managed by external-secrets"),
 			},
 		},
+		"SetSecretOwnedByOtherKV1": {
+			reason: "a secret owned by another PushSecret cannot be updated",
+			data:   &testingfake.PushSecretData{SecretKey: secretKey, RemoteKey: "secret", Owner: "ns/ps"},
+			args: args{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV1).Spec.Provider.Vault,
+				vLogical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(map[string]any{
+						fakeKey: "fake-value2",
+						"custom_metadata": map[string]any{
+							managedBy: managedByESO,
+							ownedBy:   "ns/other",
+						},
+					}, nil),
+					WriteWithContextFn: fake.ExpectWriteWithContextNoCall(),
+				},
+			},
+			want: want{
+				err: errors.New("remote secret secret is owned by ns/other"),
+			},
+		},
+		"SetSecretAdoptsSecretOfOtherOwnerKV1": {
+			reason: "a secret owned by another PushSecret is adopted even if its value is unchanged",
+			data:   &testingfake.PushSecretData{SecretKey: secretKey, RemoteKey: "secret", Owner: "ns/ps", Adopt: true},
+			args: args{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV1).Spec.Provider.Vault,
+				vLogical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(map[string]any{
+						fakeKey: fakeValue,
+						"custom_metadata": map[string]any{
+							managedBy: managedByESO,
+							ownedBy:   "ns/other",
+						},
+					}, nil),
+					WriteWithContextFn: fake.ExpectWriteWithContextValue(map[string]any{
+						fakeKey: fakeValue,
+						"custom_metadata": map[string]string{
+							managedBy: managedByESO,
+							ownedBy:   "ns/ps",
+						},
+					}),
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SetSecretDoesNotAdoptSecretNotManagedByESOV1": {
+			reason: "a secret not managed by ESO is not adopted without opt-in",
+			data:   &testingfake.PushSecretData{SecretKey: secretKey, RemoteKey: "secret", Owner: "ns/ps", Adopt: true},
+			args: args{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV1).Spec.Provider.Vault,
+				vLogical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(map[string]any{
+						fakeKey: "fake-value2",
+						"custom_metadata": map[string]any{
+							managedBy: "not-external-secrets",
+						},
+					}, nil),
+				},
+			},
+			want: want{
+				err: errors.New("secret not managed by external-secrets"),
+			},
+		},
+		"SetSecretAdoptsSecretNotManagedByESOV1": {
+			reason: "a secret not managed by ESO is adopted with opt-in",
+			data:   &testingfake.PushSecretData{SecretKey: secretKey, RemoteKey: "secret", Owner: "ns/ps", Adopt: true, Metadata: adoptUnmanaged},
+			args: args{
+				store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV1).Spec.Provider.Vault,
+				vLogical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(map[string]any{
+						fakeKey: "fake-value2",
+						"custom_metadata": map[string]any{
+							managedBy: "not-external-secrets",
+						},
+					}, nil),
+					WriteWithContextFn: fake.ExpectWriteWithContextValue(map[string]any{
+						fakeKey: fakeValue,
+						"custom_metadata": map[string]string{
+							managedBy: managedByESO,
+							ownedBy:   "ns/ps",
+						},
+					}),
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"WholeSecretKV2": {
 			reason: "secret is successfully set, with no existing vault secret",
 			args: args{
@@ -672,6 +805,8 @@ func TestPushSecret(t *testing.T) {
 			if tc.data != nil {
 				data = *tc.data
 			}
+			var version string
+			data.Version = &version
 			client := &client{
 				logical: tc.args.vLogical,
 				store:   tc.args.store,
@@ -697,6 +832,199 @@ func TestPushSecret(t *testing.T) {
 					t.Errorf("\nTesting SetSecret:\nName: %v\nReason: %v\nWant error: %v\nGot error got nil", name, tc.reason, tc.want.err)
 				}
 			}
+
+			if version != tc.want.version {
+				t.Errorf("\nTesting SetSecret:\nName: %v\nReason: %v\nWant version: %v\nGot version: %v", name, tc.reason, tc.want.version, version)
+			}
+		})
+	}
+}
+
+func TestPushSecretCheckAndSet(t *testing.T) {
+	secretKey := "secret-key"
+	casMismatch := &vault.ResponseError{
+		StatusCode: 400,
+		Errors:     []string{"check-and-set parameter did not match the current version"},
+	}
+	managedMetadata := map[string]any{managedBy: managedByESO}
+	version := func(v string) map[string]any {
+		return map[string]any{"version": json.Number(v)}
+	}
+	tests := map[string]struct {
+		reason      string
+		checkAndSet *esv1beta1.VaultCheckAndSet
+		data        map[string]any
+		metadata    map[string]any
+		config      map[string]any
+		configErr   error
+		writeErr    error
+		wantOptions map[string]any
+		wantErr     string
+	}{
+		"NoCheckAndSet": {
+			reason:   "secrets are written without check-and-set by default",
+			data:     map[string]any{"data": map[string]any{fakeKey: "old"}, "metadata": version("3")},
+			metadata: map[string]any{"current_version": json.Number("3"), "custom_metadata": managedMetadata},
+		},
+		"RequiredByStore": {
+			reason:      "the version read is passed as check-and-set parameter",
+			checkAndSet: &esv1beta1.VaultCheckAndSet{Required: true},
+			data:        map[string]any{"data": map[string]any{fakeKey: "old"}, "metadata": version("3")},
+			metadata:    map[string]any{"current_version": json.Number("3"), "custom_metadata": managedMetadata},
+			wantOptions: map[string]any{"cas": int64(3)},
+		},
+		"VersionOfDataRead": {
+			reason:      "the version of the data read is used even if the secret was changed since",
+			checkAndSet: &esv1beta1.VaultCheckAndSet{Required: true},
+			data:        map[string]any{"data": map[string]any{fakeKey: "old"}, "metadata": version("3")},
+			metadata:    map[string]any{"current_version": json.Number("4"), "custom_metadata": managedMetadata},
+			wantOptions: map[string]any{"cas": int64(3)},
+		},
+		"RequiredBySecret": {
+			reason:      "secrets with cas_required are written with check-and-set",
+			data:        map[string]any{"data": map[string]any{fakeKey: "old"}, "metadata": version("7")},
+			metadata:    map[string]any{"current_version": json.Number("7"), "cas_required": true, "custom_metadata": managedMetadata},
+			wantOptions: map[string]any{"cas": int64(7)},
+		},
+		"RequiredByMount": {
+			reason:      "secrets in mounts with cas_required are written with check-and-set",
+			data:        map[string]any{"data": map[string]any{fakeKey: "old"}, "metadata": version("5")},
+			metadata:    map[string]any{"current_version": json.Number("5"), "custom_metadata": managedMetadata},
+			config:      map[string]any{"cas_required": true, "max_versions": json.Number("0")},
+			wantOptions: map[string]any{"cas": int64(5)},
+		},
+		"MountConfigForbidden": {
+			reason:    "secrets are written without check-and-set if the mount configuration may not be read",
+			data:      map[string]any{"data": map[string]any{fakeKey: "old"}, "metadata": version("5")},
+			metadata:  map[string]any{"current_version": json.Number("5"), "custom_metadata": managedMetadata},
+			configErr: &vault.ResponseError{StatusCode: 403, Errors: []string{"permission denied"}},
+		},
+		"NewSecret": {
+			reason:      "new secrets are written with version 0",
+			checkAndSet: &esv1beta1.VaultCheckAndSet{Required: true},
+			wantOptions: map[string]any{"cas": int64(0)},
+		},
+		"DeletedSecret": {
+			reason:      "deleted secrets are written with their current version",
+			checkAndSet: &esv1beta1.VaultCheckAndSet{Required: true},
+			data:        map[string]any{"data": nil, "metadata": version("4")},
+			wantOptions: map[string]any{"cas": int64(4)},
+		},
+		"Conflict": {
+			reason:      "a concurrent change is reported as conflict",
+			checkAndSet: &esv1beta1.VaultCheckAndSet{Required: true},
+			data:        map[string]any{"data": map[string]any{fakeKey: "old"}, "metadata": version("3")},
+			metadata:    map[string]any{"current_version": json.Number("3"), "custom_metadata": managedMetadata},
+			writeErr:    casMismatch,
+			wantOptions: map[string]any{"cas": int64(3)},
+			wantErr:     "secret secret was changed since version 3 was read, not overwriting it",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var written map[string]any
+			store := makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault
+			store.CheckAndSet = tc.checkAndSet
+			client := &client{
+				store: store,
+				logical: &fake.Logical{
+					ReadWithDataWithContextFn: func(_ context.Context, path string, _ map[string][]string) (*vault.Secret, error) {
+						if strings.Contains(path, "/metadata/") {
+							if tc.metadata == nil {
+								return nil, nil
+							}
+							return &vault.Secret{Data: tc.metadata}, nil
+						}
+						if strings.HasSuffix(path, "/config") {
+							if tc.config == nil {
+								return nil, tc.configErr
+							}
+							return &vault.Secret{Data: tc.config}, nil
+						}
+						if tc.data == nil {
+							return nil, nil
+						}
+						return &vault.Secret{Data: tc.data}, nil
+					},
+					WriteWithContextFn: func(_ context.Context, path string, data map[string]any) (*vault.Secret, error) {
+						if strings.Contains(path, "/metadata/") {
+							return nil, nil
+						}
+						written = data
+						return nil, tc.writeErr
+					},
+				},
+			}
+			secret := &corev1.Secret{Data: map[string][]byte{secretKey: []byte(`{"fake-key":"fake-value"}`)}}
+			err := client.PushSecret(context.Background(), secret, testingfake.PushSecretData{SecretKey: secretKey, RemoteKey: "secret"})
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("%s: want error %q, got %v", tc.reason, tc.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.reason, err)
+			}
+			if written == nil {
+				t.Fatalf("%s: secret was not written", tc.reason)
+			}
+			options, _ := written["options"].(map[string]any)
+			if tc.wantOptions == nil && options != nil || tc.wantOptions != nil && !reflect.DeepEqual(tc.wantOptions, options) {
+				t.Errorf("%s: want options %v, got %v", tc.reason, tc.wantOptions, options)
+			}
 		})
 	}
 }
+
+func TestBuildConfigPath(t *testing.T) {
+	path := "kv/team"
+	tests := map[string]struct {
+		path *string
+		key  string
+		want string
+	}{
+		"StorePath":          {path: &path, key: "app/db", want: "kv/team/config"},
+		"MountFromKey":       {key: "secret/app/db", want: "secret/config"},
+		"MountFromSingleKey": {key: "secret", want: "secret/config"},
+		"MountBeforeData":    {key: "kv/team/data/app/db", want: "kv/team/config"},
+		"KeyStartingWithData": {
+			key:  "secret/database/db",
+			want: "secret/config",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault
+			store.Path = tc.path
+			c := &client{store: store}
+			if got := c.buildConfigPath(tc.key); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckAndSetRequiredReadsMountConfigOnce(t *testing.T) {
+	reads := 0
+	store := makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault
+	c := &client{
+		store: store,
+		logical: &fake.Logical{
+			ReadWithDataWithContextFn: func(_ context.Context, path string, _ map[string][]string) (*vault.Secret, error) {
+				reads++
+				return &vault.Secret{Data: map[string]any{"cas_required": true}}, nil
+			},
+		},
+	}
+	for _, key := range []string{"app/db", "app/api"} {
+		required, err := c.checkAndSetRequired(context.Background(), key, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !required {
+			t.Errorf("%s: want check-and-set to be required", key)
+		}
+	}
+	if reads != 1 {
+		t.Errorf("want the mount configuration to be read once, got %d reads", reads)
+	}
+}
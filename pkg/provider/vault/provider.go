@@ -82,6 +82,26 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// Features returns the optional features of the store.
+// Find operations, versions and metadata require the KV secrets engine v2.
+func (p *Provider) Features(store esv1beta1.GenericStore) esv1beta1.SecretStoreFeatures {
+	features := esv1beta1.SecretStoreFeatures{
+		PushWholeSecret: true,
+		DeleteSecret:    true,
+		SecretExists:    true,
+	}
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil || spec.Provider.Vault == nil || spec.Provider.Vault.Version == esv1beta1.VaultKVStoreV1 {
+		return features
+	}
+	features.FindByName = true
+	features.FindByTags = true
+	features.FindByPath = true
+	features.Versions = true
+	features.MetadataFetch = true
+	return features
+}
+
 // NewClient implements the Client interface.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	// controller-runtime/client does not support TokenRequest or other subresource APIs
@@ -98,23 +118,33 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 	return p.newClient(ctx, store, kube, clientset.CoreV1(), namespace)
 }
 
-func (p *Provider) NewGeneratorClient(ctx context.Context, kube kclient.Client, corev1 typedcorev1.CoreV1Interface, vaultSpec *esv1beta1.VaultProvider, namespace string) (util.Client, error) {
+// NewGeneratorClient returns a client logged in to Vault and a function which revokes the token of the login.
+// Vault revokes the leases created with a token together with the token, so the token must not be revoked
+// while the leases it created are in use.
+func (p *Provider) NewGeneratorClient(ctx context.Context, kube kclient.Client, corev1 typedcorev1.CoreV1Interface, vaultSpec *esv1beta1.VaultProvider, namespace string) (util.Client, func(context.Context) error, error) {
 	vStore, cfg, err := p.prepareConfig(ctx, kube, corev1, vaultSpec, nil, namespace, resolvers.EmptyStoreKind)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	client, err := p.NewVaultClient(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	_, err = p.initClient(ctx, vStore, client, cfg, vaultSpec)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return client, nil
+	revoke := func(ctx context.Context) error {
+		// static tokens are not revoked
+		if vaultSpec.Auth.TokenSecretRef != nil || client.Token() == "" {
+			return nil
+		}
+		return revokeTokenIfValid(ctx, client)
+	}
+	return client, revoke, nil
 }
 
 func (p *Provider) newClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, corev1 typedcorev1.CoreV1Interface, namespace string) (esv1beta1.SecretsClient, error) {
@@ -141,6 +171,17 @@ func (p *Provider) newClient(ctx context.Context, store esv1beta1.GenericStore,
 		return nil, fmt.Errorf(errVaultClient, err)
 	}
 
+	// static tokens are neither renewed nor revoked
+	if tokens != nil && vaultSpec.Auth.TokenSecretRef == nil {
+		version, err := tokenVersion(vaultSpec)
+		if err != nil {
+			return nil, err
+		}
+		key := tokenKey(store, namespace)
+		vStore.tokenKey = &key
+		vStore.tokenVersion = version
+	}
+
 	return p.initClient(ctx, vStore, client, cfg, vaultSpec)
 }
 
@@ -162,6 +203,12 @@ func (p *Provider) initClient(ctx context.Context, c *client, client util.Client
 	if c.storeKind == esv1beta1.ClusterSecretStoreKind && c.namespace == "" && isReferentSpec(vaultSpec) {
 		return c, nil
 	}
+	if c.tokenKey != nil {
+		if err := c.setManagedAuth(ctx, cfg, *c.tokenKey, c.tokenVersion); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
 	if err := c.setAuth(ctx, cfg); err != nil {
 		return nil, err
 	}
@@ -208,12 +255,13 @@ func (p *Provider) prepareConfig(ctx context.Context, kube kclient.Client, corev
 
 func getVaultClient(p *Provider, store esv1beta1.GenericStore, cfg *vault.Config) (util.Client, error) {
 	isStaticToken := store.GetSpec().Provider.Vault.Auth.TokenSecretRef != nil
-	useCache := enableCache && !isStaticToken
+	// the token manager shares tokens instead of clients
+	useCache := enableCache && tokens == nil && !isStaticToken
 
 	key := cache.Key{
 		Name:      store.GetObjectMeta().Name,
 		Namespace: store.GetObjectMeta().Namespace,
-		Kind:      store.GetTypeMeta().Kind,
+		Kind:      store.GetKind(),
 	}
 	if useCache {
 		client, ok := clientCache.Get(store.GetObjectMeta().ResourceVersion, key)
@@ -264,6 +312,15 @@ func isReferentSpec(prov *esv1beta1.VaultProvider) bool {
 	if prov.Auth.Iam != nil && prov.Auth.Iam.JWTAuth != nil && prov.Auth.Iam.JWTAuth.ServiceAccountRef != nil && prov.Auth.Iam.JWTAuth.ServiceAccountRef.Namespace == nil {
 		return true
 	}
+	if prov.Auth.GCP != nil && prov.Auth.GCP.SecretRef != nil && prov.Auth.GCP.SecretRef.SecretAccessKey.Namespace == nil {
+		return true
+	}
+	if prov.Auth.GCP != nil && prov.Auth.GCP.WorkloadIdentity != nil && prov.Auth.GCP.WorkloadIdentity.ServiceAccountRef.Namespace == nil {
+		return true
+	}
+	if prov.Auth.Azure != nil && prov.Auth.Azure.ServiceAccountRef != nil && prov.Auth.Azure.ServiceAccountRef.Namespace == nil {
+		return true
+	}
 	if prov.Auth.Iam != nil && prov.Auth.Iam.SecretRef != nil &&
 		(prov.Auth.Iam.SecretRef.AccessKeyID.Namespace == nil ||
 			prov.Auth.Iam.SecretRef.SecretAccessKey.Namespace == nil ||
@@ -274,12 +331,22 @@ func isReferentSpec(prov *esv1beta1.VaultProvider) bool {
 }
 
 func init() {
-	var vaultTokenCacheSize int
+	var (
+		vaultTokenCacheSize   int
+		enableTokenManager    bool
+		vaultTokenManagerSize int
+	)
 	fs := pflag.NewFlagSet("vault", pflag.ExitOnError)
 	fs.BoolVar(&enableCache, "experimental-enable-vault-token-cache", false, "Enable experimental Vault token cache. External secrets will reuse the Vault token without creating a new one on each request.")
 	// max. 265k vault leases with 30bytes each ~= 7MB
 	fs.IntVar(&vaultTokenCacheSize, "experimental-vault-token-cache-size", 2<<17, "Maximum size of Vault token cache. When more tokens than Only used if --experimental-enable-vault-token-cache is set.")
+	fs.BoolVar(&enableTokenManager, "enable-vault-token-manager", false, "Enable the Vault token manager. External secrets will share one Vault token per store, renew it in the background and only log in again if it can not be renewed. Takes precedence over --experimental-enable-vault-token-cache.")
+	fs.IntVar(&vaultTokenManagerSize, "vault-token-manager-size", 2<<12, "Maximum number of tokens held by the Vault token manager. Only used if --enable-vault-token-manager is set.")
 	lateInit := func() {
+		if enableTokenManager {
+			logger.Info("initializing vault token manager", "size", vaultTokenManagerSize)
+			tokens = newTokenManager(vaultTokenManagerSize, NewVaultClient)
+		}
 		logger.Info("initializing vault cache", "size", vaultTokenCacheSize)
 		clientCache = cache.Must(vaultTokenCacheSize, func(client util.Client) {
 			err := revokeTokenIfValid(context.Background(), client)
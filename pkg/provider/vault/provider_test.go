@@ -707,3 +707,24 @@ func vaultTest(t *testing.T, _ string, tc testCase) {
 		t.Errorf("\n%s\nvault.New(...): -want error, +got error:\n%s", tc.reason, diff)
 	}
 }
+
+func TestFeatures(t *testing.T) {
+	p := &Provider{}
+	makeStore := func(version esv1beta1.VaultKVStoreVersion) esv1beta1.GenericStore {
+		return &esv1beta1.SecretStore{
+			Spec: esv1beta1.SecretStoreSpec{
+				Provider: &esv1beta1.SecretStoreProvider{
+					Vault: &esv1beta1.VaultProvider{Version: version},
+				},
+			},
+		}
+	}
+	v1 := p.Features(makeStore(esv1beta1.VaultKVStoreV1))
+	if v1.FindByName || v1.Versions || v1.MetadataFetch || !v1.DeleteSecret {
+		t.Errorf("unexpected features of kv v1: %+v", v1)
+	}
+	v2 := p.Features(makeStore(esv1beta1.VaultKVStoreV2))
+	if !v2.FindByName || !v2.FindByTags || !v2.FindByPath || !v2.Versions || !v2.MetadataFetch || !v2.DeleteSecret {
+		t.Errorf("unexpected features of kv v2: %+v", v2)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/cache"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/util"
+)
+
+const (
+	// tokenExpiryMargin is the remaining lifetime below which a token is no longer handed out.
+	tokenExpiryMargin = 30 * time.Second
+	// tokenRevokeTimeout bounds the revocation of an evicted token.
+	tokenRevokeTimeout = 10 * time.Second
+)
+
+// tokens is the token manager, it is nil unless --enable-vault-token-manager is set.
+var tokens *tokenManager
+
+// tokenManager shares the Vault token of a store between all clients created for it.
+// Tokens are renewed in the background before they expire, so clients only log in
+// if there is no valid token. Tokens are revoked when they are evicted, which happens
+// when the store is deleted, its spec or the credentials it references change.
+type tokenManager struct {
+	tokens    *cache.Cache[*managedToken]
+	locks     sync.Map
+	newClient func(config *vault.Config) (util.Client, error)
+}
+
+// managedToken is a token owned by the token manager.
+// It has a dedicated client, so renewing the token does not interfere with the store clients.
+type managedToken struct {
+	token  string
+	client util.Client
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	expiry time.Time
+	failed bool
+}
+
+func newTokenManager(size int, newClient func(config *vault.Config) (util.Client, error)) *tokenManager {
+	return &tokenManager{
+		newClient: newClient,
+		tokens: cache.Must(size, func(t *managedToken) {
+			t.cancel()
+			ctx, cancel := context.WithTimeout(context.Background(), tokenRevokeTimeout)
+			defer cancel()
+			if err := revokeTokenIfValid(ctx, t.client); err != nil {
+				logger.Error(err, "unable to revoke managed token")
+			}
+		}),
+	}
+}
+
+// lock serializes the logins for the key, so concurrent reconciles do not log in more than once.
+func (m *tokenManager) lock(key cache.Key) func() {
+	mu, _ := m.locks.LoadOrStore(key, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// get returns the token for the key if it is still valid.
+// Tokens which expired or failed to renew are evicted.
+func (m *tokenManager) get(key cache.Key, version string) (string, bool) {
+	t, ok := m.tokens.Get(version, key)
+	if !ok {
+		return "", false
+	}
+	if t.valid(time.Now()) {
+		return t.token, true
+	}
+	m.tokens.RemoveFunc(func(k cache.Key) bool { return k == key })
+	return "", false
+}
+
+// manage takes over the token a client logged in with and renews it until it is evicted.
+func (m *tokenManager) manage(ctx context.Context, key cache.Key, version string, cfg *vault.Config, namespace, token string) error {
+	client, err := m.newClient(cfg)
+	if err != nil {
+		return err
+	}
+	client.SetToken(token)
+	if namespace != "" {
+		client.SetNamespace(namespace)
+	}
+	secret, err := client.AuthToken().LookupSelfWithContext(ctx)
+	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultLookupSelf, err)
+	if err != nil {
+		return err
+	}
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return err
+	}
+	renewable, err := secret.TokenIsRenewable()
+	if err != nil {
+		return err
+	}
+	renewCtx, cancel := context.WithCancel(context.Background())
+	t := &managedToken{
+		token:  token,
+		client: client,
+		cancel: cancel,
+	}
+	if ttl > 0 {
+		t.expiry = time.Now().Add(ttl)
+	}
+	// revokes the previous token of the key, Add would replace it silently
+	m.tokens.RemoveFunc(func(k cache.Key) bool { return k == key })
+	m.tokens.Add(version, key, t)
+	if renewable && ttl > 0 {
+		go t.renew(renewCtx, ttl)
+	}
+	return nil
+}
+
+func (t *managedToken) valid(now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return !t.failed && (t.expiry.IsZero() || now.Add(tokenExpiryMargin).Before(t.expiry))
+}
+
+// renew renews the token after two thirds of its lifetime, until it reaches its max TTL,
+// fails to renew or is evicted.
+func (t *managedToken) renew(ctx context.Context, ttl time.Duration) {
+	timer := time.NewTimer(ttl * 2 / 3)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		secret, err := t.client.AuthToken().RenewSelfWithContext(ctx, 0)
+		metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultRenewSelf, err)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil || secret == nil || secret.Auth == nil {
+			logger.Error(err, "unable to renew managed token, logging in again on next use")
+			t.mu.Lock()
+			t.failed = true
+			t.mu.Unlock()
+			return
+		}
+		ttl = time.Duration(secret.Auth.LeaseDuration) * time.Second
+		t.mu.Lock()
+		t.expiry = time.Now().Add(ttl)
+		t.mu.Unlock()
+		// the token reached its max TTL, it expires and clients log in again
+		if !secret.Auth.Renewable || ttl <= tokenExpiryMargin {
+			return
+		}
+		timer.Reset(ttl * 2 / 3)
+	}
+}
+
+// tokenKey returns the key of the token of the store.
+// Tokens of a ClusterSecretStore with referent authentication are specific to the namespace.
+func tokenKey(store esv1beta1.GenericStore, namespace string) cache.Key {
+	key := cache.Key{
+		Name:      store.GetName(),
+		Namespace: store.GetNamespace(),
+		Kind:      store.GetKind(),
+	}
+	if store.GetKind() == esv1beta1.ClusterSecretStoreKind && isReferentSpec(store.GetSpec().Provider.Vault) {
+		key.Namespace = namespace
+	}
+	return key
+}
+
+// tokenVersion returns the version of the token of the store, which changes with the provider spec.
+func tokenVersion(vaultSpec *esv1beta1.VaultProvider) (string, error) {
+	raw, err := json.Marshal(vaultSpec)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(raw)), nil
+}
+
+// setManagedAuth uses the token the manager holds for the store and logs in only if there is none.
+func (c *client) setManagedAuth(ctx context.Context, cfg *vault.Config, key cache.Key, version string) error {
+	unlock := tokens.lock(key)
+	defer unlock()
+	if token, ok := tokens.get(key, version); ok {
+		c.log.V(1).Info("Using managed token")
+		c.client.SetToken(token)
+		c.tokenManaged = true
+		return nil
+	}
+	if err := c.setAuth(ctx, cfg); err != nil {
+		return err
+	}
+	namespace := c.client.Namespace()
+	if c.store.Auth.Namespace != nil {
+		namespace = *c.store.Auth.Namespace
+	}
+	if err := tokens.manage(ctx, key, version, cfg, namespace, c.client.Token()); err != nil {
+		// the token is still used by this client and revoked on close
+		c.log.Error(err, "unable to manage token")
+		return nil
+	}
+	c.tokenManaged = true
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/cache"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/util"
+)
+
+// managedTokenClient returns a client for a token with the given ttl which counts its revocations.
+func managedTokenClient(ttl int, renewable bool, revoked *atomic.Int32) func(config *vault.Config) (util.Client, error) {
+	return func(config *vault.Config) (util.Client, error) {
+		token := ""
+		return &util.VaultClient{
+			SetTokenFunc:   func(v string) { token = v },
+			TokenFunc:      func() string { return token },
+			ClearTokenFunc: func() { token = "" },
+			AuthTokenField: fake.Token{
+				LookupSelfWithContextFn: func(ctx context.Context) (*vault.Secret, error) {
+					return &vault.Secret{Data: map[string]any{"type": "service", "ttl": ttl, "renewable": renewable}}, nil
+				},
+				RevokeSelfWithContextFn: func(ctx context.Context, token string) error {
+					revoked.Add(1)
+					return nil
+				},
+			},
+			SetNamespaceFunc: func(namespace string) {},
+		}, nil
+	}
+}
+
+func TestTokenManager(t *testing.T) {
+	var revoked atomic.Int32
+	m := newTokenManager(10, managedTokenClient(3600, false, &revoked))
+	key := cache.Key{Name: "vault", Namespace: "default", Kind: esv1beta1.SecretStoreKind}
+	ctx := context.Background()
+
+	_, ok := m.get(key, "v1")
+	assert.False(t, ok)
+
+	require.NoError(t, m.manage(ctx, key, "v1", nil, "", "t0k3n"))
+	token, ok := m.get(key, "v1")
+	assert.True(t, ok)
+	assert.Equal(t, "t0k3n", token)
+	assert.Equal(t, int32(0), revoked.Load())
+
+	// a new token of the same key replaces and revokes the previous one
+	require.NoError(t, m.manage(ctx, key, "v1", nil, "", "t0k3n2"))
+	assert.Equal(t, int32(1), revoked.Load())
+
+	// changing the spec revokes the token
+	_, ok = m.get(key, "v2")
+	assert.False(t, ok)
+	assert.Equal(t, int32(2), revoked.Load())
+
+	// deleting the store or changing its credentials revokes the token
+	require.NoError(t, m.manage(ctx, key, "v2", nil, "", "t0k3n3"))
+	cache.Invalidate(func(k cache.Key) bool { return k == key })
+	_, ok = m.get(key, "v2")
+	assert.False(t, ok)
+	assert.Equal(t, int32(3), revoked.Load())
+}
+
+func TestTokenManagerExpiry(t *testing.T) {
+	var revoked atomic.Int32
+	m := newTokenManager(10, managedTokenClient(10, false, &revoked))
+	key := cache.Key{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind}
+
+	// tokens about to expire are not handed out
+	require.NoError(t, m.manage(context.Background(), key, "v1", nil, "", "t0k3n"))
+	_, ok := m.get(key, "v1")
+	assert.False(t, ok)
+	assert.Equal(t, int32(1), revoked.Load())
+}
+
+func TestManagedTokenRenew(t *testing.T) {
+	renewClient := func(secret *vault.Secret, err error) util.Client {
+		return &util.VaultClient{
+			AuthTokenField: fake.Token{
+				RenewSelfWithContextFn: func(ctx context.Context, increment int) (*vault.Secret, error) {
+					return secret, err
+				},
+			},
+		}
+	}
+
+	// the token reached its max TTL, the renewal extends it one last time
+	mt := &managedToken{
+		token:  "t0k3n",
+		client: renewClient(&vault.Secret{Auth: &vault.SecretAuth{LeaseDuration: 3600}}, nil),
+		expiry: time.Now().Add(time.Minute),
+	}
+	mt.renew(context.Background(), time.Millisecond)
+	assert.True(t, mt.valid(time.Now().Add(time.Minute)))
+
+	// tokens which failed to renew are no longer handed out
+	mt = &managedToken{
+		token:  "t0k3n",
+		client: renewClient(nil, errors.New("permission denied")),
+		expiry: time.Now().Add(time.Hour),
+	}
+	mt.renew(context.Background(), time.Millisecond)
+	assert.False(t, mt.valid(time.Now()))
+
+	// the renewal stops if the token is evicted
+	mt = &managedToken{
+		token:  "t0k3n",
+		client: renewClient(nil, errors.New("permission denied")),
+		expiry: time.Now().Add(time.Hour),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mt.renew(ctx, time.Hour)
+	assert.True(t, mt.valid(time.Now()))
+}
+
+func TestTokenKey(t *testing.T) {
+	store := &esv1beta1.ClusterSecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Vault: &esv1beta1.VaultProvider{
+					Auth: esv1beta1.VaultAuth{
+						AppRole: &esv1beta1.VaultAppRole{
+							SecretRef: esmeta.SecretKeySelector{Name: "approle", Key: "secret-id"},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, cache.Key{Name: "vault", Namespace: "foo", Kind: esv1beta1.ClusterSecretStoreKind}, tokenKey(store, "foo"))
+
+	store.Spec.Provider.Vault.Auth.AppRole.SecretRef.Namespace = ptr.To("vault")
+	assert.Equal(t, cache.Key{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind}, tokenKey(store, "foo"))
+}
@@ -42,9 +42,13 @@ const (
 	errInvalidLdapSec         = "invalid Auth.Ldap.SecretRef: %w"
 	errInvalidTokenRef        = "invalid Auth.TokenSecretRef: %w"
 	errInvalidUserPassSec     = "invalid Auth.UserPass.SecretRef: %w"
+	errInvalidGCPSec          = "invalid Auth.GCP.SecretRef: %w"
+	errInvalidGCPSA           = "invalid Auth.GCP.WorkloadIdentity.ServiceAccountRef: %w"
+	errInvalidAzureSA         = "invalid Auth.Azure.ServiceAccountRef: %w"
 	errInvalidClientTLSCert   = "invalid ClientTLS.ClientCert: %w"
 	errInvalidClientTLSSecret = "invalid ClientTLS.SecretRef: %w"
 	errInvalidClientTLS       = "when provided, both ClientTLS.ClientCert and ClientTLS.SecretRef should be provided"
+	errInvalidCheckAndSet     = "CheckAndSet is only supported for KV v2"
 )
 
 func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
@@ -150,6 +154,23 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 			}
 		}
 	}
+	if vaultProvider.Auth.GCP != nil {
+		if vaultProvider.Auth.GCP.SecretRef != nil {
+			if err := utils.ValidateReferentSecretSelector(store, vaultProvider.Auth.GCP.SecretRef.SecretAccessKey); err != nil {
+				return nil, fmt.Errorf(errInvalidGCPSec, err)
+			}
+		}
+		if vaultProvider.Auth.GCP.WorkloadIdentity != nil {
+			if err := utils.ValidateReferentServiceAccountSelector(store, vaultProvider.Auth.GCP.WorkloadIdentity.ServiceAccountRef); err != nil {
+				return nil, fmt.Errorf(errInvalidGCPSA, err)
+			}
+		}
+	}
+	if vaultProvider.Auth.Azure != nil && vaultProvider.Auth.Azure.ServiceAccountRef != nil {
+		if err := utils.ValidateReferentServiceAccountSelector(store, *vaultProvider.Auth.Azure.ServiceAccountRef); err != nil {
+			return nil, fmt.Errorf(errInvalidAzureSA, err)
+		}
+	}
 	if vaultProvider.ClientTLS.CertSecretRef != nil && vaultProvider.ClientTLS.KeySecretRef != nil {
 		if err := utils.ValidateReferentSecretSelector(store, *vaultProvider.ClientTLS.CertSecretRef); err != nil {
 			return nil, fmt.Errorf(errInvalidClientTLSCert, err)
@@ -160,6 +181,9 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 	} else if vaultProvider.ClientTLS.CertSecretRef != nil || vaultProvider.ClientTLS.KeySecretRef != nil {
 		return nil, errors.New(errInvalidClientTLS)
 	}
+	if vaultProvider.CheckAndSet != nil && vaultProvider.Version == esv1beta1.VaultKVStoreV1 {
+		return nil, errors.New(errInvalidCheckAndSet)
+	}
 	return nil, nil
 }
 
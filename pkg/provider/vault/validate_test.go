@@ -26,8 +26,10 @@ const fakeValidationValue = "fake-value"
 
 func TestValidateStore(t *testing.T) {
 	type args struct {
-		auth      esv1beta1.VaultAuth
-		clientTLS esv1beta1.VaultClientTLS
+		auth        esv1beta1.VaultAuth
+		clientTLS   esv1beta1.VaultClientTLS
+		version     esv1beta1.VaultKVStoreVersion
+		checkAndSet *esv1beta1.VaultCheckAndSet
 	}
 
 	tests := []struct {
@@ -180,6 +182,35 @@ func TestValidateStore(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid gcp secret",
+			args: args{
+				auth: esv1beta1.VaultAuth{
+					GCP: &esv1beta1.VaultGCPAuth{
+						SecretRef: &esv1beta1.GCPSMAuthSecretRef{
+							SecretAccessKey: esmeta.SecretKeySelector{
+								Namespace: pointer.To("invalid"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid azure service account",
+			args: args{
+				auth: esv1beta1.VaultAuth{
+					Azure: &esv1beta1.VaultAzureAuth{
+						ServiceAccountRef: &esmeta.ServiceAccountSelector{
+							Name:      "eso",
+							Namespace: pointer.To("invalid"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid token secret",
 			args: args{
@@ -248,6 +279,22 @@ func TestValidateStore(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid checkAndSet with KV v2",
+			args: args{
+				version:     esv1beta1.VaultKVStoreV2,
+				checkAndSet: &esv1beta1.VaultCheckAndSet{Required: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid checkAndSet with KV v1",
+			args: args{
+				version:     esv1beta1.VaultKVStoreV1,
+				checkAndSet: &esv1beta1.VaultCheckAndSet{Required: true},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -258,8 +305,10 @@ func TestValidateStore(t *testing.T) {
 				Spec: esv1beta1.SecretStoreSpec{
 					Provider: &esv1beta1.SecretStoreProvider{
 						Vault: &esv1beta1.VaultProvider{
-							Auth:      tt.args.auth,
-							ClientTLS: tt.args.clientTLS,
+							Auth:        tt.args.auth,
+							ClientTLS:   tt.args.clientTLS,
+							Version:     tt.args.version,
+							CheckAndSet: tt.args.checkAndSet,
 						},
 					},
 				},
@@ -0,0 +1,32 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sprig provides the sprig template functions which are safe to offer to users of the controller.
+package sprig
+
+import (
+	tpl "text/template"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+// FuncMap returns the sprig text template functions without env and expandenv,
+// so templates can not read the environment of the controller.
+// A new map is returned on every call, callers may add their own functions.
+func FuncMap() tpl.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+	return funcs
+}
@@ -0,0 +1,37 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sprig
+
+import (
+	"testing"
+)
+
+func TestFuncMap(t *testing.T) {
+	funcs := FuncMap()
+	for _, name := range []string{"env", "expandenv"} {
+		if _, ok := funcs[name]; ok {
+			t.Errorf("FuncMap() contains %s", name)
+		}
+	}
+	if _, ok := funcs["upper"]; !ok {
+		t.Error("FuncMap() does not contain upper")
+	}
+
+	// callers may extend the map without changing the maps of other callers
+	funcs["env"] = func() string { return "" }
+	if _, ok := FuncMap()["env"]; ok {
+		t.Error("FuncMap() returned a shared map")
+	}
+}
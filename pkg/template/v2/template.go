@@ -19,11 +19,11 @@ import (
 	"fmt"
 	tpl "text/template"
 
-	"github.com/Masterminds/sprig/v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/template/sprig"
 )
 
 var tplFuncs = tpl.FuncMap{
@@ -60,11 +60,7 @@ const (
 )
 
 func init() {
-	sprigFuncs := sprig.TxtFuncMap()
-	delete(sprigFuncs, "env")
-	delete(sprigFuncs, "expandenv")
-
-	for k, v := range sprigFuncs {
+	for k, v := range sprig.FuncMap() {
 		tplFuncs[k] = v
 	}
 }
@@ -31,6 +31,7 @@ import (
 	"time"
 	"unicode"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -40,8 +41,16 @@ import (
 )
 
 const (
-	errParse   = "unable to parse transform template: %s"
-	errExecute = "unable to execute transform template: %s"
+	errParse       = "unable to parse transform template: %s"
+	errExecute     = "unable to execute transform template: %s"
+	errRemoteOwner = "remote secret %s is owned by %s"
+
+	// RemoteOwnerKey is the key of the tag, label or custom metadata
+	// which holds the PushSecret owning a remote secret.
+	RemoteOwnerKey = "owned-by"
+	// AdoptUnmanagedKey is the key of the PushSecret metadata which allows the Adopt update policy
+	// to take over remote secrets not managed by external-secrets.
+	AdoptUnmanagedKey = "adoptUnmanaged"
 )
 
 var (
@@ -58,6 +67,30 @@ func JSONMarshal(t any) ([]byte, error) {
 	return bytes.TrimRight(buffer.Bytes(), "\n"), err
 }
 
+// SecretDataToJSON serializes all keys of the Secret data as one JSON object with string values.
+// Providers use it to push a whole Secret as one remote secret, so that it is formatted the same way everywhere.
+func SecretDataToJSON(data map[string][]byte) ([]byte, error) {
+	values := make(map[string]string, len(data))
+	for k, v := range data {
+		values[k] = string(v)
+	}
+	value, err := JSONMarshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize secret content as JSON: %w", err)
+	}
+	return value, nil
+}
+
+// PushSecretValue returns the value to push for the PushSecretData. It is the value of the secret key,
+// or the whole Secret serialized with SecretDataToJSON if no secret key is set.
+// The keys of the Secret are already converted according to the ConversionStrategy of the PushSecretData.
+func PushSecretValue(secret *corev1.Secret, data esv1beta1.PushSecretData) ([]byte, error) {
+	if data.GetSecretKey() == "" {
+		return SecretDataToJSON(secret.Data)
+	}
+	return secret.Data[data.GetSecretKey()], nil
+}
+
 // MergeByteMap merges map of byte slices.
 func MergeByteMap(dst, src map[string][]byte) map[string][]byte {
 	for k, v := range src {
@@ -508,6 +541,55 @@ func dig[T any](key string, data map[string]any) (t T, _ error) {
 	return t, errKeyNotFound
 }
 
+// PushSecretOwner returns the owner of a PushSecretData or PushSecretRemoteRef
+// and whether it may adopt remote secrets owned by someone else.
+// The owner is empty if the ref does not implement esv1beta1.PushSecretOwner
+// or pushes a single property, as those remote secrets can be shared by several PushSecrets.
+func PushSecretOwner(ref any) (owner string, adopt bool) {
+	o, ok := ref.(esv1beta1.PushSecretOwner)
+	if !ok {
+		return "", false
+	}
+	if r, ok := ref.(esv1beta1.PushSecretRemoteRef); ok && r.GetProperty() != "" {
+		return "", o.GetAdopt()
+	}
+	return o.GetOwner(), o.GetAdopt()
+}
+
+// SetRemoteVersion records the version of the pushed remote secret
+// if the PushSecretData implements esv1beta1.PushSecretVersionRecorder.
+func SetRemoteVersion(data esv1beta1.PushSecretData, version string) {
+	if r, ok := data.(esv1beta1.PushSecretVersionRecorder); ok && version != "" {
+		r.SetRemoteVersion(version)
+	}
+}
+
+// IsRemoteOwner returns true if the remote secret has no owner or is owned by the PushSecret of the ref.
+// A ref without owner, like the push of a single property, does not own any remote secret.
+// Callers have to verify that the remote secret is managed by external-secrets on their own.
+func IsRemoteOwner(ref any, currentOwner string) bool {
+	owner, _ := PushSecretOwner(ref)
+	return currentOwner == "" || owner == currentOwner
+}
+
+// CheckRemoteOwner returns an error if the remote secret is owned by someone else
+// and the ref does not adopt it.
+func CheckRemoteOwner(ref any, remoteKey, currentOwner string) error {
+	if _, adopt := PushSecretOwner(ref); adopt || IsRemoteOwner(ref, currentOwner) {
+		return nil
+	}
+	return fmt.Errorf(errRemoteOwner, remoteKey, currentOwner)
+}
+
+// AdoptUnmanaged returns true if the PushSecretData may take over a remote secret not managed by external-secrets.
+// Besides the Adopt update policy this requires the adoptUnmanaged key to be set in the metadata of the data.
+func AdoptUnmanaged(data esv1beta1.PushSecretData) (bool, error) {
+	if _, adopt := PushSecretOwner(data); !adopt {
+		return false, nil
+	}
+	return FetchValueFromMetadata(AdoptUnmanagedKey, data.GetMetadata(), false)
+}
+
 func CompareStringAndByteSlices(valueString *string, valueByte []byte) bool {
 	if valueString == nil {
 		return false
@@ -904,3 +904,140 @@ func TestCompareStringAndByteSlices(t *testing.T) {
 		})
 	}
 }
+
+type ownedRef struct {
+	owner string
+	adopt bool
+}
+
+func (r ownedRef) GetOwner() string { return r.owner }
+func (r ownedRef) GetAdopt() bool   { return r.adopt }
+
+func TestCheckRemoteOwner(t *testing.T) {
+	tests := []struct {
+		name         string
+		ref          any
+		currentOwner string
+		wantErr      bool
+	}{
+		{
+			name:         "ref without owner",
+			ref:          esv1alpha1.PushSecretRemoteRef{RemoteKey: "key"},
+			currentOwner: "other/ns/name",
+			wantErr:      true,
+		},
+		{
+			name: "ref without owner and remote secret without owner",
+			ref:  esv1alpha1.PushSecretRemoteRef{RemoteKey: "key"},
+		},
+		{
+			name: "remote secret without owner",
+			ref:  ownedRef{owner: "cluster/ns/name"},
+		},
+		{
+			name:         "same owner",
+			ref:          ownedRef{owner: "cluster/ns/name"},
+			currentOwner: "cluster/ns/name",
+		},
+		{
+			name:         "different owner",
+			ref:          ownedRef{owner: "cluster/ns/name"},
+			currentOwner: "other/ns/name",
+			wantErr:      true,
+		},
+		{
+			name:         "different owner with adopt",
+			ref:          ownedRef{owner: "cluster/ns/name", adopt: true},
+			currentOwner: "other/ns/name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRemoteOwner(tt.ref, "key", tt.currentOwner)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckRemoteOwner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type ownedData struct {
+	esv1alpha1.PushSecretData
+	ownedRef
+}
+
+func TestAdoptUnmanaged(t *testing.T) {
+	optIn := &apiextensionsv1.JSON{Raw: []byte(`{"adoptUnmanaged": true}`)}
+	tests := []struct {
+		name     string
+		adopt    bool
+		metadata *apiextensionsv1.JSON
+		want     bool
+	}{
+		{
+			name:     "adopt with opt-in",
+			adopt:    true,
+			metadata: optIn,
+			want:     true,
+		},
+		{
+			name:  "adopt without opt-in",
+			adopt: true,
+		},
+		{
+			name:     "opt-in without adopt",
+			metadata: optIn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := ownedData{
+				PushSecretData: esv1alpha1.PushSecretData{Metadata: tt.metadata},
+				ownedRef:       ownedRef{owner: "ns/name", adopt: tt.adopt},
+			}
+			got, err := AdoptUnmanaged(data)
+			if err != nil {
+				t.Fatalf("AdoptUnmanaged() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AdoptUnmanaged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPushSecretValue(t *testing.T) {
+	secret := &v1.Secret{
+		Data: map[string][]byte{
+			"username": []byte("user"),
+			"password": []byte("p&ss<word>"),
+		},
+	}
+	tests := []struct {
+		name string
+		data esv1alpha1.PushSecretData
+		want string
+	}{
+		{
+			name: "secret key",
+			data: esv1alpha1.PushSecretData{Match: esv1alpha1.PushSecretMatch{SecretKey: "username"}},
+			want: "user",
+		},
+		{
+			name: "whole secret",
+			data: esv1alpha1.PushSecretData{},
+			want: `{"password":"p&ss<word>","username":"user"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PushSecretValue(secret, tt.data)
+			if err != nil {
+				t.Fatalf("PushSecretValue() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("PushSecretValue() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}